@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"os/user"
+	"syscall"
+
+	"github.com/dijiacoder/staking-indexer/internal/config"
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/rescan"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// app 各子命令共享的配置、数据库连接与仓储
+type app struct {
+	cfg  *config.Config
+	db   *gorm.DB
+	repo repository.ScannerRepository
+}
+
+func newApp(configPath string) (*app, error) {
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	db, err := gorm.Open(mysql.Open(cfg.Database.DSN), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+	if cfg.Database.Debug {
+		db = db.Debug()
+	}
+
+	return &app{
+		cfg:  cfg,
+		db:   db,
+		repo: repository.NewScannerRepository(db),
+	}, nil
+}
+
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s %s %s\n\n", os.Args[0], name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func configFlag(fs *flag.FlagSet) *string {
+	return fs.String("config", "config/config.toml", "path to config file")
+}
+
+// contractFlags 选择操作的合约，只配置了一条链或一个合约时可以省略
+type contractFlags struct {
+	chainID *int64
+	address *string
+}
+
+func addContractFlags(fs *flag.FlagSet) contractFlags {
+	return contractFlags{
+		chainID: fs.Int64("chain", 0, "chain id, optional when a single chain is configured"),
+		address: fs.String("contract", "", "contract address, optional when the chain has a single contract"),
+	}
+}
+
+// resolveContract 按命令行参数在配置中查找链和合约
+func (a *app) resolveContract(f contractFlags) (config.Chain, config.Contract, error) {
+	chains := a.cfg.ChainList()
+	var chain config.Chain
+	switch {
+	case *f.chainID != 0:
+		found := false
+		for _, c := range chains {
+			if c.ChainID == *f.chainID {
+				chain, found = c, true
+				break
+			}
+		}
+		if !found {
+			return config.Chain{}, config.Contract{}, fmt.Errorf("chain %d is not configured", *f.chainID)
+		}
+	case len(chains) == 1:
+		chain = chains[0]
+	default:
+		return config.Chain{}, config.Contract{}, errors.New("multiple chains configured, use --chain")
+	}
+
+	switch {
+	case *f.address != "":
+		contract, ok := rescan.FindContract(chain, *f.address)
+		if !ok {
+			return config.Chain{}, config.Contract{}, fmt.Errorf("contract %s is not configured on chain %d", *f.address, chain.ChainID)
+		}
+		return chain, contract, nil
+	case len(chain.Contracts) == 1:
+		return chain, chain.Contracts[0], nil
+	default:
+		return config.Chain{}, config.Contract{}, fmt.Errorf("chain %d has %d contracts, use --contract", chain.ChainID, len(chain.Contracts))
+	}
+}
+
+func (a *app) dial(ctx context.Context, chain config.Chain) (*ethclient.Client, error) {
+	client, err := ethclient.DialContext(ctx, chain.RPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("connect to rpc: %w", err)
+	}
+	return client, nil
+}
+
+// audit 与管理接口共用 admin_audit_logs，操作人记为 cli:<系统用户>
+func (a *app) audit(action string, chainID int64, contractAddress string, params any, actionErr error) {
+	actor := "cli"
+	if u, err := user.Current(); err == nil {
+		actor = "cli:" + u.Username
+	}
+	host, _ := os.Hostname()
+
+	entry := &model.AdminAuditLog{
+		Actor:           actor,
+		Action:          action,
+		ChainID:         chainID,
+		ContractAddress: contractAddress,
+		Params:          "{}",
+		Result:          repository.AuditResultOK,
+		RemoteAddr:      host,
+	}
+	if b, err := json.Marshal(params); err == nil && params != nil {
+		entry.Params = string(b)
+	}
+	if actionErr != nil {
+		entry.Result = repository.AuditResultError
+		entry.Error = actionErr.Error()
+	}
+	if err := repository.NewAdminRepository(a.db).WriteAudit(context.Background(), entry); err != nil {
+		logger.Logger.Error("Failed to write audit log", zap.String("action", action), zap.Error(err))
+	}
+}
+
+// signalContext 收到 SIGINT / SIGTERM 时取消
+func signalContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		select {
+		case sig := <-sigChan:
+			logger.Logger.Info("Received signal. Initiating graceful shutdown...",
+				zap.String("signal", sig.String()))
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(sigChan)
+		cancel()
+	}
+}
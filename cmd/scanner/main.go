@@ -1,96 +1,67 @@
 package main
 
 import (
-	"context"
 	"errors"
 	"flag"
 	"fmt"
-	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
-
-	"github.com/dijiacoder/staking-indexer/internal/config"
-	"github.com/dijiacoder/staking-indexer/internal/logger"
-	"github.com/dijiacoder/staking-indexer/internal/repository"
-	"github.com/dijiacoder/staking-indexer/internal/service/scanner"
-	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"go.uber.org/zap"
-	"gorm.io/driver/mysql"
-	"gorm.io/gorm"
+	"strings"
 )
 
-func main() {
-	// 1. Initialize Configuration from file
-	configPath := flag.String("config", "config/config.toml", "path to config file")
-	flag.Parse()
-
-	cfg, err := config.LoadConfig(*configPath)
-	if err != nil {
-		logger.Logger.Fatal("Failed to load config", zap.Error(err))
-	}
-
-	// 2. Initialize Database Connection
-	db, err := gorm.Open(mysql.Open(cfg.Database.DSN), &gorm.Config{})
-	if err != nil {
-		logger.Logger.Fatal("Failed to connect to database", zap.Error(err))
-	}
+// command 子命令，共享 --config 加载与依赖装配
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
 
-	if cfg.Database.Debug {
-		db = db.Debug()
-	}
+var commands = []command{
+	{name: "scan", usage: "运行扫描循环（默认）", run: runScan},
+	{name: "backfill", usage: "重新拉取已确认区间的日志，幂等补录缺失事件", run: runBackfill},
+	{name: "reindex", usage: "回滚指定区块之后的数据并重放到原确认高度", run: runReindex},
+	{name: "rebuild", usage: "清空用户持仓与快照，按顺序重放 staking_events 与归档日志重建，不访问 RPC", run: runRebuild},
+	{name: "redecode", usage: "用归档的原始日志重新运行事件处理器，补齐新增或修复的事件，不访问 RPC", run: runRedecode},
+	{name: "verify", usage: "对比区间内链上日志与已索引事件", run: runVerify},
+	{name: "reconcile", usage: "在最近确认区块通过 eth_call 对账质押池与用户持仓，可选按链上值修复", run: runReconcile},
+	{name: "status", usage: "查看各合约游标、同步延迟与最近一次重组", run: runStatus},
+	{name: "cursor", usage: "暂停、恢复合约扫描或直接设置游标（pause / resume / set）", run: runCursor},
+}
 
-	// 3. Initialize Ethereum RPC Client
-	client, err := ethclient.Dial(cfg.Ethereum.RPCURL)
-	if err != nil {
-		logger.Logger.Fatal("Failed to connect to Ethereum RPC", zap.Error(err))
+func main() {
+	args := os.Args[1:]
+	name := "scan"
+	// 兼容没有子命令的调用方式：staking-scanner --config=...
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
 	}
-
-	// 4. Wire Dependencies (Repository & Service)
-	repo := repository.NewScannerRepository(db)
-	svc, err := scanner.NewScannerService(
-		repo,
-		client,
-		cfg,
-	)
-	if err != nil {
-		logger.Logger.Fatal("Failed to create scanner service", zap.Error(err))
+	if name == "help" {
+		usage()
+		return
 	}
 
-	// 5. 启动 Prometheus 监控端点
-	if cfg.Prometheus.Enabled {
-		go func() {
-			http.Handle("/metrics", promhttp.Handler())
-			addr := fmt.Sprintf(":%d", cfg.Prometheus.Port)
-			logger.Logger.Info("Starting Prometheus metrics server", zap.String("address", addr))
-			if err := http.ListenAndServe(addr, nil); err != nil && err != http.ErrServerClosed {
-				logger.Logger.Error("Prometheus server error", zap.Error(err))
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+		if err := c.run(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				os.Exit(2)
 			}
-		}()
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			os.Exit(1)
+		}
+		return
 	}
 
-	// 6. Setup Context and Signal Handling for Graceful Shutdown
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-
-	go func() {
-		sig := <-sigChan
-		logger.Logger.Info("Received signal. Initiating graceful shutdown...",
-			zap.String("signal", sig.String()))
-		cancel()
-	}()
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+	usage()
+	os.Exit(2)
+}
 
-	// 7. Execute Scanner Service
-	logger.Logger.Info("ZeroToken Stake Scanner started",
-		zap.Int64("chain_id", cfg.Ethereum.ChainID),
-		zap.String("contract", cfg.Ethereum.ContractAddr))
-	if err := svc.Start(ctx); err != nil && !errors.Is(err, context.Canceled) {
-		logger.Logger.Fatal("Scanner execution error", zap.Error(err))
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> [flags]\n\nCommands:\n", os.Args[0])
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", c.name, c.usage)
 	}
-
-	logger.Logger.Info("Scanner stopped")
+	fmt.Fprintf(os.Stderr, "\nRun '%s <command> -h' for command flags.\n", os.Args[0])
 }
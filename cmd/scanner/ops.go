@@ -0,0 +1,335 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/rebuild"
+	"github.com/dijiacoder/staking-indexer/internal/service/rescan"
+)
+
+// runBackfill 重新拉取 [from, to] 的日志写入，合约需要先暂停，已存在且未变化的事件不会重复计入持仓
+func runBackfill(args []string) error {
+	fs := newFlagSet("backfill", "--from N --to M [--chain ID] [--contract ADDR]")
+	configPath := configFlag(fs)
+	cf := addContractFlags(fs)
+	from := fs.Int64("from", -1, "first block to backfill (inclusive)")
+	to := fs.Int64("to", -1, "last block to backfill (inclusive), must be confirmed")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *from < 0 || *to < *from {
+		return fmt.Errorf("invalid block range %d-%d", *from, *to)
+	}
+
+	a, err := newApp(*configPath)
+	if err != nil {
+		return err
+	}
+	chain, contract, err := a.resolveContract(cf)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	cursor, err := a.repo.GetCursor(ctx, chain.ChainID, contract.Address)
+	if err != nil {
+		return fmt.Errorf("get cursor: %w", err)
+	}
+	if repository.ScanStatusName(cursor.ScanStatus) != "paused" {
+		return errors.New("cursor is not paused, run 'cursor pause' first")
+	}
+	if *to > cursor.LastConfirmedBlock {
+		return fmt.Errorf("to %d is above last confirmed block %d", *to, cursor.LastConfirmedBlock)
+	}
+
+	client, err := a.dial(ctx, chain)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	err = rescan.ScanRange(ctx, a.repo, client, chain, contract, *from, *to, progressPrinter(*from, *to))
+	if err == nil {
+		// 补录的事件可能落在已生成的快照之前
+		err = a.repo.DiscardSnapshots(ctx, chain.ChainID, contract.Address, *from-1)
+	}
+	a.audit("backfill", chain.ChainID, contract.Address, map[string]int64{"from_block": *from, "to_block": *to}, err)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("backfilled %s on chain %d: blocks %d-%d\n", contract.Address, chain.ChainID, *from, *to)
+	return nil
+}
+
+// runReindex 暂停合约，回滚 from 之后的事件、持仓与快照，重放到原来的确认高度后恢复扫描。
+// 原确认高度之后的区块由扫描器在恢复后重新扫描
+func runReindex(args []string) error {
+	fs := newFlagSet("reindex", "--from N [--chain ID] [--contract ADDR] [--keep-paused]")
+	configPath := configFlag(fs)
+	cf := addContractFlags(fs)
+	from := fs.Int64("from", -1, "first block to reindex")
+	keepPaused := fs.Bool("keep-paused", false, "leave the contract paused after replaying")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *from < 1 {
+		return errors.New("--from must be at least 1")
+	}
+
+	a, err := newApp(*configPath)
+	if err != nil {
+		return err
+	}
+	chain, contract, err := a.resolveContract(cf)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	cursor, err := a.repo.GetCursor(ctx, chain.ChainID, contract.Address)
+	if err != nil {
+		return fmt.Errorf("get cursor: %w", err)
+	}
+	status := repository.ScanStatusName(cursor.ScanStatus)
+	if status == "rolling_back" {
+		return errors.New("cursor is rolling back, recover it before reindexing")
+	}
+	if *from > cursor.LastScannedBlock {
+		return fmt.Errorf("from %d is above last scanned block %d", *from, cursor.LastScannedBlock)
+	}
+	replayTo := cursor.LastConfirmedBlock
+	wasPaused := status == "paused"
+
+	err = func() error {
+		if !wasPaused {
+			if err := a.repo.UpdateScanStatus(ctx, chain.ChainID, contract.Address, repository.ScanStatusPaused); err != nil {
+				return fmt.Errorf("pause: %w", err)
+			}
+		}
+		if err := a.repo.RewindCursor(ctx, chain.ChainID, contract.Address, *from-1); err != nil {
+			return fmt.Errorf("rewind: %w", err)
+		}
+		fmt.Printf("rolled back %s on chain %d to block %d\n", contract.Address, chain.ChainID, *from-1)
+
+		if *from <= replayTo {
+			client, err := a.dial(ctx, chain)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			if err := rescan.ScanRange(ctx, a.repo, client, chain, contract, *from, replayTo, progressPrinter(*from, replayTo)); err != nil {
+				return fmt.Errorf("replay: %w", err)
+			}
+			if err := a.repo.SetCursor(ctx, chain.ChainID, contract.Address, replayTo, replayTo); err != nil {
+				return fmt.Errorf("set cursor: %w", err)
+			}
+		}
+
+		if !wasPaused && !*keepPaused {
+			if err := a.repo.UpdateScanStatus(ctx, chain.ChainID, contract.Address, repository.ScanStatusNormal); err != nil {
+				return fmt.Errorf("resume: %w", err)
+			}
+		}
+		return nil
+	}()
+	a.audit("reindex", chain.ChainID, contract.Address, map[string]int64{"from_block": *from, "replay_to": replayTo}, err)
+	if err != nil {
+		return fmt.Errorf("%w (the contract is left paused)", err)
+	}
+	fmt.Printf("reindexed %s on chain %d: blocks %d-%d replayed\n", contract.Address, chain.ChainID, *from, replayTo)
+	return nil
+}
+
+// runCursor 处理 cursor pause / resume / set
+func runCursor(args []string) error {
+	if len(args) == 0 {
+		return errors.New("expected pause, resume or set")
+	}
+	action, args := args[0], args[1:]
+
+	fs := newFlagSet("cursor "+action, "[--chain ID] [--contract ADDR]")
+	configPath := configFlag(fs)
+	cf := addContractFlags(fs)
+	var block, confirmed *int64
+	switch action {
+	case "pause", "resume":
+	case "set":
+		fs.Usage = func() {
+			fmt.Fprintf(fs.Output(), "Usage: %s cursor set --block N [--confirmed M] [--chain ID] [--contract ADDR]\n", os.Args[0])
+			fmt.Fprintln(fs.Output(), "\nThe contract must be paused. Indexed data is not modified.")
+			fs.PrintDefaults()
+		}
+		block = fs.Int64("block", -1, "new last_scanned_block")
+		confirmed = fs.Int64("confirmed", -1, "new last_confirmed_block, defaults to --block")
+	default:
+		return fmt.Errorf("unknown cursor action %q, expected pause, resume or set", action)
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	a, err := newApp(*configPath)
+	if err != nil {
+		return err
+	}
+	chain, contract, err := a.resolveContract(cf)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	var params any
+	switch action {
+	case "pause":
+		err = a.repo.UpdateScanStatus(ctx, chain.ChainID, contract.Address, repository.ScanStatusPaused)
+	case "resume":
+		err = a.repo.UpdateScanStatus(ctx, chain.ChainID, contract.Address, repository.ScanStatusNormal)
+	case "set":
+		if *confirmed < 0 {
+			*confirmed = *block
+		}
+		if *block < 0 || *confirmed > *block {
+			return fmt.Errorf("invalid cursor: block %d, confirmed %d", *block, *confirmed)
+		}
+		params = map[string]int64{"last_scanned_block": *block, "last_confirmed_block": *confirmed}
+		err = a.repo.SetCursor(ctx, chain.ChainID, contract.Address, *block, *confirmed)
+		if errors.Is(err, repository.ErrCursorNotPaused) {
+			err = errors.New("cursor is not paused, run 'cursor pause' first")
+		}
+		action = "cursor_set"
+	}
+	a.audit(action, chain.ChainID, contract.Address, params, err)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := a.repo.GetCursor(ctx, chain.ChainID, contract.Address)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s on chain %d: status=%s last_scanned_block=%d last_confirmed_block=%d\n",
+		contract.Address, chain.ChainID, repository.ScanStatusName(cursor.ScanStatus),
+		cursor.LastScannedBlock, cursor.LastConfirmedBlock)
+	return nil
+}
+
+func progressPrinter(from, to int64) func(block int64) error {
+	return func(block int64) error {
+		fmt.Printf("processed %d/%d blocks (at %d)\n", block-from+1, to-from+1, block)
+		return nil
+	}
+}
+
+// runRebuild 暂停合约，清空用户持仓与持仓快照后按顺序重放 staking_events，不访问 RPC。
+// 重建前已暂停的合约保持暂停
+func runRebuild(args []string) error {
+	fs := newFlagSet("rebuild", "[--chain ID] [--contract ADDR] [--keep-paused]")
+	configPath := configFlag(fs)
+	cf := addContractFlags(fs)
+	keepPaused := fs.Bool("keep-paused", false, "leave the contract paused after rebuilding")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	a, err := newApp(*configPath)
+	if err != nil {
+		return err
+	}
+	chain, contract, err := a.resolveContract(cf)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	cursor, err := a.repo.GetCursor(ctx, chain.ChainID, contract.Address)
+	if err != nil {
+		return fmt.Errorf("get cursor: %w", err)
+	}
+	status := repository.ScanStatusName(cursor.ScanStatus)
+	if status == "rolling_back" {
+		return errors.New("cursor is rolling back, recover it before rebuilding")
+	}
+	wasPaused := status == "paused"
+
+	var result *rebuild.Result
+	err = func() error {
+		if !wasPaused {
+			if err := a.repo.UpdateScanStatus(ctx, chain.ChainID, contract.Address, repository.ScanStatusPaused); err != nil {
+				return fmt.Errorf("pause: %w", err)
+			}
+		}
+		var err error
+		result, err = rebuild.NewRebuilder(a.db).Rebuild(ctx, chain.ChainID, contract.Address, func(logs int, block int64) {
+			fmt.Printf("replayed %d logs (at block %d)\n", logs, block)
+		})
+		if err != nil {
+			return err
+		}
+		if !wasPaused && !*keepPaused {
+			if err := a.repo.UpdateScanStatus(ctx, chain.ChainID, contract.Address, repository.ScanStatusNormal); err != nil {
+				return fmt.Errorf("resume: %w", err)
+			}
+		}
+		return nil
+	}()
+	a.audit("rebuild", chain.ChainID, contract.Address, result, err)
+	if err != nil {
+		return fmt.Errorf("%w (the contract is left paused)", err)
+	}
+	fmt.Printf("rebuilt %s on chain %d: %d events and %d archived logs replayed up to block %d, cursor at %d (confirmed %d)\n",
+		contract.Address, chain.ChainID, result.Events, result.RawLogs, result.LastEventBlock,
+		result.LastScannedBlock, result.LastConfirmedBlock)
+	return nil
+}
+
+// runRedecode 把已确认区间内的归档日志重新交给事件处理器，新增或修复处理器后补齐历史事件，不访问 RPC
+func runRedecode(args []string) error {
+	fs := newFlagSet("redecode", "--from N --to M [--events Deposit,Claim] [--chain ID] [--contract ADDR]")
+	configPath := configFlag(fs)
+	cf := addContractFlags(fs)
+	from := fs.Int64("from", -1, "first block to decode (inclusive)")
+	to := fs.Int64("to", -1, "last block to decode (inclusive), must be confirmed")
+	events := fs.String("events", "", "comma separated events to decode, defaults to the contract's handlers")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *from < 0 || *to < *from {
+		return fmt.Errorf("invalid block range %d-%d", *from, *to)
+	}
+
+	a, err := newApp(*configPath)
+	if err != nil {
+		return err
+	}
+	chain, contract, err := a.resolveContract(cf)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	handlers := contract.Handlers
+	if *events != "" {
+		handlers = strings.Split(*events, ",")
+	}
+	count, err := rebuild.Redecode(ctx, a.repo, chain.ChainID, contract.Address, *from, *to, handlers, func(logs int, block int64) {
+		fmt.Printf("decoded %d logs (at block %d)\n", logs, block)
+	})
+	if errors.Is(err, repository.ErrCursorNotPaused) {
+		err = errors.New("cursor is not paused, run 'cursor pause' first")
+	}
+	a.audit("redecode", chain.ChainID, contract.Address, map[string]any{"from_block": *from, "to_block": *to, "events": handlers}, err)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("redecoded %s on chain %d: %d archived logs in blocks %d-%d\n", contract.Address, chain.ChainID, count, *from, *to)
+	return nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/reconcile"
+)
+
+// runReconcile 在最近确认区块对账合约的质押池和用户持仓，--repair 时按链上值修复并写入审计
+func runReconcile(args []string) error {
+	fs := newFlagSet("reconcile", "[--all | --sample N] [--repair] [--json] [--chain ID] [--contract ADDR]")
+	configPath := configFlag(fs)
+	cf := addContractFlags(fs)
+	all := fs.Bool("all", false, "check every user position instead of a sample")
+	sample := fs.Int("sample", -1, "number of user positions to check, defaults to reconcile.sample_size")
+	repair := fs.Bool("repair", false, "repair staked amounts and pool parameters from on-chain values")
+	asJSON := fs.Bool("json", false, "print JSON instead of a table")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	a, err := newApp(*configPath)
+	if err != nil {
+		return err
+	}
+	chain, contract, err := a.resolveContract(cf)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	opts := reconcile.Options{
+		SampleSize: a.cfg.Reconcile.SampleSize,
+		AutoRepair: *repair,
+		Tolerance:  a.cfg.Reconcile.Tolerance,
+	}
+	if *sample >= 0 {
+		opts.SampleSize = *sample
+	}
+	if *all {
+		opts.SampleSize = 0
+	}
+
+	reconciler := reconcile.NewReconciler(repository.NewReconcileRepository(a.db), a.repo, a.cfg.ChainList(), a.cfg.Reconcile)
+	defer reconciler.Close()
+	report, err := reconciler.ReconcileContract(ctx, chain, contract.Address, opts)
+	if *repair {
+		params := map[string]any{"sample_size": opts.SampleSize}
+		if report != nil {
+			params["block_number"] = report.BlockNumber
+			params["repaired"] = report.Repaired
+		}
+		a.audit("reconcile", chain.ChainID, contract.Address, params, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	fmt.Printf("reconciled %s on chain %d at block %d: %d pools, %d positions, %d drifts, %d repaired\n",
+		contract.Address, chain.ChainID, report.BlockNumber, report.Pools, report.Positions, len(report.Drifts), report.Repaired)
+	if len(report.Drifts) == 0 {
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "POOL\tUSER\tFIELD\tINDEXED\tCHAIN\tREPAIRED")
+	for _, d := range report.Drifts {
+		user := d.UserAddress
+		if user == "" {
+			user = "-"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%t\n", d.PoolID, user, d.Field, d.IndexedValue, d.ChainValue, d.Repaired == 1)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/admin"
+	"github.com/dijiacoder/staking-indexer/internal/api"
+	"github.com/dijiacoder/staking-indexer/internal/grpcserver"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/broadcast"
+	"github.com/dijiacoder/staking-indexer/internal/service/health"
+	"github.com/dijiacoder/staking-indexer/internal/service/outbox"
+	"github.com/dijiacoder/staking-indexer/internal/service/reconcile"
+	"github.com/dijiacoder/staking-indexer/internal/service/rescan"
+	"github.com/dijiacoder/staking-indexer/internal/service/reward"
+	"github.com/dijiacoder/staking-indexer/internal/service/scanner"
+	"github.com/dijiacoder/staking-indexer/internal/service/sink"
+	"github.com/dijiacoder/staking-indexer/internal/service/webhook"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+// runScan 运行扫描循环以及配置中开启的查询、推送与管理接口，阻塞直到收到退出信号
+func runScan(args []string) error {
+	fs := newFlagSet("scan", "")
+	configPath := configFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// 1-2. Initialize configuration and database connection
+	a, err := newApp(*configPath)
+	if err != nil {
+		return err
+	}
+	cfg, db := a.cfg, a.db
+
+	// 3. Wire Dependencies (Repository & Supervisor)
+	// 每条链在 supervisor 中拥有独立的 RPC 客户端和扫描器
+	repo := a.repo
+	// 开启推送接口时，扫描器在区块提交后向广播器发布事件
+	var broadcaster *broadcast.Broadcaster
+	if cfg.API.Enabled || cfg.GRPC.Enabled {
+		broadcaster = broadcast.NewBroadcaster(0)
+	}
+	supervisor, err := scanner.NewSupervisor(repo, cfg.ChainList(), broadcaster)
+	if err != nil {
+		logger.Logger.Fatal("Failed to create scanner supervisor", zap.Error(err))
+	}
+
+	// 存活与就绪检查同时挂载在监控端口和 API 端口
+	checker := health.NewChecker(db, repo, supervisor, cfg.ChainList(), cfg.Health)
+	defer checker.Close()
+
+	// 4. 启动 Prometheus 监控端点
+	if cfg.Prometheus.Enabled {
+		go func() {
+			http.Handle("/metrics", promhttp.Handler())
+			http.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(supervisor.Status())
+			})
+			http.HandleFunc("GET /healthz", checker.ServeHealth)
+			http.HandleFunc("GET /readyz", checker.ServeReady)
+			addr := fmt.Sprintf(":%d", cfg.Prometheus.Port)
+			logger.Logger.Info("Starting Prometheus metrics server", zap.String("address", addr))
+			if err := http.ListenAndServe(addr, nil); err != nil && err != http.ErrServerClosed {
+				logger.Logger.Error("Prometheus server error", zap.Error(err))
+			}
+		}()
+	}
+
+	// 5. Setup Context and Signal Handling for Graceful Shutdown
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	// 启动只读查询接口（REST、GraphQL 与 SSE 推送）
+	if cfg.API.Enabled {
+		rewardRepo := repository.NewRewardRepository(db)
+		go syncRewardParams(ctx, a, rewardRepo)
+		apiServer, err := api.NewServer(repository.NewStakingQueryRepository(db), broadcaster, reward.NewEngine(rewardRepo))
+		if err != nil {
+			logger.Logger.Fatal("Failed to create API server", zap.Error(err))
+		}
+		apiServer.Handle("GET /healthz", http.HandlerFunc(checker.ServeHealth))
+		apiServer.Handle("GET /readyz", http.HandlerFunc(checker.ServeReady))
+		go func() {
+			if err := apiServer.ListenAndServe(ctx, cfg.API.Port); err != nil {
+				logger.Logger.Error("API server error", zap.Error(err))
+			}
+		}()
+	}
+
+	// 启动 gRPC 查询与事件推送接口
+	if cfg.GRPC.Enabled {
+		grpcServer := grpcserver.NewServer(repository.NewStakingQueryRepository(db), broadcaster)
+		go func() {
+			if err := grpcServer.ListenAndServe(ctx, cfg.GRPC.Port); err != nil {
+				logger.Logger.Error("gRPC server error", zap.Error(err))
+			}
+		}()
+	}
+
+	// 启动管理接口与重扫任务执行器，每个操作写入审计表
+	if cfg.Admin.Enabled {
+		adminRepo := repository.NewAdminRepository(db)
+		adminServer, err := admin.NewServer(adminRepo, repo, cfg.ChainList(), cfg.Admin.Tokens)
+		if err != nil {
+			logger.Logger.Fatal("Failed to create admin server", zap.Error(err))
+		}
+		runner := rescan.NewRunner(adminRepo, repo, cfg.ChainList(), time.Duration(cfg.Admin.JobPollInterval)*time.Second)
+		go func() {
+			if err := adminServer.ListenAndServe(ctx, cfg.Admin.Port); err != nil {
+				logger.Logger.Error("Admin server error", zap.Error(err))
+			}
+		}()
+		go func() {
+			if err := runner.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				logger.Logger.Error("Rescan runner error", zap.Error(err))
+			}
+		}()
+	}
+
+	// 启动链上对账，定期比对合约视图函数与索引数据
+	if cfg.Reconcile.Enabled {
+		reconciler := reconcile.NewReconciler(repository.NewReconcileRepository(db), repo, cfg.ChainList(), cfg.Reconcile)
+		go func() {
+			if err := reconciler.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				logger.Logger.Error("Reconciler error", zap.Error(err))
+			}
+		}()
+	}
+
+	// 发件箱与事件在同一事务写入，webhook 与事件输出各自按进度消费
+	outboxRepo := repository.NewOutboxRepository(db)
+	chainIDs := make([]int64, 0, len(cfg.ChainList()))
+	for _, chain := range cfg.ChainList() {
+		chainIDs = append(chainIDs, chain.ChainID)
+	}
+	startRelay := func(consumer string, handler outbox.Handler) {
+		relay := outbox.NewRelay(outboxRepo, consumer, chainIDs, handler,
+			time.Duration(cfg.Outbox.PollInterval)*time.Second, cfg.Outbox.BatchSize,
+			time.Duration(cfg.Outbox.GapTimeout)*time.Second)
+		if err := relay.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			logger.Logger.Error("Outbox relay error", zap.String("consumer", consumer), zap.Error(err))
+		}
+	}
+	if cfg.Outbox.RetentionHours > 0 {
+		pruner := outbox.NewPruner(outboxRepo, time.Duration(cfg.Outbox.RetentionHours)*time.Hour)
+		go func() {
+			_ = pruner.Run(ctx)
+		}()
+	}
+
+	// 启动 webhook 投递：dispatcher 写入投递队列，worker 签名投递并重试
+	if cfg.Webhook.Enabled {
+		webhookRepo := repository.NewWebhookRepository(db)
+		dispatcher := webhook.NewDispatcher(webhookRepo)
+		worker := webhook.NewWorker(webhookRepo,
+			time.Duration(cfg.Webhook.PollInterval)*time.Second,
+			time.Duration(cfg.Webhook.Timeout)*time.Second,
+			cfg.Webhook.MaxAttempts,
+		)
+		go startRelay(dispatcher.Consumer(), dispatcher.Handle)
+		go func() {
+			if err := worker.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				logger.Logger.Error("Webhook worker error", zap.Error(err))
+			}
+		}()
+	}
+
+	// 启动事件输出，把解码后的事件和重组回滚写入配置的 sink
+	for _, sinkCfg := range cfg.Sinks {
+		s, err := sink.New(sinkCfg)
+		if err != nil {
+			logger.Logger.Fatal("Failed to create sink", zap.String("type", sinkCfg.Type), zap.Error(err))
+		}
+		publisher := sink.NewPublisher(s)
+		go func() {
+			defer publisher.Close()
+			startRelay(publisher.Consumer(), publisher.Handle)
+		}()
+	}
+
+	// 6. Execute Scanner Supervisor
+	logger.Logger.Info("ZeroToken Stake Scanner started",
+		zap.Int("chains", len(cfg.ChainList())))
+	if err := supervisor.Start(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("scanner execution: %w", err)
+	}
+
+	logger.Logger.Info("Scanner stopped")
+	return nil
+}
+
+// syncRewardParams 在合约的 start_block 读取奖励参数，之后的修改由奖励引擎从归档日志重放。
+// 读取失败只记录警告，奖励查询在同步之前返回 404
+func syncRewardParams(ctx context.Context, a *app, repo repository.RewardRepository) {
+	for _, chain := range a.cfg.ChainList() {
+		var client *ethclient.Client
+		for _, contract := range chain.Contracts {
+			if contract.StartBlock <= 0 {
+				logger.Logger.Warn("Skip reward params sync without start_block",
+					zap.Int64("chain_id", chain.ChainID), zap.String("contract", contract.Address))
+				continue
+			}
+			if client == nil {
+				var err error
+				if client, err = a.dial(ctx, chain); err != nil {
+					logger.Logger.Warn("Failed to sync reward params", zap.Int64("chain_id", chain.ChainID), zap.Error(err))
+					break
+				}
+				defer client.Close()
+			}
+			params, err := reward.SyncParams(ctx, repo, client, chain.ChainID, contract.Address, contract.StartBlock)
+			if err != nil {
+				logger.Logger.Warn("Failed to sync reward params", zap.Int64("chain_id", chain.ChainID),
+					zap.String("contract", contract.Address), zap.Error(err))
+				continue
+			}
+			logger.Logger.Info("Reward params synced", zap.Int64("chain_id", chain.ChainID), zap.String("contract", contract.Address),
+				zap.Int64("block_number", params.BlockNumber), zap.Int64("start_block", params.StartBlock),
+				zap.Int64("end_block", params.EndBlock), zap.String("zero_token_per_block", params.ZeroTokenPerBlock))
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/config"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+)
+
+const statusRPCTimeout = 5 * time.Second
+
+type contractStatus struct {
+	ChainID            int64      `json:"chain_id"`
+	ContractAddress    string     `json:"contract_address"`
+	ContractName       string     `json:"contract_name"`
+	ScanStatus         string     `json:"scan_status"`
+	LastScannedBlock   int64      `json:"last_scanned_block"`
+	LastConfirmedBlock int64      `json:"last_confirmed_block"`
+	HeadBlock          *int64     `json:"head_block"`
+	Lag                *int64     `json:"lag"`
+	LastReorgBlock     int64      `json:"last_reorg_block"`
+	LastReorgAt        *time.Time `json:"last_reorg_at"`
+}
+
+// runStatus 列出所有合约的游标、链上最新区块与同步延迟，以及最近一次重组
+func runStatus(args []string) error {
+	fs := newFlagSet("status", "[--json]")
+	configPath := configFlag(fs)
+	asJSON := fs.Bool("json", false, "print JSON instead of a table")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	a, err := newApp(*configPath)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	cursors, err := repository.NewAdminRepository(a.db).ListCursors(ctx)
+	if err != nil {
+		return fmt.Errorf("list cursors: %w", err)
+	}
+
+	// 每条链查询一次最新区块，RPC 不可用时 HEAD 和 LAG 留空
+	heads := make(map[int64]int64)
+	for _, chain := range a.cfg.ChainList() {
+		head, err := a.headBlock(ctx, chain)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "chain %d: %v\n", chain.ChainID, err)
+			continue
+		}
+		heads[chain.ChainID] = head
+	}
+
+	statuses := make([]contractStatus, 0, len(cursors))
+	for _, c := range cursors {
+		s := contractStatus{
+			ChainID:            c.ChainID,
+			ContractAddress:    c.ContractAddress,
+			ContractName:       c.ContractName,
+			ScanStatus:         repository.ScanStatusName(c.ScanStatus),
+			LastScannedBlock:   c.LastScannedBlock,
+			LastConfirmedBlock: c.LastConfirmedBlock,
+			LastReorgAt:        c.LastReorgAt,
+		}
+		if c.LastReorgBlock != nil {
+			s.LastReorgBlock = *c.LastReorgBlock
+		}
+		if head, ok := heads[c.ChainID]; ok {
+			lag := max(head-c.LastScannedBlock, 0)
+			s.HeadBlock, s.Lag = &head, &lag
+		}
+		statuses = append(statuses, s)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(statuses)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHAIN\tCONTRACT\tNAME\tSTATUS\tSCANNED\tCONFIRMED\tHEAD\tLAG\tLAST REORG")
+	for _, s := range statuses {
+		reorg := "-"
+		if s.LastReorgAt != nil {
+			reorg = fmt.Sprintf("%d (%s)", s.LastReorgBlock, s.LastReorgAt.Local().Format(time.DateTime))
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%d\t%d\t%s\t%s\t%s\n",
+			s.ChainID, s.ContractAddress, s.ContractName, s.ScanStatus,
+			s.LastScannedBlock, s.LastConfirmedBlock, optionalInt(s.HeadBlock), optionalInt(s.Lag), reorg)
+	}
+	return w.Flush()
+}
+
+func (a *app) headBlock(ctx context.Context, chain config.Chain) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, statusRPCTimeout)
+	defer cancel()
+
+	client, err := a.dial(ctx, chain)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get block number: %w", err)
+	}
+	return int64(head), nil
+}
+
+func optionalInt(v *int64) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.FormatInt(*v, 10)
+}
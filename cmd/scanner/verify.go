@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/contracts"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// 写入 staking_events 的事件类型
+var indexedEventTypes = []string{"Deposit", "RequestUnstake", "Claim", "Withdraw"}
+
+// 每类差异最多打印的条数
+const maxVerifyReport = 20
+
+type verifyEntry struct {
+	eventType   string
+	blockNumber int64
+}
+
+// runVerify 重新拉取区间内的链上日志，与 staking_events 按 tx_hash + log_index 对比
+func runVerify(args []string) error {
+	fs := newFlagSet("verify", "--from N --to M [--chain ID] [--contract ADDR]")
+	configPath := configFlag(fs)
+	cf := addContractFlags(fs)
+	from := fs.Int64("from", -1, "first block to verify (inclusive)")
+	to := fs.Int64("to", -1, "last block to verify (inclusive), must be scanned")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *from < 0 || *to < *from {
+		return fmt.Errorf("invalid block range %d-%d", *from, *to)
+	}
+
+	a, err := newApp(*configPath)
+	if err != nil {
+		return err
+	}
+	chain, contract, err := a.resolveContract(cf)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	cursor, err := a.repo.GetCursor(ctx, chain.ChainID, contract.Address)
+	if err != nil {
+		return fmt.Errorf("get cursor: %w", err)
+	}
+	if *to > cursor.LastScannedBlock {
+		return fmt.Errorf("to %d is above last scanned block %d", *to, cursor.LastScannedBlock)
+	}
+
+	client, err := a.dial(ctx, chain)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	enabled := make(map[string]bool, len(indexedEventTypes))
+	for _, name := range indexedEventTypes {
+		enabled[name] = len(contract.Handlers) == 0
+	}
+	for _, name := range contract.Handlers {
+		if _, ok := enabled[name]; ok {
+			enabled[name] = true
+		}
+	}
+
+	// 1. 链上日志
+	stakingContract := contracts.NewStakingContract()
+	onChain := make(map[string]verifyEntry)
+	chunkSize := int64(chain.BatchSize)
+	if chunkSize <= 0 {
+		chunkSize = 100
+	}
+	for start := *from; start <= *to; start += chunkSize {
+		end := min(start+chunkSize-1, *to)
+		logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: big.NewInt(start),
+			ToBlock:   big.NewInt(end),
+			Addresses: []common.Address{common.HexToAddress(contract.Address)},
+		})
+		if err != nil {
+			return fmt.Errorf("filter logs %d-%d: %w", start, end, err)
+		}
+		for _, log := range logs {
+			if log.Removed || len(log.Topics) == 0 {
+				continue
+			}
+			name, ok := stakingContract.GetEventName(log.Topics[0])
+			if !ok || !enabled[name] {
+				continue
+			}
+			onChain[fmt.Sprintf("%s:%d", log.TxHash.Hex(), log.Index)] = verifyEntry{eventType: name, blockNumber: int64(log.BlockNumber)}
+		}
+	}
+
+	// 2. 已索引事件（含未确认）
+	queryRepo := repository.NewStakingQueryRepository(a.db)
+	filter := repository.EventFilter{
+		ChainID:         chain.ChainID,
+		ContractAddress: contract.Address,
+		FromBlock:       *from,
+		ToBlock:         *to,
+		IncludePending:  true,
+		Limit:           repository.MaxPageSize,
+	}
+	indexed := make(map[string]verifyEntry)
+	after := repository.EventPosition{BlockNumber: *from - 1, LogIndex: -1}
+	for {
+		events, err := queryRepo.ListEventsAfterPosition(ctx, filter, after)
+		if err != nil {
+			return fmt.Errorf("list events: %w", err)
+		}
+		for _, ev := range events {
+			indexed[eventKey(ev)] = verifyEntry{eventType: ev.EventType, blockNumber: ev.BlockNumber}
+		}
+		if len(events) < repository.MaxPageSize {
+			break
+		}
+		last := events[len(events)-1]
+		after = repository.EventPosition{BlockNumber: last.BlockNumber, LogIndex: last.LogIndex}
+	}
+
+	// 3. 对比
+	var missing, extra, mismatched []string
+	for key, want := range onChain {
+		got, ok := indexed[key]
+		switch {
+		case !ok:
+			missing = append(missing, fmt.Sprintf("%s %s at block %d", key, want.eventType, want.blockNumber))
+		case got != want:
+			mismatched = append(mismatched, fmt.Sprintf("%s chain=%s@%d indexed=%s@%d",
+				key, want.eventType, want.blockNumber, got.eventType, got.blockNumber))
+		}
+	}
+	for key, got := range indexed {
+		if _, ok := onChain[key]; !ok {
+			extra = append(extra, fmt.Sprintf("%s %s at block %d", key, got.eventType, got.blockNumber))
+		}
+	}
+
+	fmt.Printf("verified %s on chain %d, blocks %d-%d: %d on chain, %d indexed\n",
+		contract.Address, chain.ChainID, *from, *to, len(onChain), len(indexed))
+	printDiff("missing", missing)
+	printDiff("extra", extra)
+	printDiff("mismatched", mismatched)
+	if n := len(missing) + len(extra) + len(mismatched); n > 0 {
+		return fmt.Errorf("%d differences found", n)
+	}
+	fmt.Println("ok")
+	return nil
+}
+
+func eventKey(ev *model.StakingEvent) string {
+	return fmt.Sprintf("%s:%d", ev.TxHash, ev.LogIndex)
+}
+
+func printDiff(kind string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Printf("%d %s:\n", len(lines), kind)
+	for i, line := range lines {
+		if i == maxVerifyReport {
+			fmt.Printf("  ... %d more\n", len(lines)-maxVerifyReport)
+			break
+		}
+		fmt.Printf("  %s\n", line)
+	}
+}
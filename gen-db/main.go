@@ -26,6 +26,14 @@ func main() {
 
 	g.UseDB(gormdb)
 
+	// DECIMAL(38,0) 的链上数量使用精确整数类型，避免经过 float64 丢失精度
+	g.WithImportPkgPath("github.com/dijiacoder/staking-indexer/internal/decimal")
+	g.WithDataTypeMap(map[string]func(columnType gorm.ColumnType) (dataType string){
+		"decimal": func(columnType gorm.ColumnType) string {
+			return "decimal.Int"
+		},
+	})
+
 	// 已有的表模型生成
 	g.ApplyBasic(
 		g.GenerateModel("chain_blocks"),
@@ -33,6 +41,19 @@ func main() {
 		g.GenerateModel("staking_events"),
 		g.GenerateModel("staking_pools"),
 		g.GenerateModel("staking_user_positions"),
+		g.GenerateModel("webhook_subscriptions"),
+		g.GenerateModel("webhook_deliveries"),
+		g.GenerateModel("webhook_delivery_logs"),
+		g.GenerateModel("event_outbox"),
+		g.GenerateModel("outbox_checkpoints"),
+		g.GenerateModel("staking_position_snapshots"),
+		g.GenerateModel("admin_jobs"),
+		g.GenerateModel("admin_audit_logs"),
+		g.GenerateModel("raw_logs"),
+		g.GenerateModel("reconcile_drifts"),
+		g.GenerateModel("staking_reward_params"),
+		g.GenerateModel("staking_claim_daily"),
+		g.GenerateModel("staking_pool_history"),
 	)
 
 	g.Execute()
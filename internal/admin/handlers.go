@@ -0,0 +1,321 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// httpError 携带响应状态码的操作错误
+type httpError struct {
+	status int
+	err    error
+}
+
+func (e *httpError) Error() string { return e.err.Error() }
+
+func badRequest(format string, args ...any) error {
+	return &httpError{status: http.StatusBadRequest, err: fmt.Errorf(format, args...)}
+}
+
+func conflict(format string, args ...any) error {
+	return &httpError{status: http.StatusConflict, err: fmt.Errorf(format, args...)}
+}
+
+// target 操作的合约，address 为配置中的地址
+type target struct {
+	chainID int64
+	address string
+}
+
+type cursorResponse struct {
+	ChainID            int64      `json:"chain_id"`
+	ContractAddress    string     `json:"contract_address"`
+	ContractName       string     `json:"contract_name"`
+	ScanStatus         string     `json:"scan_status"`
+	LastScannedBlock   int64      `json:"last_scanned_block"`
+	LastConfirmedBlock int64      `json:"last_confirmed_block"`
+	LastSnapshotBlock  int64      `json:"last_snapshot_block"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+}
+
+func newCursorResponse(c *model.ChainScanCursor) cursorResponse {
+	resp := cursorResponse{
+		ChainID:            c.ChainID,
+		ContractAddress:    c.ContractAddress,
+		ContractName:       c.ContractName,
+		ScanStatus:         repository.ScanStatusName(c.ScanStatus),
+		LastScannedBlock:   c.LastScannedBlock,
+		LastConfirmedBlock: c.LastConfirmedBlock,
+		UpdatedAt:          c.UpdatedAt,
+	}
+	if c.LastSnapshotBlock != nil {
+		resp.LastSnapshotBlock = *c.LastSnapshotBlock
+	}
+	return resp
+}
+
+type rewindRequest struct {
+	ToBlock *int64 `json:"to_block"`
+}
+
+type rescanRequest struct {
+	FromBlock *int64 `json:"from_block"`
+	ToBlock   *int64 `json:"to_block"`
+}
+
+// GET /admin/cursors
+func (s *Server) listCursors(w http.ResponseWriter, r *http.Request) {
+	cursors, err := s.adminRepo.ListCursors(r.Context())
+	if err != nil {
+		internalError(w, "list cursors", err)
+		return
+	}
+	data := make([]cursorResponse, 0, len(cursors))
+	for _, c := range cursors {
+		data = append(data, newCursorResponse(c))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": data})
+}
+
+// POST /admin/contracts/{chainId}/{address}/pause
+func (s *Server) pause(w http.ResponseWriter, r *http.Request) {
+	s.do(w, r, "pause", nil, func(ctx context.Context, t target) (int, any, error) {
+		cursor, err := s.repo.GetCursor(ctx, t.chainID, t.address)
+		if err != nil {
+			return 0, nil, err
+		}
+		switch repository.ScanStatusName(cursor.ScanStatus) {
+		case "paused":
+			return http.StatusOK, newCursorResponse(cursor), nil
+		case "rolling_back":
+			return 0, nil, conflict("cursor is rolling back")
+		}
+		return s.setScanStatus(ctx, t, repository.ScanStatusPaused)
+	})
+}
+
+// POST /admin/contracts/{chainId}/{address}/resume
+func (s *Server) resume(w http.ResponseWriter, r *http.Request) {
+	s.do(w, r, "resume", nil, func(ctx context.Context, t target) (int, any, error) {
+		cursor, err := s.repo.GetCursor(ctx, t.chainID, t.address)
+		if err != nil {
+			return 0, nil, err
+		}
+		switch repository.ScanStatusName(cursor.ScanStatus) {
+		case "normal":
+			return http.StatusOK, newCursorResponse(cursor), nil
+		case "rolling_back":
+			return 0, nil, conflict("cursor is rolling back")
+		}
+		return s.setScanStatus(ctx, t, repository.ScanStatusNormal)
+	})
+}
+
+// POST /admin/contracts/{chainId}/{address}/rewind {"to_block": N}
+// 合约需要先暂停，回退后保持暂停，恢复后从 to_block + 1 重新扫描
+func (s *Server) rewind(w http.ResponseWriter, r *http.Request) {
+	var req rewindRequest
+	s.do(w, r, "rewind", &req, func(ctx context.Context, t target) (int, any, error) {
+		if req.ToBlock == nil {
+			return 0, nil, badRequest("to_block is required")
+		}
+		err := s.repo.RewindCursor(ctx, t.chainID, t.address, *req.ToBlock)
+		switch {
+		case errors.Is(err, repository.ErrCursorNotPaused):
+			return 0, nil, conflict("pause the contract before rewinding")
+		case errors.Is(err, repository.ErrInvalidRewind):
+			return 0, nil, badRequest("%v", err)
+		case err != nil:
+			return 0, nil, err
+		}
+		cursor, err := s.repo.GetCursor(ctx, t.chainID, t.address)
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, newCursorResponse(cursor), nil
+	})
+}
+
+// POST /admin/contracts/{chainId}/{address}/rescan {"from_block": A, "to_block": B}
+// 创建重扫任务，合约需要先暂停、区间需要已确认，任务由后台执行，通过 /admin/jobs/{id} 查看进度
+func (s *Server) rescan(w http.ResponseWriter, r *http.Request) {
+	var req rescanRequest
+	s.do(w, r, "rescan", &req, func(ctx context.Context, t target) (int, any, error) {
+		if req.FromBlock == nil || req.ToBlock == nil {
+			return 0, nil, badRequest("from_block and to_block are required")
+		}
+		from, to := *req.FromBlock, *req.ToBlock
+		if from < 0 || from > to {
+			return 0, nil, badRequest("invalid block range %d-%d", from, to)
+		}
+		cursor, err := s.repo.GetCursor(ctx, t.chainID, t.address)
+		if err != nil {
+			return 0, nil, err
+		}
+		if repository.ScanStatusName(cursor.ScanStatus) != "paused" {
+			return 0, nil, conflict("pause the contract before rescanning")
+		}
+		if to > cursor.LastConfirmedBlock {
+			return 0, nil, badRequest("to_block %d is above last confirmed block %d", to, cursor.LastConfirmedBlock)
+		}
+
+		job := &model.AdminJob{
+			Kind:            repository.AdminJobRescan,
+			ChainID:         t.chainID,
+			ContractAddress: t.address,
+			FromBlock:       from,
+			ToBlock:         to,
+			CurrentBlock:    from - 1,
+			Status:          repository.AdminJobPending,
+			CreatedBy:       actorFrom(ctx),
+		}
+		if err := s.adminRepo.CreateJob(ctx, job); err != nil {
+			return 0, nil, err
+		}
+		return http.StatusAccepted, job, nil
+	})
+}
+
+// GET /admin/jobs?chainId=&contract=&status=&limit=
+func (s *Server) listJobs(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := repository.AdminJobFilter{
+		ContractAddress: q.Get("contract"),
+		Status:          q.Get("status"),
+	}
+	var err error
+	if v := q.Get("chainId"); v != "" {
+		if filter.ChainID, err = strconv.ParseInt(v, 10, 64); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid chainId")
+			return
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		if filter.Limit, err = strconv.Atoi(v); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+
+	jobs, err := s.adminRepo.ListJobs(r.Context(), filter)
+	if err != nil {
+		internalError(w, "list jobs", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": jobs})
+}
+
+// GET /admin/jobs/{id}
+func (s *Server) getJob(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid job id")
+		return
+	}
+	job, err := s.adminRepo.GetJob(r.Context(), id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+	if err != nil {
+		internalError(w, "get job", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+func (s *Server) setScanStatus(ctx context.Context, t target, status int32) (int, any, error) {
+	if err := s.repo.UpdateScanStatus(ctx, t.chainID, t.address, status); err != nil {
+		return 0, nil, err
+	}
+	cursor, err := s.repo.GetCursor(ctx, t.chainID, t.address)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, newCursorResponse(cursor), nil
+}
+
+// do 解析目标合约和请求体，执行操作并写入审计记录，审计失败时操作结果仍然返回
+func (s *Server) do(w http.ResponseWriter, r *http.Request, action string, params any, fn func(ctx context.Context, t target) (int, any, error)) {
+	ctx := r.Context()
+	chainID, _ := strconv.ParseInt(r.PathValue("chainId"), 10, 64)
+	t := target{chainID: chainID, address: r.PathValue("address")}
+
+	status, resp, err := func() (int, any, error) {
+		if chainID <= 0 {
+			return 0, nil, badRequest("invalid chainId")
+		}
+		address, ok := s.findContract(chainID, t.address)
+		if !ok {
+			return 0, nil, &httpError{status: http.StatusNotFound, err: errors.New("contract is not configured")}
+		}
+		t.address = address
+		if params != nil {
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(params); err != nil {
+				return 0, nil, badRequest("invalid request body: %v", err)
+			}
+		}
+		return fn(ctx, t)
+	}()
+
+	s.audit(ctx, r, action, t, params, err)
+
+	if err != nil {
+		var he *httpError
+		switch {
+		case errors.As(err, &he):
+			writeError(w, he.status, he.Error())
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			writeError(w, http.StatusNotFound, "cursor not found")
+		default:
+			internalError(w, action, err)
+		}
+		return
+	}
+	writeJSON(w, status, resp)
+}
+
+func (s *Server) audit(ctx context.Context, r *http.Request, action string, t target, params any, actionErr error) {
+	entry := &model.AdminAuditLog{
+		Actor:           actorFrom(ctx),
+		Action:          action,
+		ChainID:         t.chainID,
+		ContractAddress: t.address,
+		Params:          "{}",
+		Result:          repository.AuditResultOK,
+		RemoteAddr:      r.RemoteAddr,
+	}
+	if params != nil {
+		if b, err := json.Marshal(params); err == nil {
+			entry.Params = string(b)
+		}
+	}
+	if actionErr != nil {
+		entry.Result = repository.AuditResultError
+		entry.Error = actionErr.Error()
+	}
+	// 请求取消时审计仍需落库
+	if err := s.adminRepo.WriteAudit(context.WithoutCancel(ctx), entry); err != nil {
+		logger.Logger.Error("Failed to write admin audit log",
+			zap.String("action", action),
+			zap.String("actor", entry.Actor),
+			zap.Error(err),
+		)
+	}
+}
+
+func internalError(w http.ResponseWriter, op string, err error) {
+	logger.Logger.Error("Admin request failed", zap.String("op", op), zap.Error(err))
+	writeError(w, http.StatusInternalServerError, "internal error")
+}
@@ -0,0 +1,142 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/config"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"go.uber.org/zap"
+)
+
+type actorKey struct{}
+
+// Server 管理接口：暂停/恢复合约扫描、回退游标、区间重扫与任务查询，所有操作写入 admin_audit_logs
+type Server struct {
+	adminRepo repository.AdminRepository
+	repo      repository.ScannerRepository
+	chains    []config.Chain
+	tokens    map[string]string
+	mux       *http.ServeMux
+}
+
+// tokens 为名称到令牌的映射，名称作为审计记录中的操作人
+func NewServer(adminRepo repository.AdminRepository, repo repository.ScannerRepository, chains []config.Chain, tokens map[string]string) (*Server, error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("admin api requires at least one token")
+	}
+	for name, token := range tokens {
+		if token == "" {
+			return nil, fmt.Errorf("admin token %q is empty", name)
+		}
+	}
+
+	s := &Server{
+		adminRepo: adminRepo,
+		repo:      repo,
+		chains:    chains,
+		tokens:    tokens,
+		mux:       http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("GET /admin/cursors", s.listCursors)
+	s.mux.HandleFunc("POST /admin/contracts/{chainId}/{address}/pause", s.pause)
+	s.mux.HandleFunc("POST /admin/contracts/{chainId}/{address}/resume", s.resume)
+	s.mux.HandleFunc("POST /admin/contracts/{chainId}/{address}/rewind", s.rewind)
+	s.mux.HandleFunc("POST /admin/contracts/{chainId}/{address}/rescan", s.rescan)
+	s.mux.HandleFunc("GET /admin/jobs", s.listJobs)
+	s.mux.HandleFunc("GET /admin/jobs/{id}", s.getJob)
+
+	return s, nil
+}
+
+// ServeHTTP 校验 Authorization: Bearer <token> 后分发请求
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	actor, ok := s.authenticate(r)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	s.mux.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), actorKey{}, actor)))
+}
+
+// ListenAndServe 启动 HTTP 服务，ctx 取消时优雅关闭
+func (s *Server) ListenAndServe(ctx context.Context, port int) error {
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	logger.Logger.Info("Starting admin server", zap.String("address", srv.Addr))
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// authenticate 返回令牌对应的名称，逐个做常量时间比较
+func (s *Server) authenticate(r *http.Request) (string, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return "", false
+	}
+	actor := ""
+	for name, expected := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1 {
+			actor = name
+		}
+	}
+	return actor, actor != ""
+}
+
+func actorFrom(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// findContract 按配置校验链和合约，返回配置中的合约地址
+func (s *Server) findContract(chainID int64, address string) (string, bool) {
+	for _, chain := range s.chains {
+		if chain.ChainID != chainID {
+			continue
+		}
+		for _, c := range chain.Contracts {
+			if strings.EqualFold(c.Address, address) {
+				return c.Address, true
+			}
+		}
+	}
+	return "", false
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Logger.Error("write response error", zap.Error(err))
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
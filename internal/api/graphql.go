@@ -0,0 +1,457 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/decimal"
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/graphql-go/graphql"
+)
+
+// 关联列表字段默认返回条数
+const defaultRelationSize = 10
+
+// graphqlRequest GraphQL 请求体
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// POST /graphql 或 GET /graphql?query=
+func (s *Server) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphqlRequest
+	if r.Method == http.MethodGet {
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+		if raw := r.URL.Query().Get("variables"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &req.Variables); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid variables")
+				return
+			}
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Query == "" {
+		writeError(w, http.StatusBadRequest, "missing query")
+		return
+	}
+
+	// 每个请求使用独立的 loader，缓存不跨请求
+	result := graphql.Do(graphql.Params{
+		Schema:         s.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        withLoaders(r.Context(), newLoaders(s.repo)),
+	})
+	writeJSON(w, http.StatusOK, result)
+}
+
+// newSchema 构建与数据模型对应的 GraphQL schema，关联字段通过 loader 批量加载
+func newSchema(repo repository.StakingQueryRepository) (graphql.Schema, error) {
+	var poolType, positionType, eventType, blockType *graphql.Object
+
+	blockType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Block",
+		Fields: graphql.Fields{
+			"chainId":     field(graphql.Int, func(b *model.ChainBlock) interface{} { return b.ChainID }),
+			"blockNumber": field(graphql.Int, func(b *model.ChainBlock) interface{} { return b.BlockNumber }),
+			"blockHash":   field(graphql.String, func(b *model.ChainBlock) interface{} { return b.BlockHash }),
+			"parentHash":  field(graphql.String, func(b *model.ChainBlock) interface{} { return b.ParentHash }),
+			"blockTime":   field(graphql.DateTime, func(b *model.ChainBlock) interface{} { return b.BlockTime }),
+			"isConfirmed": field(graphql.Boolean, func(b *model.ChainBlock) interface{} { return b.IsConfirmed == 1 }),
+		},
+	})
+
+	poolType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Pool",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"chainId":             field(graphql.Int, func(p *model.StakingPool) interface{} { return p.ChainID }),
+				"contractAddress":     field(graphql.String, func(p *model.StakingPool) interface{} { return p.ContractAddress }),
+				"poolId":              field(graphql.Int, func(p *model.StakingPool) interface{} { return p.PoolID }),
+				"stTokenAddress":      field(graphql.String, func(p *model.StakingPool) interface{} { return p.StTokenAddress }),
+				"poolWeight":          field(graphql.String, func(p *model.StakingPool) interface{} { return strconv.FormatInt(p.PoolWeight, 10) }),
+				"lastRewardBlock":     field(graphql.Int, func(p *model.StakingPool) interface{} { return p.LastRewardBlock }),
+				"accZeroTokenPerSt":   field(graphql.String, func(p *model.StakingPool) interface{} { return strconv.FormatInt(p.AccZeroTokenPerSt, 10) }),
+				"stTokenAmount":       field(graphql.String, func(p *model.StakingPool) interface{} { return decimal.Deref(p.StTokenAmount).String() }),
+				"pendingUnstake":      field(graphql.String, func(p *model.StakingPool) interface{} { return decimal.Deref(p.PendingUnstake).String() }),
+				"stakerCount":         field(graphql.Int, func(p *model.StakingPool) interface{} { return int64Value(p.StakerCount) }),
+				"minDepositAmount":    field(graphql.String, func(p *model.StakingPool) interface{} { return strconv.FormatInt(p.MinDepositAmount, 10) }),
+				"unstakeLockedBlocks": field(graphql.Int, func(p *model.StakingPool) interface{} { return p.UnstakeLockedBlocks }),
+				"totalClaimed":        field(graphql.String, func(p *model.StakingPool) interface{} { return decimal.Deref(p.TotalClaimed).String() }),
+				"topStakers": &graphql.Field{
+					Type:        graphql.NewList(positionType),
+					Description: "按质押数量倒序的持仓",
+					Args: graphql.FieldConfigArgument{
+						"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultRelationSize},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						pool := p.Source.(*model.StakingPool)
+						key := poolListKey{
+							pool:  poolKeyOf(pool.ChainID, pool.ContractAddress, pool.PoolID),
+							limit: repository.PageSize(p.Args["first"].(int)),
+						}
+						return loadersFrom(p.Context).topStakers.load(p.Context, key), nil
+					},
+				},
+				"recentEvents": &graphql.Field{
+					Type:        graphql.NewList(eventType),
+					Description: "按时间倒序的最近事件",
+					Args: graphql.FieldConfigArgument{
+						"first":          &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultRelationSize},
+						"includePending": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						pool := p.Source.(*model.StakingPool)
+						key := poolListKey{
+							pool:           poolKeyOf(pool.ChainID, pool.ContractAddress, pool.PoolID),
+							limit:          repository.PageSize(p.Args["first"].(int)),
+							includePending: p.Args["includePending"].(bool),
+						}
+						return loadersFrom(p.Context).recentEvents.load(p.Context, key), nil
+					},
+				},
+			}
+		}),
+	})
+
+	positionType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Position",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"chainId":         field(graphql.Int, func(p *model.StakingUserPosition) interface{} { return p.ChainID }),
+				"contractAddress": field(graphql.String, func(p *model.StakingUserPosition) interface{} { return p.ContractAddress }),
+				"poolId":          field(graphql.Int, func(p *model.StakingUserPosition) interface{} { return p.PoolID }),
+				"userAddress":     field(graphql.String, func(p *model.StakingUserPosition) interface{} { return p.UserAddress }),
+				"stakedAmount":    field(graphql.String, func(p *model.StakingUserPosition) interface{} { return decimal.Deref(p.StakedAmount).String() }),
+				"rewardDebt":      field(graphql.String, func(p *model.StakingUserPosition) interface{} { return decimal.Deref(p.RewardDebt).String() }),
+				"totalClaimed":    field(graphql.String, func(p *model.StakingUserPosition) interface{} { return decimal.Deref(p.TotalClaimed).String() }),
+				"updatedAt":       field(graphql.DateTime, func(p *model.StakingUserPosition) interface{} { return p.UpdatedAt }),
+				"pool": &graphql.Field{
+					Type: poolType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						pos := p.Source.(*model.StakingUserPosition)
+						key := poolKeyOf(pos.ChainID, pos.ContractAddress, pos.PoolID)
+						return loadersFrom(p.Context).pools.load(p.Context, key), nil
+					},
+				},
+			}
+		}),
+	})
+
+	eventType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Event",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":              field(graphql.ID, func(e *model.StakingEvent) interface{} { return strconv.FormatInt(e.ID, 10) }),
+				"chainId":         field(graphql.Int, func(e *model.StakingEvent) interface{} { return e.ChainID }),
+				"contractAddress": field(graphql.String, func(e *model.StakingEvent) interface{} { return e.ContractAddress }),
+				"poolId":          field(graphql.Int, func(e *model.StakingEvent) interface{} { return e.PoolID }),
+				"eventType":       field(graphql.String, func(e *model.StakingEvent) interface{} { return e.EventType }),
+				"userAddress":     field(graphql.String, func(e *model.StakingEvent) interface{} { return e.UserAddress }),
+				"amount":          field(graphql.String, func(e *model.StakingEvent) interface{} { return e.Amount.String() }),
+				"blockNumber":     field(graphql.Int, func(e *model.StakingEvent) interface{} { return e.BlockNumber }),
+				"blockTime":       field(graphql.DateTime, func(e *model.StakingEvent) interface{} { return e.BlockTime }),
+				"txHash":          field(graphql.String, func(e *model.StakingEvent) interface{} { return e.TxHash }),
+				"logIndex":        field(graphql.Int, func(e *model.StakingEvent) interface{} { return e.LogIndex }),
+				"confirmationStatus": field(graphql.String, func(e *model.StakingEvent) interface{} {
+					return e.ConfirmationStatus
+				}),
+				"pool": &graphql.Field{
+					Type: poolType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						e := p.Source.(*model.StakingEvent)
+						key := poolKeyOf(e.ChainID, e.ContractAddress, e.PoolID)
+						return loadersFrom(p.Context).pools.load(p.Context, key), nil
+					},
+				},
+				"block": &graphql.Field{
+					Type: blockType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						e := p.Source.(*model.StakingEvent)
+						key := repository.BlockKey{ChainID: e.ChainID, BlockNumber: e.BlockNumber}
+						return loadersFrom(p.Context).blocks.load(p.Context, key), nil
+					},
+				},
+			}
+		}),
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"pools": &graphql.Field{
+				Type: pageType("PoolPage", poolType),
+				Args: pageArgs(graphql.FieldConfigArgument{
+					"chainId":  &graphql.ArgumentConfig{Type: graphql.Int},
+					"contract": &graphql.ArgumentConfig{Type: graphql.String},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					args := graphqlArgs(p.Args)
+					filter := repository.PoolFilter{
+						ChainID:         args.int64("chainId"),
+						ContractAddress: args.address("contract"),
+						AfterID:         args.cursor("after"),
+						Limit:           int(args.int64("first")),
+					}
+					if args.err != nil {
+						return nil, args.err
+					}
+					pools, err := repo.ListPools(p.Context, filter)
+					if err != nil {
+						return nil, err
+					}
+					return newPage(pools, filter.Limit, func(p *model.StakingPool) int64 { return p.ID }), nil
+				},
+			},
+			"pool": &graphql.Field{
+				Type: poolType,
+				Args: graphql.FieldConfigArgument{
+					"chainId":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"contract": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"poolId":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					args := graphqlArgs(p.Args)
+					key := poolKeyOf(args.int64("chainId"), args.address("contract"), args.int64("poolId"))
+					if args.err != nil {
+						return nil, args.err
+					}
+					return loadersFrom(p.Context).pools.load(p.Context, key), nil
+				},
+			},
+			"positions": &graphql.Field{
+				Type: pageType("PositionPage", positionType),
+				Args: pageArgs(graphql.FieldConfigArgument{
+					"user":           &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"chainId":        &graphql.ArgumentConfig{Type: graphql.Int},
+					"contract":       &graphql.ArgumentConfig{Type: graphql.String},
+					"includePending": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					args := graphqlArgs(p.Args)
+					filter := repository.PositionFilter{
+						ChainID:         args.int64("chainId"),
+						ContractAddress: args.address("contract"),
+						UserAddress:     args.address("user"),
+						IncludePending:  args.bool("includePending"),
+						AfterID:         args.cursor("after"),
+						Limit:           int(args.int64("first")),
+					}
+					if args.err != nil {
+						return nil, args.err
+					}
+					positions, err := repo.ListUserPositions(p.Context, filter)
+					if err != nil {
+						return nil, err
+					}
+					return newPage(positions, filter.Limit, func(p *model.StakingUserPosition) int64 { return p.ID }), nil
+				},
+			},
+			"events": &graphql.Field{
+				Type: pageType("EventPage", eventType),
+				Args: pageArgs(graphql.FieldConfigArgument{
+					"chainId":        &graphql.ArgumentConfig{Type: graphql.Int},
+					"contract":       &graphql.ArgumentConfig{Type: graphql.String},
+					"user":           &graphql.ArgumentConfig{Type: graphql.String},
+					"poolId":         &graphql.ArgumentConfig{Type: graphql.Int},
+					"type":           &graphql.ArgumentConfig{Type: graphql.String},
+					"fromBlock":      &graphql.ArgumentConfig{Type: graphql.Int},
+					"toBlock":        &graphql.ArgumentConfig{Type: graphql.Int},
+					"fromTime":       &graphql.ArgumentConfig{Type: graphql.String},
+					"toTime":         &graphql.ArgumentConfig{Type: graphql.String},
+					"includePending": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					args := graphqlArgs(p.Args)
+					filter := repository.EventFilter{
+						ChainID:         args.int64("chainId"),
+						ContractAddress: args.address("contract"),
+						UserAddress:     args.address("user"),
+						PoolID:          args.optionalInt64("poolId"),
+						EventType:       args.string("type"),
+						FromBlock:       args.int64("fromBlock"),
+						ToBlock:         args.int64("toBlock"),
+						FromTime:        args.time("fromTime"),
+						ToTime:          args.time("toTime"),
+						IncludePending:  args.bool("includePending"),
+						AfterID:         args.cursor("after"),
+						Limit:           int(args.int64("first")),
+					}
+					if args.err != nil {
+						return nil, args.err
+					}
+					events, err := repo.ListEvents(p.Context, filter)
+					if err != nil {
+						return nil, err
+					}
+					return newPage(events, filter.Limit, func(e *model.StakingEvent) int64 { return e.ID }), nil
+				},
+			},
+			"block": &graphql.Field{
+				Type: blockType,
+				Args: graphql.FieldConfigArgument{
+					"chainId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"number":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					args := graphqlArgs(p.Args)
+					key := repository.BlockKey{ChainID: args.int64("chainId"), BlockNumber: args.int64("number")}
+					return loadersFrom(p.Context).blocks.load(p.Context, key), nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+// field 从模型结构体取值的字段
+func field[T any](typ graphql.Output, get func(T) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: typ,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			src, ok := p.Source.(T)
+			if !ok {
+				return nil, nil
+			}
+			return get(src), nil
+		},
+	}
+}
+
+// page 游标分页结果，nextCursor 为空表示没有更多数据
+type page struct {
+	Items      interface{} `json:"items"`
+	NextCursor *string     `json:"nextCursor"`
+}
+
+func newPage[T any](items []T, limit int, id func(T) int64) *page {
+	result := &page{Items: items}
+	if len(items) == repository.PageSize(limit) {
+		cursor := strconv.FormatInt(id(items[len(items)-1]), 10)
+		result.NextCursor = &cursor
+	}
+	return result
+}
+
+func pageType(name string, item *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"items": &graphql.Field{
+				Type: graphql.NewList(item),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*page).Items, nil
+				},
+			},
+			"nextCursor": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*page).NextCursor, nil
+				},
+			},
+		},
+	})
+}
+
+// pageArgs 为列表字段追加 first/after 分页参数
+func pageArgs(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	args["first"] = &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: repository.DefaultPageSize}
+	args["after"] = &graphql.ArgumentConfig{Type: graphql.String}
+	return args
+}
+
+// argValues 解析 GraphQL 参数，记录第一个解析错误
+type argValues struct {
+	args map[string]interface{}
+	err  error
+}
+
+func graphqlArgs(args map[string]interface{}) *argValues {
+	return &argValues{args: args}
+}
+
+func (a *argValues) string(name string) string {
+	v, _ := a.args[name].(string)
+	return v
+}
+
+func (a *argValues) int64(name string) int64 {
+	v := a.optionalInt64(name)
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func (a *argValues) optionalInt64(name string) *int64 {
+	v, ok := a.args[name].(int)
+	if !ok {
+		return nil
+	}
+	n := int64(v)
+	return &n
+}
+
+func (a *argValues) bool(name string) bool {
+	v, _ := a.args[name].(bool)
+	return v
+}
+
+// cursor 解析 after 游标
+func (a *argValues) cursor(name string) int64 {
+	raw := a.string(name)
+	if raw == "" {
+		return 0
+	}
+	v, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		a.fail(errors.New("invalid " + name + " cursor"))
+		return 0
+	}
+	return v
+}
+
+// time 解析 Unix 秒或 RFC 3339 格式的时间参数
+func (a *argValues) time(name string) *time.Time {
+	raw := a.string(name)
+	if raw == "" {
+		return nil
+	}
+	t, err := parseTime(raw)
+	if err != nil {
+		a.fail(errors.New("invalid " + name))
+		return nil
+	}
+	return &t
+}
+
+// address 解析地址参数并统一为 checksum 格式
+func (a *argValues) address(name string) string {
+	raw := a.string(name)
+	if raw == "" {
+		return ""
+	}
+	if !common.IsHexAddress(raw) {
+		a.fail(errors.New("invalid " + name + " address"))
+		return ""
+	}
+	return common.HexToAddress(raw).Hex()
+}
+
+func (a *argValues) fail(err error) {
+	if a.err == nil {
+		a.err = err
+	}
+}
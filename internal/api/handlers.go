@@ -0,0 +1,454 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/reward"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// GET /pools?chainId=&contract=&cursor=&limit=
+func (s *Server) listPools(w http.ResponseWriter, r *http.Request) {
+	params := queryParams{r: r}
+	filter := repository.PoolFilter{
+		ChainID:         params.int64("chainId"),
+		ContractAddress: params.address("contract"),
+		AfterID:         params.int64("cursor"),
+		Limit:           int(params.int64("limit")),
+	}
+	if params.err != nil {
+		writeError(w, http.StatusBadRequest, params.err.Error())
+		return
+	}
+
+	pools, err := s.repo.ListPools(r.Context(), filter)
+	if err != nil {
+		s.internalError(w, "list pools", err)
+		return
+	}
+
+	data := make([]poolResponse, 0, len(pools))
+	for _, p := range pools {
+		data = append(data, newPoolResponse(p))
+	}
+	resp := listResponse{Data: data}
+	if len(pools) == repository.PageSize(filter.Limit) {
+		resp.NextCursor = strconv.FormatInt(pools[len(pools)-1].ID, 10)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GET /pools/{id}?chainId=&contract=，id 为合约内的 Pool ID
+func (s *Server) getPool(w http.ResponseWriter, r *http.Request) {
+	poolID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid pool id")
+		return
+	}
+
+	params := queryParams{r: r}
+	filter := repository.PoolFilter{
+		ChainID:         params.int64("chainId"),
+		ContractAddress: params.address("contract"),
+		PoolID:          &poolID,
+		Limit:           2,
+	}
+	if params.err != nil {
+		writeError(w, http.StatusBadRequest, params.err.Error())
+		return
+	}
+
+	pools, err := s.repo.ListPools(r.Context(), filter)
+	if err != nil {
+		s.internalError(w, "get pool", err)
+		return
+	}
+	switch len(pools) {
+	case 0:
+		writeError(w, http.StatusNotFound, "pool not found")
+	case 1:
+		writeJSON(w, http.StatusOK, newPoolResponse(pools[0]))
+	default:
+		writeError(w, http.StatusBadRequest, "pool id matches multiple contracts, specify chainId and contract")
+	}
+}
+
+// GET /pools/{id}/history?chainId=&contract=&fromBlock=&toBlock=&cursor=&limit=
+// 质押池每个有变化的区块结束时的总量，cursor 为上一页最后一条记录的区块号
+func (s *Server) listPoolHistory(w http.ResponseWriter, r *http.Request) {
+	poolID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid pool id")
+		return
+	}
+
+	params := queryParams{r: r}
+	filter := repository.PoolHistoryFilter{
+		ChainID:         params.int64("chainId"),
+		ContractAddress: params.address("contract"),
+		PoolID:          poolID,
+		FromBlock:       params.int64("fromBlock"),
+		ToBlock:         params.int64("toBlock"),
+		AfterBlock:      params.int64("cursor"),
+		Limit:           int(params.int64("limit")),
+	}
+	if params.err != nil {
+		writeError(w, http.StatusBadRequest, params.err.Error())
+		return
+	}
+	if filter.ChainID == 0 || filter.ContractAddress == "" {
+		writeError(w, http.StatusBadRequest, "chainId and contract are required")
+		return
+	}
+
+	history, err := s.repo.ListPoolHistory(r.Context(), filter)
+	if err != nil {
+		s.internalError(w, "list pool history", err)
+		return
+	}
+
+	data := make([]poolHistoryResponse, 0, len(history))
+	for _, h := range history {
+		data = append(data, newPoolHistoryResponse(h))
+	}
+	resp := listResponse{Data: data}
+	if len(history) == repository.PageSize(filter.Limit) {
+		resp.NextCursor = strconv.FormatInt(history[len(history)-1].BlockNumber, 10)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GET /users/{address}/positions?chainId=&contract=&includePending=&cursor=&limit=
+func (s *Server) listUserPositions(w http.ResponseWriter, r *http.Request) {
+	address := r.PathValue("address")
+	if !common.IsHexAddress(address) {
+		writeError(w, http.StatusBadRequest, "invalid user address")
+		return
+	}
+
+	params := queryParams{r: r}
+	filter := repository.PositionFilter{
+		ChainID:         params.int64("chainId"),
+		ContractAddress: params.address("contract"),
+		UserAddress:     common.HexToAddress(address).Hex(),
+		IncludePending:  params.bool("includePending"),
+		AfterID:         params.int64("cursor"),
+		Limit:           int(params.int64("limit")),
+	}
+	if params.err != nil {
+		writeError(w, http.StatusBadRequest, params.err.Error())
+		return
+	}
+
+	positions, err := s.repo.ListUserPositions(r.Context(), filter)
+	if err != nil {
+		s.internalError(w, "list positions", err)
+		return
+	}
+
+	data := make([]positionResponse, 0, len(positions))
+	for _, p := range positions {
+		data = append(data, newPositionResponse(p))
+	}
+	resp := listResponse{Data: data}
+	if len(positions) == repository.PageSize(filter.Limit) {
+		resp.NextCursor = strconv.FormatInt(positions[len(positions)-1].ID, 10)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GET /users/{address}/positions/at?chainId=&contract=&pool=&block=&timestamp=&includePending=
+// 用户在指定区块（或时间点所在区块）的持仓，block 与 timestamp 二选一，timestamp 为 Unix 秒或 RFC 3339
+func (s *Server) getUserPositionsAt(w http.ResponseWriter, r *http.Request) {
+	address := r.PathValue("address")
+	if !common.IsHexAddress(address) {
+		writeError(w, http.StatusBadRequest, "invalid user address")
+		return
+	}
+
+	params := queryParams{r: r}
+	filter := repository.PositionAtFilter{
+		ChainID:         params.int64("chainId"),
+		ContractAddress: params.address("contract"),
+		UserAddress:     common.HexToAddress(address).Hex(),
+		PoolID:          params.optionalInt64("pool"),
+		IncludePending:  params.bool("includePending"),
+	}
+	block := params.optionalInt64("block")
+	at := params.time("timestamp")
+	if params.err != nil {
+		writeError(w, http.StatusBadRequest, params.err.Error())
+		return
+	}
+	if filter.ChainID == 0 {
+		writeError(w, http.StatusBadRequest, "chainId is required")
+		return
+	}
+	if (block == nil) == (at == nil) {
+		writeError(w, http.StatusBadRequest, "exactly one of block or timestamp is required")
+		return
+	}
+
+	if block != nil {
+		filter.BlockNumber = *block
+	} else {
+		blockNumber, err := s.repo.GetBlockNumberAt(r.Context(), filter.ChainID, *at)
+		if errors.Is(err, repository.ErrBlockTimeNotIndexed) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		if err != nil {
+			s.internalError(w, "resolve block by time", err)
+			return
+		}
+		filter.BlockNumber = blockNumber
+	}
+
+	positions, err := s.repo.GetPositionsAt(r.Context(), filter)
+	if err != nil {
+		s.internalError(w, "get positions at block", err)
+		return
+	}
+
+	data := make([]positionAtResponse, 0, len(positions))
+	for _, p := range positions {
+		data = append(data, newPositionAtResponse(p))
+	}
+	writeJSON(w, http.StatusOK, positionsAtResponse{
+		ChainID:     filter.ChainID,
+		BlockNumber: filter.BlockNumber,
+		Data:        data,
+	})
+}
+
+// GET /users/{address}/rewards?chainId=&contract=&pool=&block=
+// 用户在指定区块（默认最近确认区块）可领取的 ZeroToken，由归档日志重放计算，与合约 pendingZeroTokenByBlockNumber 一致
+func (s *Server) getUserRewards(w http.ResponseWriter, r *http.Request) {
+	if s.rewards == nil {
+		writeError(w, http.StatusServiceUnavailable, "reward queries are disabled")
+		return
+	}
+	address := r.PathValue("address")
+	if !common.IsHexAddress(address) {
+		writeError(w, http.StatusBadRequest, "invalid user address")
+		return
+	}
+
+	params := queryParams{r: r}
+	chainID := params.int64("chainId")
+	contract := params.address("contract")
+	poolID := params.optionalInt64("pool")
+	block := params.optionalInt64("block")
+	if params.err != nil {
+		writeError(w, http.StatusBadRequest, params.err.Error())
+		return
+	}
+	if chainID == 0 || contract == "" {
+		writeError(w, http.StatusBadRequest, "chainId and contract are required")
+		return
+	}
+
+	rewards, err := s.rewards.PendingRewards(r.Context(), chainID, contract, common.HexToAddress(address), poolID, block)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, reward.ErrParamsNotSynced):
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, reward.ErrUnknownPool), errors.Is(err, reward.ErrInvalidBlockRange):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		s.internalError(w, "get user rewards", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newUserRewardsResponse(rewards))
+}
+
+// GET /claims/daily?chainId=&contract=&pool=&from=&to=&cursor=&limit=
+// 按质押池和区块时间 UTC 日期汇总的领取奖励，from / to 为 YYYY-MM-DD（含）
+func (s *Server) listClaimDaily(w http.ResponseWriter, r *http.Request) {
+	params := queryParams{r: r}
+	filter := repository.ClaimDailyFilter{
+		ChainID:         params.int64("chainId"),
+		ContractAddress: params.address("contract"),
+		PoolID:          params.optionalInt64("pool"),
+		From:            params.date("from"),
+		To:              params.date("to"),
+		AfterID:         params.int64("cursor"),
+		Limit:           int(params.int64("limit")),
+	}
+	if params.err != nil {
+		writeError(w, http.StatusBadRequest, params.err.Error())
+		return
+	}
+
+	days, err := s.repo.ListClaimDaily(r.Context(), filter)
+	if err != nil {
+		s.internalError(w, "list daily claims", err)
+		return
+	}
+
+	data := make([]claimDailyResponse, 0, len(days))
+	for _, d := range days {
+		data = append(data, newClaimDailyResponse(d))
+	}
+	resp := listResponse{Data: data}
+	if len(days) == repository.PageSize(filter.Limit) {
+		resp.NextCursor = strconv.FormatInt(days[len(days)-1].ID, 10)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GET /events?chainId=&contract=&user=&pool=&type=&fromBlock=&toBlock=&fromTime=&toTime=&includePending=&cursor=&limit=
+func (s *Server) listEvents(w http.ResponseWriter, r *http.Request) {
+	params := queryParams{r: r}
+	filter := repository.EventFilter{
+		ChainID:         params.int64("chainId"),
+		ContractAddress: params.address("contract"),
+		UserAddress:     params.address("user"),
+		PoolID:          params.optionalInt64("pool"),
+		EventType:       params.string("type"),
+		FromBlock:       params.int64("fromBlock"),
+		ToBlock:         params.int64("toBlock"),
+		FromTime:        params.time("fromTime"),
+		ToTime:          params.time("toTime"),
+		IncludePending:  params.bool("includePending"),
+		AfterID:         params.int64("cursor"),
+		Limit:           int(params.int64("limit")),
+	}
+	if params.err != nil {
+		writeError(w, http.StatusBadRequest, params.err.Error())
+		return
+	}
+
+	events, err := s.repo.ListEvents(r.Context(), filter)
+	if err != nil {
+		s.internalError(w, "list events", err)
+		return
+	}
+
+	data := make([]eventResponse, 0, len(events))
+	for _, e := range events {
+		data = append(data, newEventResponse(e))
+	}
+	resp := listResponse{Data: data}
+	if len(events) == repository.PageSize(filter.Limit) {
+		resp.NextCursor = strconv.FormatInt(events[len(events)-1].ID, 10)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) internalError(w http.ResponseWriter, op string, err error) {
+	logger.Logger.Error("api query error", zap.String("op", op), zap.Error(err))
+	writeError(w, http.StatusInternalServerError, "internal error")
+}
+
+// queryParams 解析查询参数，记录第一个解析错误
+type queryParams struct {
+	r   *http.Request
+	err error
+}
+
+func (p *queryParams) string(name string) string {
+	return p.r.URL.Query().Get(name)
+}
+
+func (p *queryParams) int64(name string) int64 {
+	v := p.optionalInt64(name)
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func (p *queryParams) optionalInt64(name string) *int64 {
+	raw := p.r.URL.Query().Get(name)
+	if raw == "" {
+		return nil
+	}
+	v, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		p.fail(fmt.Errorf("invalid %s: %q", name, raw))
+		return nil
+	}
+	return &v
+}
+
+// time 解析 Unix 秒或 RFC 3339 格式的时间
+func (p *queryParams) time(name string) *time.Time {
+	raw := p.r.URL.Query().Get(name)
+	if raw == "" {
+		return nil
+	}
+	t, err := parseTime(raw)
+	if err != nil {
+		p.fail(fmt.Errorf("invalid %s: %q", name, raw))
+		return nil
+	}
+	return &t
+}
+
+// parseTime 解析 Unix 秒或 RFC 3339 格式的时间，统一为 UTC
+func parseTime(raw string) (time.Time, error) {
+	if sec, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Unix(sec, 0).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
+
+// date 解析 YYYY-MM-DD 格式的 UTC 日期
+func (p *queryParams) date(name string) *time.Time {
+	raw := p.r.URL.Query().Get(name)
+	if raw == "" {
+		return nil
+	}
+	t, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		p.fail(fmt.Errorf("invalid %s: %q", name, raw))
+		return nil
+	}
+	return &t
+}
+
+func (p *queryParams) bool(name string) bool {
+	raw := p.r.URL.Query().Get(name)
+	if raw == "" {
+		return false
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		p.fail(fmt.Errorf("invalid %s: %q", name, raw))
+		return false
+	}
+	return v
+}
+
+// address 解析地址参数并统一为 checksum 格式
+func (p *queryParams) address(name string) string {
+	raw := p.r.URL.Query().Get(name)
+	if raw == "" {
+		return ""
+	}
+	if !common.IsHexAddress(raw) {
+		p.fail(fmt.Errorf("invalid %s: %q", name, raw))
+		return ""
+	}
+	return common.HexToAddress(raw).Hex()
+}
+
+func (p *queryParams) fail(err error) {
+	if p.err == nil {
+		p.err = err
+	}
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+)
+
+// batchLoader 收集同一层级字段解析时请求的 key，在第一次取值时合并为一次批量查询。
+// GraphQL 执行器按层展开 thunk，同层的关联字段因此只产生一次数据库查询。
+type batchLoader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]bool
+	loaded  map[K]V
+	failed  map[K]error
+}
+
+func newBatchLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *batchLoader[K, V] {
+	return &batchLoader[K, V]{
+		fetch:  fetch,
+		queued: make(map[K]bool),
+		loaded: make(map[K]V),
+		failed: make(map[K]error),
+	}
+}
+
+// load 登记 key 并返回 thunk，由执行器在同层字段全部登记后调用
+func (l *batchLoader[K, V]) load(ctx context.Context, key K) func() (interface{}, error) {
+	l.mu.Lock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if len(l.pending) > 0 {
+			keys := l.pending
+			l.pending = nil
+			values, err := l.fetch(ctx, keys)
+			for _, k := range keys {
+				if err != nil {
+					l.failed[k] = err
+					continue
+				}
+				l.loaded[k] = values[k]
+			}
+		}
+
+		if err := l.failed[key]; err != nil {
+			return nil, err
+		}
+		return l.loaded[key], nil
+	}
+}
+
+// poolListKey 池内列表关联字段的 key
+type poolListKey struct {
+	pool           repository.PoolKey
+	limit          int
+	includePending bool
+}
+
+// loaders 单次 GraphQL 请求内共享的批量加载器
+type loaders struct {
+	pools        *batchLoader[repository.PoolKey, *model.StakingPool]
+	blocks       *batchLoader[repository.BlockKey, *model.ChainBlock]
+	topStakers   *batchLoader[poolListKey, []*model.StakingUserPosition]
+	recentEvents *batchLoader[poolListKey, []*model.StakingEvent]
+}
+
+func newLoaders(repo repository.StakingQueryRepository) *loaders {
+	return &loaders{
+		pools: newBatchLoader(func(ctx context.Context, keys []repository.PoolKey) (map[repository.PoolKey]*model.StakingPool, error) {
+			pools, err := repo.GetPoolsByKeys(ctx, keys)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[repository.PoolKey]*model.StakingPool, len(pools))
+			for _, p := range pools {
+				result[poolKeyOf(p.ChainID, p.ContractAddress, p.PoolID)] = p
+			}
+			return result, nil
+		}),
+		blocks: newBatchLoader(func(ctx context.Context, keys []repository.BlockKey) (map[repository.BlockKey]*model.ChainBlock, error) {
+			blocks, err := repo.GetBlocksByKeys(ctx, keys)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[repository.BlockKey]*model.ChainBlock, len(blocks))
+			for _, b := range blocks {
+				result[repository.BlockKey{ChainID: b.ChainID, BlockNumber: b.BlockNumber}] = b
+			}
+			return result, nil
+		}),
+		topStakers: newBatchLoader(func(ctx context.Context, keys []poolListKey) (map[poolListKey][]*model.StakingUserPosition, error) {
+			result := make(map[poolListKey][]*model.StakingUserPosition, len(keys))
+			for _, group := range groupPoolListKeys(keys) {
+				positions, err := repo.ListTopPositionsByPools(ctx, group.pools, group.maxLimit)
+				if err != nil {
+					return nil, err
+				}
+				byPool := make(map[repository.PoolKey][]*model.StakingUserPosition)
+				for _, p := range positions {
+					k := poolKeyOf(p.ChainID, p.ContractAddress, p.PoolID)
+					byPool[k] = append(byPool[k], p)
+				}
+				for _, k := range group.keys {
+					result[k] = truncate(byPool[k.pool], k.limit)
+				}
+			}
+			return result, nil
+		}),
+		recentEvents: newBatchLoader(func(ctx context.Context, keys []poolListKey) (map[poolListKey][]*model.StakingEvent, error) {
+			result := make(map[poolListKey][]*model.StakingEvent, len(keys))
+			for includePending, group := range groupPoolListKeys(keys) {
+				events, err := repo.ListRecentEventsByPools(ctx, group.pools, group.maxLimit, includePending)
+				if err != nil {
+					return nil, err
+				}
+				byPool := make(map[repository.PoolKey][]*model.StakingEvent)
+				for _, e := range events {
+					k := poolKeyOf(e.ChainID, e.ContractAddress, e.PoolID)
+					byPool[k] = append(byPool[k], e)
+				}
+				for _, k := range group.keys {
+					result[k] = truncate(byPool[k.pool], k.limit)
+				}
+			}
+			return result, nil
+		}),
+	}
+}
+
+type poolListGroup struct {
+	keys     []poolListKey
+	pools    []repository.PoolKey
+	maxLimit int
+}
+
+// groupPoolListKeys 按 includePending 分组，每组用最大 limit 一次查询后再按 key 截断
+func groupPoolListKeys(keys []poolListKey) map[bool]*poolListGroup {
+	groups := make(map[bool]*poolListGroup)
+	seen := make(map[bool]map[repository.PoolKey]bool)
+	for _, k := range keys {
+		g, ok := groups[k.includePending]
+		if !ok {
+			g = &poolListGroup{}
+			groups[k.includePending] = g
+			seen[k.includePending] = make(map[repository.PoolKey]bool)
+		}
+		g.keys = append(g.keys, k)
+		if !seen[k.includePending][k.pool] {
+			seen[k.includePending][k.pool] = true
+			g.pools = append(g.pools, k.pool)
+		}
+		if k.limit > g.maxLimit {
+			g.maxLimit = k.limit
+		}
+	}
+	return groups
+}
+
+func truncate[T any](items []T, limit int) []T {
+	if len(items) > limit {
+		return items[:limit]
+	}
+	return items
+}
+
+func poolKeyOf(chainID int64, contractAddress string, poolID int64) repository.PoolKey {
+	return repository.PoolKey{ChainID: chainID, ContractAddress: contractAddress, PoolID: poolID}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,240 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/decimal"
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/reward"
+	"go.uber.org/zap"
+)
+
+// listResponse 列表响应，NextCursor 为空表示没有更多数据
+type listResponse struct {
+	Data       any    `json:"data"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type poolResponse struct {
+	ChainID             int64  `json:"chain_id"`
+	ContractAddress     string `json:"contract_address"`
+	PoolID              int64  `json:"pool_id"`
+	StTokenAddress      string `json:"st_token_address"`
+	PoolWeight          int64  `json:"pool_weight"`
+	LastRewardBlock     int64  `json:"last_reward_block"`
+	AccZeroTokenPerSt   string `json:"acc_zero_token_per_st"`
+	StTokenAmount       string `json:"st_token_amount"`
+	PendingUnstake      string `json:"pending_unstake"`
+	StakerCount         int64  `json:"staker_count"`
+	MinDepositAmount    string `json:"min_deposit_amount"`
+	UnstakeLockedBlocks int64  `json:"unstake_locked_blocks"`
+	TotalClaimed        string `json:"total_claimed"`
+}
+
+// poolHistoryResponse 质押池在区块结束时的总量
+type poolHistoryResponse struct {
+	BlockNumber    int64  `json:"block_number"`
+	StTokenAmount  string `json:"st_token_amount"`
+	PendingUnstake string `json:"pending_unstake"`
+	StakerCount    int64  `json:"staker_count"`
+}
+
+type positionResponse struct {
+	ChainID         int64      `json:"chain_id"`
+	ContractAddress string     `json:"contract_address"`
+	PoolID          int64      `json:"pool_id"`
+	UserAddress     string     `json:"user_address"`
+	StakedAmount    string     `json:"staked_amount"`
+	RewardDebt      string     `json:"reward_debt"`
+	TotalClaimed    string     `json:"total_claimed"`
+	UpdatedAt       *time.Time `json:"updated_at"`
+}
+
+// positionsAtResponse 历史持仓响应，BlockNumber 为实际查询的区块
+type positionsAtResponse struct {
+	ChainID     int64                `json:"chain_id"`
+	BlockNumber int64                `json:"block_number"`
+	Data        []positionAtResponse `json:"data"`
+}
+
+type positionAtResponse struct {
+	ContractAddress string `json:"contract_address"`
+	PoolID          int64  `json:"pool_id"`
+	UserAddress     string `json:"user_address"`
+	StakedAmount    string `json:"staked_amount"`
+	PendingUnstake  string `json:"pending_unstake"`
+	ClaimedAmount   string `json:"claimed_amount"`
+}
+
+// userRewardsResponse 用户奖励响应，StateBlock 为重放日志的截止区块，之后的奖励按合约公式推算
+type userRewardsResponse struct {
+	ChainID         int64                `json:"chain_id"`
+	ContractAddress string               `json:"contract_address"`
+	UserAddress     string               `json:"user_address"`
+	BlockNumber     int64                `json:"block_number"`
+	StateBlock      int64                `json:"state_block"`
+	Data            []userRewardResponse `json:"data"`
+}
+
+type userRewardResponse struct {
+	PoolID            int64  `json:"pool_id"`
+	StAmount          string `json:"st_amount"`
+	FinishedZeroToken string `json:"finished_zero_token"`
+	PendingZeroToken  string `json:"pending_zero_token"`
+	Pending           string `json:"pending"`
+}
+
+type claimDailyResponse struct {
+	ChainID         int64  `json:"chain_id"`
+	ContractAddress string `json:"contract_address"`
+	PoolID          int64  `json:"pool_id"`
+	Day             string `json:"day"`
+	ClaimedAmount   string `json:"claimed_amount"`
+	ClaimCount      int32  `json:"claim_count"`
+}
+
+type eventResponse struct {
+	ID                 int64      `json:"id"`
+	ChainID            int64      `json:"chain_id"`
+	ContractAddress    string     `json:"contract_address"`
+	PoolID             int64      `json:"pool_id"`
+	EventType          string     `json:"event_type"`
+	UserAddress        string     `json:"user_address"`
+	Amount             string     `json:"amount"`
+	BlockNumber        int64      `json:"block_number"`
+	BlockTime          *time.Time `json:"block_time"`
+	TxHash             string     `json:"tx_hash"`
+	LogIndex           int32      `json:"log_index"`
+	ConfirmationStatus string     `json:"confirmation_status"`
+}
+
+func newPoolResponse(p *model.StakingPool) poolResponse {
+	return poolResponse{
+		ChainID:             p.ChainID,
+		ContractAddress:     p.ContractAddress,
+		PoolID:              p.PoolID,
+		StTokenAddress:      p.StTokenAddress,
+		PoolWeight:          p.PoolWeight,
+		LastRewardBlock:     p.LastRewardBlock,
+		AccZeroTokenPerSt:   strconv.FormatInt(p.AccZeroTokenPerSt, 10),
+		StTokenAmount:       decimal.Deref(p.StTokenAmount).String(),
+		PendingUnstake:      decimal.Deref(p.PendingUnstake).String(),
+		StakerCount:         int64Value(p.StakerCount),
+		MinDepositAmount:    strconv.FormatInt(p.MinDepositAmount, 10),
+		UnstakeLockedBlocks: p.UnstakeLockedBlocks,
+		TotalClaimed:        decimal.Deref(p.TotalClaimed).String(),
+	}
+}
+
+func newPoolHistoryResponse(h *model.StakingPoolHistory) poolHistoryResponse {
+	return poolHistoryResponse{
+		BlockNumber:    h.BlockNumber,
+		StTokenAmount:  h.StTokenAmount.String(),
+		PendingUnstake: h.PendingUnstake.String(),
+		StakerCount:    h.StakerCount,
+	}
+}
+
+func newPositionResponse(p *model.StakingUserPosition) positionResponse {
+	return positionResponse{
+		ChainID:         p.ChainID,
+		ContractAddress: p.ContractAddress,
+		PoolID:          p.PoolID,
+		UserAddress:     p.UserAddress,
+		StakedAmount:    decimal.Deref(p.StakedAmount).String(),
+		RewardDebt:      decimal.Deref(p.RewardDebt).String(),
+		TotalClaimed:    decimal.Deref(p.TotalClaimed).String(),
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
+
+func newPositionAtResponse(p *repository.PositionAt) positionAtResponse {
+	return positionAtResponse{
+		ContractAddress: p.ContractAddress,
+		PoolID:          p.PoolID,
+		UserAddress:     p.UserAddress,
+		StakedAmount:    p.StakedAmount.String(),
+		PendingUnstake:  p.PendingUnstake.String(),
+		ClaimedAmount:   p.ClaimedAmount.String(),
+	}
+}
+
+func newUserRewardsResponse(u *reward.UserRewards) userRewardsResponse {
+	data := make([]userRewardResponse, 0, len(u.Rewards))
+	for _, r := range u.Rewards {
+		data = append(data, userRewardResponse{
+			PoolID:            r.PoolID,
+			StAmount:          r.StAmount.String(),
+			FinishedZeroToken: r.FinishedZeroToken.String(),
+			PendingZeroToken:  r.PendingZeroToken.String(),
+			Pending:           r.Pending.String(),
+		})
+	}
+	return userRewardsResponse{
+		ChainID:         u.ChainID,
+		ContractAddress: u.ContractAddress,
+		UserAddress:     u.UserAddress,
+		BlockNumber:     u.BlockNumber,
+		StateBlock:      u.StateBlock,
+		Data:            data,
+	}
+}
+
+func newClaimDailyResponse(d *model.StakingClaimDaily) claimDailyResponse {
+	return claimDailyResponse{
+		ChainID:         d.ChainID,
+		ContractAddress: d.ContractAddress,
+		PoolID:          d.PoolID,
+		Day:             d.Day.Format(time.DateOnly),
+		ClaimedAmount:   d.ClaimedAmount.String(),
+		ClaimCount:      d.ClaimCount,
+	}
+}
+
+func newEventResponse(e *model.StakingEvent) eventResponse {
+	resp := eventResponse{
+		ID:              e.ID,
+		ChainID:         e.ChainID,
+		ContractAddress: e.ContractAddress,
+		PoolID:          e.PoolID,
+		EventType:       e.EventType,
+		UserAddress:     e.UserAddress,
+		Amount:          e.Amount.String(),
+		BlockNumber:     e.BlockNumber,
+		BlockTime:       e.BlockTime,
+		TxHash:          e.TxHash,
+		LogIndex:        e.LogIndex,
+	}
+	if e.ConfirmationStatus != nil {
+		resp.ConfirmationStatus = *e.ConfirmationStatus
+	}
+	return resp
+}
+
+func int64Value(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Logger.Error("write response error", zap.Error(err))
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
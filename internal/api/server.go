@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/broadcast"
+	"github.com/dijiacoder/staking-indexer/internal/service/reward"
+	"github.com/graphql-go/graphql"
+	"go.uber.org/zap"
+)
+
+// Server 只读 HTTP 查询接口，包含 REST、GraphQL 与 SSE 推送
+type Server struct {
+	repo        repository.StakingQueryRepository
+	broadcaster *broadcast.Broadcaster
+	rewards     *reward.Engine
+	schema      graphql.Schema
+	mux         *http.ServeMux
+}
+
+// broadcaster 为 nil 时不提供事件推送，rewards 为 nil 时不提供奖励查询
+func NewServer(repo repository.StakingQueryRepository, broadcaster *broadcast.Broadcaster, rewards *reward.Engine) (*Server, error) {
+	schema, err := newSchema(repo)
+	if err != nil {
+		return nil, fmt.Errorf("build graphql schema: %w", err)
+	}
+
+	s := &Server{
+		repo:        repo,
+		broadcaster: broadcaster,
+		rewards:     rewards,
+		schema:      schema,
+		mux:         http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("GET /pools", s.listPools)
+	s.mux.HandleFunc("GET /pools/{id}", s.getPool)
+	s.mux.HandleFunc("GET /pools/{id}/history", s.listPoolHistory)
+	s.mux.HandleFunc("GET /users/{address}/positions", s.listUserPositions)
+	s.mux.HandleFunc("GET /users/{address}/positions/at", s.getUserPositionsAt)
+	s.mux.HandleFunc("GET /users/{address}/rewards", s.getUserRewards)
+	s.mux.HandleFunc("GET /claims/daily", s.listClaimDaily)
+	s.mux.HandleFunc("GET /events", s.listEvents)
+	s.mux.HandleFunc("GET /events/stream", s.streamEvents)
+	s.mux.HandleFunc("GET /graphql", s.serveGraphQL)
+	s.mux.HandleFunc("POST /graphql", s.serveGraphQL)
+
+	return s, nil
+}
+
+// Handle 在同一端口挂载额外的处理器
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// ListenAndServe 启动 HTTP 服务，ctx 取消时优雅关闭
+func (s *Server) ListenAndServe(ctx context.Context, port int) error {
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	logger.Logger.Info("Starting API server", zap.String("address", srv.Addr))
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/broadcast"
+	"go.uber.org/zap"
+)
+
+// heartbeatInterval SSE 心跳间隔，防止代理断开空闲连接
+const heartbeatInterval = 15 * time.Second
+
+// SSE 事件名
+const (
+	sseEventStaking = "staking_event"
+	sseEventRetract = "retract"
+)
+
+type retractResponse struct {
+	ChainID           int64    `json:"chain_id"`
+	ContractAddresses []string `json:"contract_addresses"`
+	FromBlock         int64    `json:"from_block"`
+}
+
+// GET /events/stream?chainId=&contract=&user=&pool=
+// 以 Server-Sent Events 推送已确认事件，事件 ID 为 "区块号-log index"，
+// 断线重连时根据 Last-Event-ID（或 lastEventId 参数）补发之后的事件
+func (s *Server) streamEvents(w http.ResponseWriter, r *http.Request) {
+	if s.broadcaster == nil {
+		writeError(w, http.StatusServiceUnavailable, "event streaming is disabled")
+		return
+	}
+
+	params := queryParams{r: r}
+	filter := repository.EventFilter{
+		ChainID:         params.int64("chainId"),
+		ContractAddress: params.address("contract"),
+		UserAddress:     params.address("user"),
+		PoolID:          params.optionalInt64("pool"),
+	}
+	if params.err != nil {
+		writeError(w, http.StatusBadRequest, params.err.Error())
+		return
+	}
+	if filter.ChainID == 0 {
+		writeError(w, http.StatusBadRequest, "chainId is required")
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = params.string("lastEventId")
+	}
+	var resumeFrom *repository.EventPosition
+	if lastEventID != "" {
+		pos, err := parseEventID(lastEventID)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid Last-Event-ID")
+			return
+		}
+		resumeFrom = &pos
+	}
+
+	ctx := r.Context()
+	follower := broadcast.Follow(s.broadcaster, s.repo, filter)
+	defer follower.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	sse := &sseWriter{w: w, rc: http.NewResponseController(w)}
+
+	// 1. 补发 Last-Event-ID 之后的事件
+	if resumeFrom != nil {
+		if err := follower.Replay(ctx, *resumeFrom, sse.sendEvent); err != nil {
+			if ctx.Err() == nil {
+				logger.Logger.Error("replay events error", zap.Error(err))
+			}
+			return
+		}
+	}
+	if err := sse.flush(); err != nil {
+		return
+	}
+
+	// 2. 实时推送与心跳
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if err := sse.comment("heartbeat"); err != nil {
+				return
+			}
+		case msg, ok := <-follower.C():
+			if !ok {
+				// 通知客户端立即重连并从 Last-Event-ID 恢复
+				if errors.Is(follower.Err(), broadcast.ErrLagged) {
+					_ = sse.comment(broadcast.ErrLagged.Error())
+				}
+				return
+			}
+			if !follower.Accept(msg) {
+				continue
+			}
+
+			var err error
+			if msg.Kind == broadcast.KindRetract {
+				err = sse.sendRetract(msg)
+			} else {
+				err = sse.sendEvent(msg.Event)
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// sseWriter 写入 SSE 帧并记录最后发送的事件位置
+type sseWriter struct {
+	w    http.ResponseWriter
+	rc   *http.ResponseController
+	last *repository.EventPosition
+}
+
+func (s *sseWriter) sendEvent(e *model.StakingEvent) error {
+	pos := repository.EventPosition{BlockNumber: e.BlockNumber, LogIndex: e.LogIndex}
+	s.last = &pos
+	return s.send(formatEventID(pos), sseEventStaking, newEventResponse(e))
+}
+
+// sendRetract 回滚区块之后的事件作废，事件 ID 退回到回滚区块，重连时从新分叉补发
+func (s *sseWriter) sendRetract(msg broadcast.Message) error {
+	id := ""
+	if s.last != nil && s.last.BlockNumber > msg.FromBlock {
+		pos := repository.EventPosition{BlockNumber: msg.FromBlock, LogIndex: math.MaxInt32}
+		s.last = &pos
+		id = formatEventID(pos)
+	}
+	return s.send(id, sseEventRetract, retractResponse{
+		ChainID:           msg.ChainID,
+		ContractAddresses: msg.ContractAddresses,
+		FromBlock:         msg.FromBlock,
+	})
+}
+
+func (s *sseWriter) send(id, event string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	if id != "" {
+		fmt.Fprintf(&b, "id: %s\n", id)
+	}
+	fmt.Fprintf(&b, "event: %s\ndata: %s\n\n", event, data)
+	if _, err := s.w.Write([]byte(b.String())); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+func (s *sseWriter) comment(text string) error {
+	if _, err := fmt.Fprintf(s.w, ": %s\n\n", text); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+func (s *sseWriter) flush() error {
+	return s.rc.Flush()
+}
+
+func formatEventID(pos repository.EventPosition) string {
+	return fmt.Sprintf("%d-%d", pos.BlockNumber, pos.LogIndex)
+}
+
+func parseEventID(id string) (repository.EventPosition, error) {
+	blockPart, logPart, ok := strings.Cut(id, "-")
+	if !ok {
+		return repository.EventPosition{}, fmt.Errorf("invalid event id %q", id)
+	}
+	blockNumber, err := strconv.ParseInt(blockPart, 10, 64)
+	if err != nil {
+		return repository.EventPosition{}, err
+	}
+	logIndex, err := strconv.ParseInt(logPart, 10, 32)
+	if err != nil {
+		return repository.EventPosition{}, err
+	}
+	return repository.EventPosition{BlockNumber: blockNumber, LogIndex: int32(logIndex)}, nil
+}
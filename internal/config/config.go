@@ -4,14 +4,25 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
 	Database   Database   `mapstructure:"database"`
-	Ethereum  Ethereum  `mapstructure:"ethereum"`
-	Scanner   Scanner   `mapstructure:"scanner"`
+	Ethereum   Ethereum   `mapstructure:"ethereum"`
+	Scanner    Scanner    `mapstructure:"scanner"`
+	Contracts  []Contract `mapstructure:"contracts"`
+	Chains     []Chain    `mapstructure:"chains"`
 	Prometheus Prometheus `mapstructure:"prometheus"`
+	API        API        `mapstructure:"api"`
+	GRPC       GRPC       `mapstructure:"grpc"`
+	Webhook    Webhook    `mapstructure:"webhook"`
+	Sinks      []Sink     `mapstructure:"sinks"`
+	Outbox     Outbox     `mapstructure:"outbox"`
+	Health     Health     `mapstructure:"health"`
+	Admin      Admin      `mapstructure:"admin"`
+	Reconcile  Reconcile  `mapstructure:"reconcile"`
 }
 
 type Database struct {
@@ -24,12 +35,30 @@ type Ethereum struct {
 	ChainID       int64  `mapstructure:"chain_id"`
 	ContractAddr  string `mapstructure:"contract_address"`
 	Confirmations int64  `mapstructure:"confirmations"`
+	MaxReorgDepth int64  `mapstructure:"max_reorg_depth"`
+	// Finality 确认策略：confirmations / safe / finalized
+	Finality string `mapstructure:"finality"`
+}
+
+// Contract 同一条链上需要索引的合约，每个合约一条 chain_scan_cursor
+type Contract struct {
+	Address    string `mapstructure:"address"`
+	Name       string `mapstructure:"name"`
+	StartBlock int64  `mapstructure:"start_block"`
+	// Handlers 启用的事件处理器，为空时启用全部
+	Handlers []string `mapstructure:"handlers"`
 }
 
 type Scanner struct {
 	BatchSize    int `mapstructure:"batch_size"`
 	ScanInterval int `mapstructure:"scan_interval"`
 	ScanTimeout  int `mapstructure:"scan_timeout"`
+	// IndexPending 是否索引安全高度以上的区块（pending 状态，随确认数增长提升为 confirmed）
+	IndexPending bool `mapstructure:"index_pending"`
+	// RecoverRollback 启动时自动恢复停留在回滚中（scan_status = 2）的游标
+	RecoverRollback bool `mapstructure:"recover_rollback"`
+	// SnapshotInterval 每隔多少个已确认区块生成一次持仓快照，0 表示不生成
+	SnapshotInterval int64 `mapstructure:"snapshot_interval"`
 }
 
 type Prometheus struct {
@@ -37,6 +66,161 @@ type Prometheus struct {
 	Port    int  `mapstructure:"port"`
 }
 
+// Chain 单条链的扫描配置，每条链独立的 RPC 客户端和扫描器
+// 扫描参数为 0 时使用 [scanner] 中的默认值
+type Chain struct {
+	Name          string `mapstructure:"name"`
+	RPCURL        string `mapstructure:"rpc_url"`
+	ChainID       int64  `mapstructure:"chain_id"`
+	Confirmations int64  `mapstructure:"confirmations"`
+	MaxReorgDepth int64  `mapstructure:"max_reorg_depth"`
+	Finality      string `mapstructure:"finality"`
+	BatchSize     int    `mapstructure:"batch_size"`
+	ScanInterval  int    `mapstructure:"scan_interval"`
+	ScanTimeout   int    `mapstructure:"scan_timeout"`
+	// IndexPending、RecoverRollback 未配置时使用 [scanner] 中的值，配置为 false 时覆盖全局开关
+	IndexPending *bool `mapstructure:"index_pending"`
+	// RecoverRollback 启动时自动恢复停留在回滚中（scan_status = 2）的游标
+	RecoverRollback  *bool      `mapstructure:"recover_rollback"`
+	SnapshotInterval int64      `mapstructure:"snapshot_interval"`
+	Contracts        []Contract `mapstructure:"contracts"`
+}
+
+// API 只读查询接口配置
+type API struct {
+	Enabled bool `mapstructure:"enabled"`
+	Port    int  `mapstructure:"port"`
+}
+
+// GRPC gRPC 查询与事件推送接口配置
+type GRPC struct {
+	Enabled bool `mapstructure:"enabled"`
+	Port    int  `mapstructure:"port"`
+}
+
+// Webhook 事件回调投递配置，订阅在 webhook_subscriptions 表中维护
+type Webhook struct {
+	Enabled      bool `mapstructure:"enabled"`
+	PollInterval int  `mapstructure:"poll_interval"` // 投递队列轮询间隔（秒）
+	Timeout      int  `mapstructure:"timeout"`       // 单次请求超时（秒）
+	MaxAttempts  int  `mapstructure:"max_attempts"`  // 最大尝试次数，超过后标记为 failed
+}
+
+// Outbox 事件发件箱消费配置，webhook 与 [[sinks]] 从发件箱读取事件
+type Outbox struct {
+	PollInterval   int `mapstructure:"poll_interval"`   // 轮询间隔（秒），默认 1
+	BatchSize      int `mapstructure:"batch_size"`      // 单批读取条数，默认 500
+	RetentionHours int `mapstructure:"retention_hours"` // 保留时长（小时），0 表示不清理
+	GapTimeout     int `mapstructure:"gap_timeout"`     // id 空洞等待未提交事务的时长（秒），默认 60
+}
+
+// Health /healthz 与 /readyz 检查配置
+type Health struct {
+	MaxLagBlocks     int64 `mapstructure:"max_lag_blocks"`     // 就绪时允许的最大同步延迟（区块数），默认 50
+	MaxScanIntervals int   `mapstructure:"max_scan_intervals"` // 最近一次成功扫描距今不超过的扫描间隔数，默认 10
+	Timeout          int   `mapstructure:"timeout"`            // 数据库与 RPC 检查超时（秒），默认 3
+}
+
+// Admin 管理接口配置，请求需携带 Authorization: Bearer <token>
+type Admin struct {
+	Enabled         bool              `mapstructure:"enabled"`
+	Port            int               `mapstructure:"port"`
+	Tokens          map[string]string `mapstructure:"tokens"`            // 名称 → 令牌，名称记录为审计操作人
+	JobPollInterval int               `mapstructure:"job_poll_interval"` // 重扫任务轮询间隔（秒），默认 5
+}
+
+// Reconcile 链上对账配置，在最近确认区块通过 eth_call 读取合约视图函数与索引数据比对
+type Reconcile struct {
+	Enabled    bool    `mapstructure:"enabled"`
+	Interval   int     `mapstructure:"interval"`    // 对账间隔（秒），默认 3600
+	SampleSize int     `mapstructure:"sample_size"` // 每次抽查的用户持仓数，0 表示全部
+	AutoRepair bool    `mapstructure:"auto_repair"` // 按链上值修复用户质押数量和质押池参数
+	Tolerance  float64 `mapstructure:"tolerance"`   // 数量比对的相对误差，默认 1e-9，为 0 时要求完全一致
+}
+
+// Sink 事件输出配置，type 为 stdout、file 或通过 sink.Register 注册的类型
+type Sink struct {
+	Type       string `mapstructure:"type"`
+	Path       string `mapstructure:"path"`        // file: 输出文件路径
+	MaxSize    int    `mapstructure:"max_size"`    // file: 单个文件上限（MB），超过后轮转，默认 100
+	MaxBackups int    `mapstructure:"max_backups"` // file: 保留的轮转文件数，0 表示全部保留
+}
+
+// ChainList 返回需要扫描的链，未配置 [[chains]] 时由 [ethereum]、[scanner] 和 [[contracts]] 组成一条链
+func (c *Config) ChainList() []Chain {
+	if len(c.Chains) == 0 {
+		return []Chain{{
+			Name:             fmt.Sprintf("chain-%d", c.Ethereum.ChainID),
+			RPCURL:           c.Ethereum.RPCURL,
+			ChainID:          c.Ethereum.ChainID,
+			Confirmations:    c.Ethereum.Confirmations,
+			MaxReorgDepth:    c.Ethereum.MaxReorgDepth,
+			Finality:         c.Ethereum.Finality,
+			BatchSize:        c.Scanner.BatchSize,
+			ScanInterval:     c.Scanner.ScanInterval,
+			ScanTimeout:      c.Scanner.ScanTimeout,
+			IndexPending:     boolPtr(c.Scanner.IndexPending),
+			RecoverRollback:  boolPtr(c.Scanner.RecoverRollback),
+			SnapshotInterval: c.Scanner.SnapshotInterval,
+			Contracts:        c.ContractList(),
+		}}
+	}
+
+	chains := make([]Chain, 0, len(c.Chains))
+	for _, chain := range c.Chains {
+		if chain.Name == "" {
+			chain.Name = fmt.Sprintf("chain-%d", chain.ChainID)
+		}
+		if chain.BatchSize == 0 {
+			chain.BatchSize = c.Scanner.BatchSize
+		}
+		if chain.ScanInterval == 0 {
+			chain.ScanInterval = c.Scanner.ScanInterval
+		}
+		if chain.ScanTimeout == 0 {
+			chain.ScanTimeout = c.Scanner.ScanTimeout
+		}
+		if chain.SnapshotInterval == 0 {
+			chain.SnapshotInterval = c.Scanner.SnapshotInterval
+		}
+		if chain.Confirmations == 0 {
+			chain.Confirmations = c.Ethereum.Confirmations
+		}
+		if chain.MaxReorgDepth == 0 {
+			chain.MaxReorgDepth = c.Ethereum.MaxReorgDepth
+		}
+		if chain.Finality == "" {
+			chain.Finality = c.Ethereum.Finality
+		}
+		if chain.IndexPending == nil {
+			chain.IndexPending = boolPtr(c.Scanner.IndexPending)
+		}
+		if chain.RecoverRollback == nil {
+			chain.RecoverRollback = boolPtr(c.Scanner.RecoverRollback)
+		}
+		chains = append(chains, chain)
+	}
+	return chains
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+// ContractList 返回需要索引的合约，未配置 [[contracts]] 时使用 ethereum.contract_address
+func (c *Config) ContractList() []Contract {
+	if len(c.Contracts) > 0 {
+		return c.Contracts
+	}
+	if c.Ethereum.ContractAddr == "" {
+		return nil
+	}
+	return []Contract{{
+		Address: c.Ethereum.ContractAddr,
+		Name:    "staking",
+	}}
+}
+
 func LoadConfig(configPath string) (*Config, error) {
 	v := viper.New()
 	v.SetConfigFile(configPath)
@@ -52,5 +236,40 @@ func LoadConfig(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	// 合约地址统一为 checksum 格式，与写入的数据及各接口的查询参数一致
+	if err := normalizeAddresses(&cfg); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
+
+// normalizeAddresses 校验配置中的合约地址并转换为 checksum 格式
+func normalizeAddresses(cfg *Config) error {
+	normalize := func(addr *string) error {
+		if *addr == "" {
+			return nil
+		}
+		if !common.IsHexAddress(*addr) {
+			return fmt.Errorf("invalid contract address %q", *addr)
+		}
+		*addr = common.HexToAddress(*addr).Hex()
+		return nil
+	}
+	if err := normalize(&cfg.Ethereum.ContractAddr); err != nil {
+		return err
+	}
+	for i := range cfg.Contracts {
+		if err := normalize(&cfg.Contracts[i].Address); err != nil {
+			return err
+		}
+	}
+	for i := range cfg.Chains {
+		for j := range cfg.Chains[i].Contracts {
+			if err := normalize(&cfg.Chains[i].Contracts[j].Address); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
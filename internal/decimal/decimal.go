@@ -0,0 +1,182 @@
+// Package decimal 提供与 DECIMAL(38,0) 列对应的精确整数类型，链上数量（wei）超过 2^53 时不经过浮点数
+package decimal
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Int 不可变的十进制整数，零值为 0。读写数据库与 JSON 时使用十进制字符串
+type Int struct {
+	v *big.Int
+}
+
+// Zero 0
+var Zero = Int{}
+
+// New 复制 v 构造 Int，v 为 nil 时为 0
+func New(v *big.Int) Int {
+	if v == nil {
+		return Int{}
+	}
+	return Int{v: new(big.Int).Set(v)}
+}
+
+// NewFromInt64 由 int64 构造 Int
+func NewFromInt64(v int64) Int {
+	return Int{v: big.NewInt(v)}
+}
+
+// Parse 解析十进制整数字符串，允许 MySQL 返回的 "123.000" 这类小数部分为 0 的写法
+func Parse(s string) (Int, error) {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		if strings.Trim(s[i+1:], "0") != "" {
+			return Int{}, fmt.Errorf("decimal: %q is not an integer", s)
+		}
+		s = s[:i]
+	}
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return Int{}, fmt.Errorf("decimal: invalid integer %q", s)
+	}
+	return Int{v: v}, nil
+}
+
+// Ptr 返回 d 的指针，用于数据库默认值对应的指针字段
+func Ptr(d Int) *Int {
+	return &d
+}
+
+// Deref 指针为 nil 时返回 0
+func Deref(d *Int) Int {
+	if d == nil {
+		return Int{}
+	}
+	return *d
+}
+
+func (d Int) big() *big.Int {
+	if d.v == nil {
+		return new(big.Int)
+	}
+	return d.v
+}
+
+// BigInt 返回 d 的副本
+func (d Int) BigInt() *big.Int {
+	return new(big.Int).Set(d.big())
+}
+
+func (d Int) Add(x Int) Int {
+	return Int{v: new(big.Int).Add(d.big(), x.big())}
+}
+
+func (d Int) Sub(x Int) Int {
+	return Int{v: new(big.Int).Sub(d.big(), x.big())}
+}
+
+func (d Int) Neg() Int {
+	return Int{v: new(big.Int).Neg(d.big())}
+}
+
+// Mul 乘以 int64，用于按符号计入或撤销
+func (d Int) Mul(x int64) Int {
+	return Int{v: new(big.Int).Mul(d.big(), big.NewInt(x))}
+}
+
+// Cmp 比较 d 与 x，返回 -1、0 或 1
+func (d Int) Cmp(x Int) int {
+	return d.big().Cmp(x.big())
+}
+
+// Sign 返回 -1、0 或 1
+func (d Int) Sign() int {
+	return d.big().Sign()
+}
+
+func (d Int) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Float64 近似值，只用于指标等不要求精确的场景
+func (d Int) Float64() float64 {
+	f, _ := new(big.Float).SetInt(d.big()).Float64()
+	return f
+}
+
+// String 十进制字符串，不使用科学计数法
+func (d Int) String() string {
+	return d.big().String()
+}
+
+// Scan 实现 sql.Scanner，DECIMAL 列由驱动返回字符串或字节
+func (d *Int) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = Int{}
+		return nil
+	case []byte:
+		return d.parse(string(v))
+	case string:
+		return d.parse(v)
+	case int64:
+		*d = NewFromInt64(v)
+		return nil
+	case float64:
+		// SQLite 等驱动可能以浮点数返回，只接受整数值
+		f := new(big.Float).SetFloat64(v)
+		if !f.IsInt() {
+			return fmt.Errorf("decimal: %v is not an integer", v)
+		}
+		i, _ := f.Int(nil)
+		*d = Int{v: i}
+		return nil
+	default:
+		return fmt.Errorf("decimal: cannot scan %T", src)
+	}
+}
+
+func (d *Int) parse(s string) error {
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Value 实现 driver.Valuer，以字符串写入避免精度损失
+func (d Int) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// MarshalJSON 输出为 JSON 字符串，避免客户端按浮点数解析
+func (d Int) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON 接受 JSON 字符串或数字，数字可能是旧版本按浮点数写入的 1e+21 等形式
+func (d *Int) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		*d = Int{}
+		return nil
+	}
+	if unquoted, ok := strings.CutPrefix(s, `"`); ok {
+		return d.parse(strings.TrimSuffix(unquoted, `"`))
+	}
+	if err := d.parse(s); err == nil {
+		return nil
+	}
+	f, _, err := big.ParseFloat(s, 10, 256, big.ToNearestEven)
+	if err != nil || !f.IsInt() {
+		return fmt.Errorf("decimal: invalid JSON number %s", s)
+	}
+	v, _ := f.Int(nil)
+	*d = Int{v: v}
+	return nil
+}
@@ -0,0 +1,84 @@
+package decimal
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	cases := []struct {
+		src  interface{}
+		want string
+	}{
+		{[]byte("123456789012345678901234567890"), "123456789012345678901234567890"},
+		{"9007199254740993", "9007199254740993"},
+		{"-5.000", "-5"},
+		{int64(42), "42"},
+		{float64(1e18), "1000000000000000000"},
+		{nil, "0"},
+	}
+	for _, tc := range cases {
+		var d Int
+		if err := d.Scan(tc.src); err != nil {
+			t.Fatalf("scan %v: %v", tc.src, err)
+		}
+		if d.String() != tc.want {
+			t.Fatalf("scan %v = %s, want %s", tc.src, d, tc.want)
+		}
+	}
+
+	for _, src := range []interface{}{"1.5", "abc", float64(0.5), true} {
+		var d Int
+		if err := d.Scan(src); err == nil {
+			t.Fatalf("scan %v: want error, got %s", src, d)
+		}
+	}
+}
+
+func TestArithmeticAbove2Pow53(t *testing.T) {
+	a, err := Parse("9007199254740993")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := a.Add(NewFromInt64(1)).Sub(NewFromInt64(3)).Mul(-1)
+	if sum.String() != "-9007199254740991" {
+		t.Fatalf("sum = %s", sum)
+	}
+	if v, _ := a.Value(); v != "9007199254740993" {
+		t.Fatalf("value = %v", v)
+	}
+	var zero Int
+	if !zero.IsZero() || zero.Add(a).Cmp(a) != 0 || Deref(nil).String() != "0" {
+		t.Fatal("zero value should behave as 0")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type payload struct {
+		Amount Int `json:"amount"`
+	}
+	a, _ := Parse("123456789012345678901")
+	data, err := json.Marshal(payload{Amount: a})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"amount":"123456789012345678901"}` {
+		t.Fatalf("marshal = %s", data)
+	}
+
+	// 字符串、整数与旧版本按浮点数写入的数字
+	for input, want := range map[string]string{
+		string(data):                     "123456789012345678901",
+		`{"amount":1000000000000000000}`: "1000000000000000000",
+		`{"amount":1e+21}`:               "1000000000000000000000",
+		`{"amount":null}`:                "0",
+	} {
+		var p payload
+		if err := json.Unmarshal([]byte(input), &p); err != nil {
+			t.Fatalf("unmarshal %s: %v", input, err)
+		}
+		if p.Amount.String() != want {
+			t.Fatalf("unmarshal %s = %s, want %s", input, p.Amount, want)
+		}
+	}
+}
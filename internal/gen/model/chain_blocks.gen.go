@@ -12,13 +12,14 @@ const TableNameChainBlock = "chain_blocks"
 
 // ChainBlock 区块头缓存表（用于Reorg处理）
 type ChainBlock struct {
-	ID          int64      `gorm:"column:id;type:bigint;primaryKey;autoIncrement:true;comment:主键" json:"id"`                                        // 主键
-	ChainID     int64      `gorm:"column:chain_id;type:bigint;not null;uniqueIndex:uk_chain_block,priority:1;comment:链ID" json:"chain_id"`          // 链ID
-	BlockNumber int64      `gorm:"column:block_number;type:bigint;not null;uniqueIndex:uk_chain_block,priority:2;comment:区块高度" json:"block_number"` // 区块高度
-	BlockHash   string     `gorm:"column:block_hash;type:varchar(66);not null;index:idx_block_hash,priority:1;comment:区块Hash" json:"block_hash"`    // 区块Hash
-	ParentHash  string     `gorm:"column:parent_hash;type:varchar(66);not null;comment:父区块Hash" json:"parent_hash"`                                 // 父区块Hash
-	IsConfirmed int32      `gorm:"column:is_confirmed;type:tinyint;not null;comment:是否已确认" json:"is_confirmed"`                                     // 是否已确认
-	CreatedAt   *time.Time `gorm:"column:created_at;type:timestamp;not null;default:CURRENT_TIMESTAMP;comment:记录时间" json:"created_at"`              // 记录时间
+	ID          int64      `gorm:"column:id;type:bigint;primaryKey;autoIncrement:true;comment:主键" json:"id"`                                                               // 主键
+	ChainID     int64      `gorm:"column:chain_id;type:bigint;not null;uniqueIndex:uk_chain_block,priority:1;index:idx_chain_time,priority:1;comment:链ID" json:"chain_id"` // 链ID
+	BlockNumber int64      `gorm:"column:block_number;type:bigint;not null;uniqueIndex:uk_chain_block,priority:2;comment:区块高度" json:"block_number"`                        // 区块高度
+	BlockHash   string     `gorm:"column:block_hash;type:varchar(66);not null;index:idx_block_hash,priority:1;comment:区块Hash" json:"block_hash"`                           // 区块Hash
+	ParentHash  string     `gorm:"column:parent_hash;type:varchar(66);not null;comment:父区块Hash" json:"parent_hash"`                                                        // 父区块Hash
+	IsConfirmed int32      `gorm:"column:is_confirmed;type:tinyint;not null;comment:是否已确认" json:"is_confirmed"`                                                            // 是否已确认
+	BlockTime   *time.Time `gorm:"column:block_time;type:datetime;index:idx_chain_time,priority:2;comment:区块时间（UTC）" json:"block_time"`                                    // 区块时间（UTC）
+	CreatedAt   *time.Time `gorm:"column:created_at;type:timestamp;not null;default:CURRENT_TIMESTAMP;comment:记录时间" json:"created_at"`                                     // 记录时间
 }
 
 // TableName ChainBlock's table name
@@ -19,7 +19,10 @@ type ChainScanCursor struct {
 	LastScannedBlock   int64      `gorm:"column:last_scanned_block;type:bigint;not null;index:idx_chain_scan,priority:2;comment:最近已扫描区块（可能未确认）" json:"last_scanned_block"`                                 // 最近已扫描区块（可能未确认）
 	LastConfirmedBlock int64      `gorm:"column:last_confirmed_block;type:bigint;not null;comment:最近已确认区块高度" json:"last_confirmed_block"`                                                                  // 最近已确认区块高度
 	ConfirmationBlocks *int32     `gorm:"column:confirmation_blocks;type:int;not null;default:12;comment:确认区块数" json:"confirmation_blocks"`                                                                // 确认区块数
-	ScanStatus         *int32     `gorm:"column:scan_status;type:tinyint;not null;default:1;comment:扫描状态：1-正常 2-回滚中 3-暂停" json:"scan_status"`                                                              // 扫描状态：1-正常 2-回滚中 3-暂停
+	ScanStatus         *int32     `gorm:"column:scan_status;type:tinyint;not null;default:1;comment:扫描状态：1-正常 2-回滚中（回滚深度超限待人工处理） 3-暂停" json:"scan_status"`                                                 // 扫描状态：1-正常 2-回滚中（回滚深度超限待人工处理） 3-暂停
+	LastSnapshotBlock  *int64     `gorm:"column:last_snapshot_block;type:bigint;not null;default:0;comment:最近生成持仓快照的区块" json:"last_snapshot_block"`                                                        // 最近生成持仓快照的区块
+	LastReorgBlock     *int64     `gorm:"column:last_reorg_block;type:bigint;not null;default:0;comment:最近一次重组回滚到的区块" json:"last_reorg_block"`                                                             // 最近一次重组回滚到的区块
+	LastReorgAt        *time.Time `gorm:"column:last_reorg_at;type:timestamp;comment:最近一次重组时间" json:"last_reorg_at"`                                                                                       // 最近一次重组时间
 	CreatedAt          *time.Time `gorm:"column:created_at;type:timestamp;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`                                                              // 创建时间
 	UpdatedAt          *time.Time `gorm:"column:updated_at;type:timestamp;not null;default:CURRENT_TIMESTAMP;comment:更新时间" json:"updated_at"`                                                              // 更新时间
 }
@@ -6,23 +6,27 @@ package model
 
 import (
 	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/decimal"
 )
 
 const TableNameStakingEvent = "staking_events"
 
 // StakingEvent Staking事件表（仅存确认后数据）
 type StakingEvent struct {
-	ID              int64      `gorm:"column:id;type:bigint;primaryKey;autoIncrement:true;comment:主键" json:"id"`                                                   // 主键
-	ChainID         int64      `gorm:"column:chain_id;type:bigint;not null;index:idx_pool_block,priority:1;index:idx_user,priority:1;comment:链ID" json:"chain_id"` // 链ID
-	ContractAddress string     `gorm:"column:contract_address;type:varchar(42);not null;comment:合约地址" json:"contract_address"`                                     // 合约地址
-	PoolID          int64      `gorm:"column:pool_id;type:bigint;not null;index:idx_pool_block,priority:2;comment:Pool ID" json:"pool_id"`                         // Pool ID
-	EventType       string     `gorm:"column:event_type;type:varchar(16);not null;comment:事件类型：Deposit / Withdraw / Claim" json:"event_type"`                      // 事件类型：Deposit / Withdraw / Claim
-	UserAddress     string     `gorm:"column:user_address;type:varchar(42);not null;index:idx_user,priority:2;comment:用户地址" json:"user_address"`                   // 用户地址
-	Amount          float64    `gorm:"column:amount;type:decimal(38,0);not null;comment:数量（wei）" json:"amount"`                                                    // 数量（wei）
-	BlockNumber     int64      `gorm:"column:block_number;type:bigint;not null;index:idx_pool_block,priority:3;comment:区块高度" json:"block_number"`                  // 区块高度
-	TxHash          string     `gorm:"column:tx_hash;type:varchar(66);not null;uniqueIndex:uk_tx_log,priority:1;comment:交易Hash" json:"tx_hash"`                    // 交易Hash
-	LogIndex        int32      `gorm:"column:log_index;type:int;not null;uniqueIndex:uk_tx_log,priority:2;comment:日志索引" json:"log_index"`                          // 日志索引
-	CreatedAt       *time.Time `gorm:"column:created_at;type:timestamp;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`                         // 创建时间
+	ID                 int64       `gorm:"column:id;type:bigint;primaryKey;autoIncrement:true;comment:主键" json:"id"`                                                                                                                           // 主键
+	ChainID            int64       `gorm:"column:chain_id;type:bigint;not null;index:idx_chain_block_time,priority:1;index:idx_pool_block,priority:1;index:idx_status_block,priority:1;index:idx_user,priority:1;comment:链ID" json:"chain_id"` // 链ID
+	ContractAddress    string      `gorm:"column:contract_address;type:varchar(42);not null;comment:合约地址" json:"contract_address"`                                                                                                             // 合约地址
+	PoolID             int64       `gorm:"column:pool_id;type:bigint;not null;index:idx_pool_block,priority:2;comment:Pool ID" json:"pool_id"`                                                                                                 // Pool ID
+	EventType          string      `gorm:"column:event_type;type:varchar(16);not null;comment:事件类型：Deposit / Withdraw / Claim" json:"event_type"`                                                                                              // 事件类型：Deposit / Withdraw / Claim
+	UserAddress        string      `gorm:"column:user_address;type:varchar(42);not null;index:idx_user,priority:2;comment:用户地址" json:"user_address"`                                                                                           // 用户地址
+	Amount             decimal.Int `gorm:"column:amount;type:decimal(38,0);not null;comment:数量（wei）" json:"amount"`                                                                                                                            // 数量（wei）
+	BlockNumber        int64       `gorm:"column:block_number;type:bigint;not null;index:idx_pool_block,priority:3;index:idx_status_block,priority:3;comment:区块高度" json:"block_number"`                                                        // 区块高度
+	BlockTime          *time.Time  `gorm:"column:block_time;type:datetime;index:idx_chain_block_time,priority:2;comment:区块时间（UTC）" json:"block_time"`                                                                                          // 区块时间（UTC）
+	TxHash             string      `gorm:"column:tx_hash;type:varchar(66);not null;uniqueIndex:uk_tx_log,priority:1;comment:交易Hash" json:"tx_hash"`                                                                                            // 交易Hash
+	LogIndex           int32       `gorm:"column:log_index;type:int;not null;uniqueIndex:uk_tx_log,priority:2;comment:日志索引" json:"log_index"`                                                                                                  // 日志索引
+	ConfirmationStatus *string     `gorm:"column:confirmation_status;type:varchar(16);not null;index:idx_status_block,priority:2;default:confirmed;comment:确认状态：pending / confirmed / orphaned" json:"confirmation_status"`                    // 确认状态：pending / confirmed / orphaned
+	CreatedAt          *time.Time  `gorm:"column:created_at;type:timestamp;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`                                                                                                 // 创建时间
 }
 
 // TableName StakingEvent's table name
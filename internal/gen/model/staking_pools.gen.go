@@ -6,25 +6,30 @@ package model
 
 import (
 	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/decimal"
 )
 
 const TableNameStakingPool = "staking_pools"
 
 // StakingPool Staking池定义表
 type StakingPool struct {
-	ID                  int64      `gorm:"column:id;type:bigint;primaryKey;autoIncrement:true;comment:主键" json:"id"`                                                     // 主键
-	ChainID             int64      `gorm:"column:chain_id;type:bigint;not null;uniqueIndex:uk_pool,priority:1;comment:链ID" json:"chain_id"`                              // 链ID
-	ContractAddress     string     `gorm:"column:contract_address;type:varchar(42);not null;uniqueIndex:uk_pool,priority:2;comment:Staking合约地址" json:"contract_address"` // Staking合约地址
-	PoolID              int64      `gorm:"column:pool_id;type:bigint;not null;uniqueIndex:uk_pool,priority:3;comment:Pool ID（合约内定义）" json:"pool_id"`                     // Pool ID（合约内定义）
-	StTokenAddress      string     `gorm:"column:st_token_address;type:varchar(42);not null;comment:质押代币的地址（ETH为0x0）" json:"st_token_address"`                           // 质押代币的地址（ETH为0x0）
-	PoolWeight          int64      `gorm:"column:pool_weight;type:bigint;not null;comment:不同资金池所占的权重" json:"pool_weight"`                                                // 不同资金池所占的权重
-	LastRewardBlock     int64      `gorm:"column:last_reward_block;type:bigint;not null;comment:最后一次分配奖励的区块号" json:"last_reward_block"`                                  // 最后一次分配奖励的区块号
-	AccZeroTokenPerSt   int64      `gorm:"column:acc_zero_token_per_st;type:bigint;not null;comment:质押 1个ETH经过1个区块高度，能拿到 n 个ZeroToken" json:"acc_zero_token_per_st"`     // 质押 1个ETH经过1个区块高度，能拿到 n 个ZeroToken
-	StTokenAmount       int64      `gorm:"column:st_token_amount;type:bigint;not null;comment:质押的代币数量" json:"st_token_amount"`                                           // 质押的代币数量
-	MinDepositAmount    int64      `gorm:"column:min_deposit_amount;type:bigint;not null;comment:最小质押数量" json:"min_deposit_amount"`                                      // 最小质押数量
-	UnstakeLockedBlocks int64      `gorm:"column:unstake_locked_blocks;type:bigint;not null;comment:解质押锁定的区块高度" json:"unstake_locked_blocks"`                            // 解质押锁定的区块高度
-	CreatedAt           *time.Time `gorm:"column:created_at;type:timestamp;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`                           // 创建时间
-	UpdatedAt           *time.Time `gorm:"column:updated_at;type:timestamp;comment:更新时间" json:"updated_at"`                                                              // 更新时间
+	ID                  int64        `gorm:"column:id;type:bigint;primaryKey;autoIncrement:true;comment:主键" json:"id"`                                                          // 主键
+	ChainID             int64        `gorm:"column:chain_id;type:bigint;not null;uniqueIndex:uk_pool,priority:1;comment:链ID" json:"chain_id"`                                   // 链ID
+	ContractAddress     string       `gorm:"column:contract_address;type:varchar(42);not null;uniqueIndex:uk_pool,priority:2;comment:Staking合约地址" json:"contract_address"`      // Staking合约地址
+	PoolID              int64        `gorm:"column:pool_id;type:bigint;not null;uniqueIndex:uk_pool,priority:3;comment:Pool ID（合约内定义）" json:"pool_id"`                          // Pool ID（合约内定义）
+	StTokenAddress      string       `gorm:"column:st_token_address;type:varchar(42);not null;comment:质押代币的地址（ETH为0x0）" json:"st_token_address"`                                // 质押代币的地址（ETH为0x0）
+	PoolWeight          int64        `gorm:"column:pool_weight;type:bigint;not null;comment:不同资金池所占的权重" json:"pool_weight"`                                                     // 不同资金池所占的权重
+	LastRewardBlock     int64        `gorm:"column:last_reward_block;type:bigint;not null;comment:最后一次分配奖励的区块号" json:"last_reward_block"`                                       // 最后一次分配奖励的区块号
+	AccZeroTokenPerSt   int64        `gorm:"column:acc_zero_token_per_st;type:bigint;not null;comment:质押 1个ETH经过1个区块高度，能拿到 n 个ZeroToken" json:"acc_zero_token_per_st"`          // 质押 1个ETH经过1个区块高度，能拿到 n 个ZeroToken
+	StTokenAmount       *decimal.Int `gorm:"column:st_token_amount;type:decimal(38,0);not null;default:0;comment:质押的代币数量（Deposit 减 RequestUnstake）" json:"st_token_amount"`     // 质押的代币数量（Deposit 减 RequestUnstake）
+	PendingUnstake      *decimal.Int `gorm:"column:pending_unstake;type:decimal(38,0);not null;default:0;comment:已申请赎回未提取数量（RequestUnstake 减 Withdraw）" json:"pending_unstake"` // 已申请赎回未提取数量（RequestUnstake 减 Withdraw）
+	StakerCount         *int64       `gorm:"column:staker_count;type:bigint;not null;default:0;comment:质押数量扣除待提取后大于 0 的用户数" json:"staker_count"`                                // 质押数量扣除待提取后大于 0 的用户数
+	MinDepositAmount    int64        `gorm:"column:min_deposit_amount;type:bigint;not null;comment:最小质押数量" json:"min_deposit_amount"`                                           // 最小质押数量
+	UnstakeLockedBlocks int64        `gorm:"column:unstake_locked_blocks;type:bigint;not null;comment:解质押锁定的区块高度" json:"unstake_locked_blocks"`                                 // 解质押锁定的区块高度
+	TotalClaimed        *decimal.Int `gorm:"column:total_claimed;type:decimal(38,0);not null;default:0;comment:累计领取奖励" json:"total_claimed"`                                    // 累计领取奖励
+	CreatedAt           *time.Time   `gorm:"column:created_at;type:timestamp;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`                                // 创建时间
+	UpdatedAt           *time.Time   `gorm:"column:updated_at;type:timestamp;comment:更新时间" json:"updated_at"`                                                                   // 更新时间
 }
 
 // TableName StakingPool's table name
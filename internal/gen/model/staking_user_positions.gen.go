@@ -6,20 +6,24 @@ package model
 
 import (
 	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/decimal"
 )
 
 const TableNameStakingUserPosition = "staking_user_positions"
 
 // StakingUserPosition 用户质押实时状态（链下计算）
 type StakingUserPosition struct {
-	ID              int64      `gorm:"column:id;type:bigint;primaryKey;autoIncrement:true;comment:主键" json:"id"`                                                   // 主键
-	ChainID         int64      `gorm:"column:chain_id;type:bigint;not null;uniqueIndex:uk_user_pool,priority:1;comment:链ID" json:"chain_id"`                       // 链ID
-	ContractAddress string     `gorm:"column:contract_address;type:varchar(42);not null;uniqueIndex:uk_user_pool,priority:2;comment:合约地址" json:"contract_address"` // 合约地址
-	PoolID          int64      `gorm:"column:pool_id;type:bigint;not null;uniqueIndex:uk_user_pool,priority:3;comment:Pool ID" json:"pool_id"`                     // Pool ID
-	UserAddress     string     `gorm:"column:user_address;type:varchar(42);not null;uniqueIndex:uk_user_pool,priority:4;comment:用户地址" json:"user_address"`         // 用户地址
-	StakedAmount    *float64   `gorm:"column:staked_amount;type:decimal(38,0);not null;default:0;comment:当前质押数量" json:"staked_amount"`                             // 当前质押数量
-	RewardDebt      *float64   `gorm:"column:reward_debt;type:decimal(38,0);not null;default:0;comment:奖励债务" json:"reward_debt"`                                   // 奖励债务
-	UpdatedAt       *time.Time `gorm:"column:updated_at;type:timestamp;not null;default:CURRENT_TIMESTAMP;comment:更新时间" json:"updated_at"`                         // 更新时间
+	ID              int64        `gorm:"column:id;type:bigint;primaryKey;autoIncrement:true;comment:主键" json:"id"`                                                   // 主键
+	ChainID         int64        `gorm:"column:chain_id;type:bigint;not null;uniqueIndex:uk_user_pool,priority:1;comment:链ID" json:"chain_id"`                       // 链ID
+	ContractAddress string       `gorm:"column:contract_address;type:varchar(42);not null;uniqueIndex:uk_user_pool,priority:2;comment:合约地址" json:"contract_address"` // 合约地址
+	PoolID          int64        `gorm:"column:pool_id;type:bigint;not null;uniqueIndex:uk_user_pool,priority:3;comment:Pool ID" json:"pool_id"`                     // Pool ID
+	UserAddress     string       `gorm:"column:user_address;type:varchar(42);not null;uniqueIndex:uk_user_pool,priority:4;comment:用户地址" json:"user_address"`         // 用户地址
+	StakedAmount    *decimal.Int `gorm:"column:staked_amount;type:decimal(38,0);not null;default:0;comment:当前质押数量" json:"staked_amount"`                             // 当前质押数量
+	PendingUnstake  *decimal.Int `gorm:"column:pending_unstake;type:decimal(38,0);not null;default:0;comment:已申请赎回未提取数量" json:"pending_unstake"`                     // 已申请赎回未提取数量
+	RewardDebt      *decimal.Int `gorm:"column:reward_debt;type:decimal(38,0);not null;default:0;comment:奖励债务" json:"reward_debt"`                                   // 奖励债务
+	TotalClaimed    *decimal.Int `gorm:"column:total_claimed;type:decimal(38,0);not null;default:0;comment:累计领取奖励" json:"total_claimed"`                             // 累计领取奖励
+	UpdatedAt       *time.Time   `gorm:"column:updated_at;type:timestamp;not null;default:CURRENT_TIMESTAMP;comment:更新时间" json:"updated_at"`                         // 更新时间
 }
 
 // TableName StakingUserPosition's table name
@@ -0,0 +1,1235 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: staking/v1/staking.proto
+
+package stakingv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Pool struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ChainId         int64                  `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ContractAddress string                 `protobuf:"bytes,2,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	PoolId          int64                  `protobuf:"varint,3,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	StTokenAddress  string                 `protobuf:"bytes,4,opt,name=st_token_address,json=stTokenAddress,proto3" json:"st_token_address,omitempty"`
+	PoolWeight      int64                  `protobuf:"varint,5,opt,name=pool_weight,json=poolWeight,proto3" json:"pool_weight,omitempty"`
+	LastRewardBlock int64                  `protobuf:"varint,6,opt,name=last_reward_block,json=lastRewardBlock,proto3" json:"last_reward_block,omitempty"`
+	// 金额均为十进制字符串
+	AccZeroTokenPerSt   string `protobuf:"bytes,7,opt,name=acc_zero_token_per_st,json=accZeroTokenPerSt,proto3" json:"acc_zero_token_per_st,omitempty"`
+	StTokenAmount       string `protobuf:"bytes,8,opt,name=st_token_amount,json=stTokenAmount,proto3" json:"st_token_amount,omitempty"`
+	MinDepositAmount    string `protobuf:"bytes,9,opt,name=min_deposit_amount,json=minDepositAmount,proto3" json:"min_deposit_amount,omitempty"`
+	UnstakeLockedBlocks int64  `protobuf:"varint,10,opt,name=unstake_locked_blocks,json=unstakeLockedBlocks,proto3" json:"unstake_locked_blocks,omitempty"`
+	// 已申请赎回未提取数量
+	PendingUnstake string `protobuf:"bytes,11,opt,name=pending_unstake,json=pendingUnstake,proto3" json:"pending_unstake,omitempty"`
+	// 有效质押（质押数量扣除待提取）大于 0 的用户数
+	StakerCount int64 `protobuf:"varint,12,opt,name=staker_count,json=stakerCount,proto3" json:"staker_count,omitempty"`
+	// 累计领取奖励
+	TotalClaimed  string `protobuf:"bytes,13,opt,name=total_claimed,json=totalClaimed,proto3" json:"total_claimed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pool) Reset() {
+	*x = Pool{}
+	mi := &file_staking_v1_staking_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pool) ProtoMessage() {}
+
+func (x *Pool) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_v1_staking_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pool.ProtoReflect.Descriptor instead.
+func (*Pool) Descriptor() ([]byte, []int) {
+	return file_staking_v1_staking_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Pool) GetChainId() int64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *Pool) GetContractAddress() string {
+	if x != nil {
+		return x.ContractAddress
+	}
+	return ""
+}
+
+func (x *Pool) GetPoolId() int64 {
+	if x != nil {
+		return x.PoolId
+	}
+	return 0
+}
+
+func (x *Pool) GetStTokenAddress() string {
+	if x != nil {
+		return x.StTokenAddress
+	}
+	return ""
+}
+
+func (x *Pool) GetPoolWeight() int64 {
+	if x != nil {
+		return x.PoolWeight
+	}
+	return 0
+}
+
+func (x *Pool) GetLastRewardBlock() int64 {
+	if x != nil {
+		return x.LastRewardBlock
+	}
+	return 0
+}
+
+func (x *Pool) GetAccZeroTokenPerSt() string {
+	if x != nil {
+		return x.AccZeroTokenPerSt
+	}
+	return ""
+}
+
+func (x *Pool) GetStTokenAmount() string {
+	if x != nil {
+		return x.StTokenAmount
+	}
+	return ""
+}
+
+func (x *Pool) GetMinDepositAmount() string {
+	if x != nil {
+		return x.MinDepositAmount
+	}
+	return ""
+}
+
+func (x *Pool) GetUnstakeLockedBlocks() int64 {
+	if x != nil {
+		return x.UnstakeLockedBlocks
+	}
+	return 0
+}
+
+func (x *Pool) GetPendingUnstake() string {
+	if x != nil {
+		return x.PendingUnstake
+	}
+	return ""
+}
+
+func (x *Pool) GetStakerCount() int64 {
+	if x != nil {
+		return x.StakerCount
+	}
+	return 0
+}
+
+func (x *Pool) GetTotalClaimed() string {
+	if x != nil {
+		return x.TotalClaimed
+	}
+	return ""
+}
+
+type Position struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ChainId         int64                  `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ContractAddress string                 `protobuf:"bytes,2,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	PoolId          int64                  `protobuf:"varint,3,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	UserAddress     string                 `protobuf:"bytes,4,opt,name=user_address,json=userAddress,proto3" json:"user_address,omitempty"`
+	StakedAmount    string                 `protobuf:"bytes,5,opt,name=staked_amount,json=stakedAmount,proto3" json:"staked_amount,omitempty"`
+	RewardDebt      string                 `protobuf:"bytes,6,opt,name=reward_debt,json=rewardDebt,proto3" json:"reward_debt,omitempty"`
+	TotalClaimed    string                 `protobuf:"bytes,7,opt,name=total_claimed,json=totalClaimed,proto3" json:"total_claimed,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	mi := &file_staking_v1_staking_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_v1_staking_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_staking_v1_staking_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Position) GetChainId() int64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *Position) GetContractAddress() string {
+	if x != nil {
+		return x.ContractAddress
+	}
+	return ""
+}
+
+func (x *Position) GetPoolId() int64 {
+	if x != nil {
+		return x.PoolId
+	}
+	return 0
+}
+
+func (x *Position) GetUserAddress() string {
+	if x != nil {
+		return x.UserAddress
+	}
+	return ""
+}
+
+func (x *Position) GetStakedAmount() string {
+	if x != nil {
+		return x.StakedAmount
+	}
+	return ""
+}
+
+func (x *Position) GetRewardDebt() string {
+	if x != nil {
+		return x.RewardDebt
+	}
+	return ""
+}
+
+func (x *Position) GetTotalClaimed() string {
+	if x != nil {
+		return x.TotalClaimed
+	}
+	return ""
+}
+
+func (x *Position) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Event struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChainId            int64                  `protobuf:"varint,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ContractAddress    string                 `protobuf:"bytes,3,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	PoolId             int64                  `protobuf:"varint,4,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	EventType          string                 `protobuf:"bytes,5,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	UserAddress        string                 `protobuf:"bytes,6,opt,name=user_address,json=userAddress,proto3" json:"user_address,omitempty"`
+	Amount             string                 `protobuf:"bytes,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	BlockNumber        int64                  `protobuf:"varint,8,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	TxHash             string                 `protobuf:"bytes,9,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	LogIndex           int32                  `protobuf:"varint,10,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	ConfirmationStatus string                 `protobuf:"bytes,11,opt,name=confirmation_status,json=confirmationStatus,proto3" json:"confirmation_status,omitempty"`
+	// 区块时间（UTC），区块头没有记录时间时为空
+	BlockTime     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_staking_v1_staking_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_v1_staking_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_staking_v1_staking_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Event) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Event) GetChainId() int64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *Event) GetContractAddress() string {
+	if x != nil {
+		return x.ContractAddress
+	}
+	return ""
+}
+
+func (x *Event) GetPoolId() int64 {
+	if x != nil {
+		return x.PoolId
+	}
+	return 0
+}
+
+func (x *Event) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *Event) GetUserAddress() string {
+	if x != nil {
+		return x.UserAddress
+	}
+	return ""
+}
+
+func (x *Event) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Event) GetBlockNumber() int64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *Event) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *Event) GetLogIndex() int32 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+func (x *Event) GetConfirmationStatus() string {
+	if x != nil {
+		return x.ConfirmationStatus
+	}
+	return ""
+}
+
+func (x *Event) GetBlockTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BlockTime
+	}
+	return nil
+}
+
+// EventCursor 事件在链上的位置
+type EventCursor struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockNumber   int64                  `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	LogIndex      int32                  `protobuf:"varint,2,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventCursor) Reset() {
+	*x = EventCursor{}
+	mi := &file_staking_v1_staking_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventCursor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventCursor) ProtoMessage() {}
+
+func (x *EventCursor) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_v1_staking_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventCursor.ProtoReflect.Descriptor instead.
+func (*EventCursor) Descriptor() ([]byte, []int) {
+	return file_staking_v1_staking_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EventCursor) GetBlockNumber() int64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *EventCursor) GetLogIndex() int32 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+type GetPoolRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ChainId         int64                  `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ContractAddress string                 `protobuf:"bytes,2,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	PoolId          int64                  `protobuf:"varint,3,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetPoolRequest) Reset() {
+	*x = GetPoolRequest{}
+	mi := &file_staking_v1_staking_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPoolRequest) ProtoMessage() {}
+
+func (x *GetPoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_v1_staking_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPoolRequest.ProtoReflect.Descriptor instead.
+func (*GetPoolRequest) Descriptor() ([]byte, []int) {
+	return file_staking_v1_staking_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetPoolRequest) GetChainId() int64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *GetPoolRequest) GetContractAddress() string {
+	if x != nil {
+		return x.ContractAddress
+	}
+	return ""
+}
+
+func (x *GetPoolRequest) GetPoolId() int64 {
+	if x != nil {
+		return x.PoolId
+	}
+	return 0
+}
+
+type ListPositionsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserAddress     string                 `protobuf:"bytes,1,opt,name=user_address,json=userAddress,proto3" json:"user_address,omitempty"`
+	ChainId         int64                  `protobuf:"varint,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ContractAddress string                 `protobuf:"bytes,3,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	IncludePending  bool                   `protobuf:"varint,4,opt,name=include_pending,json=includePending,proto3" json:"include_pending,omitempty"`
+	// 游标分页：上一页返回的 next_cursor
+	Cursor        int64 `protobuf:"varint,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit         int32 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPositionsRequest) Reset() {
+	*x = ListPositionsRequest{}
+	mi := &file_staking_v1_staking_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPositionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPositionsRequest) ProtoMessage() {}
+
+func (x *ListPositionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_v1_staking_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPositionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPositionsRequest) Descriptor() ([]byte, []int) {
+	return file_staking_v1_staking_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListPositionsRequest) GetUserAddress() string {
+	if x != nil {
+		return x.UserAddress
+	}
+	return ""
+}
+
+func (x *ListPositionsRequest) GetChainId() int64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *ListPositionsRequest) GetContractAddress() string {
+	if x != nil {
+		return x.ContractAddress
+	}
+	return ""
+}
+
+func (x *ListPositionsRequest) GetIncludePending() bool {
+	if x != nil {
+		return x.IncludePending
+	}
+	return false
+}
+
+func (x *ListPositionsRequest) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *ListPositionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListPositionsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Positions []*Position            `protobuf:"bytes,1,rep,name=positions,proto3" json:"positions,omitempty"`
+	// 为 0 表示没有更多数据
+	NextCursor    int64 `protobuf:"varint,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPositionsResponse) Reset() {
+	*x = ListPositionsResponse{}
+	mi := &file_staking_v1_staking_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPositionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPositionsResponse) ProtoMessage() {}
+
+func (x *ListPositionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_v1_staking_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPositionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPositionsResponse) Descriptor() ([]byte, []int) {
+	return file_staking_v1_staking_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListPositionsResponse) GetPositions() []*Position {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+func (x *ListPositionsResponse) GetNextCursor() int64 {
+	if x != nil {
+		return x.NextCursor
+	}
+	return 0
+}
+
+type ListEventsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ChainId         int64                  `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ContractAddress string                 `protobuf:"bytes,2,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	UserAddress     string                 `protobuf:"bytes,3,opt,name=user_address,json=userAddress,proto3" json:"user_address,omitempty"`
+	PoolId          *int64                 `protobuf:"varint,4,opt,name=pool_id,json=poolId,proto3,oneof" json:"pool_id,omitempty"`
+	EventType       string                 `protobuf:"bytes,5,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	FromBlock       int64                  `protobuf:"varint,6,opt,name=from_block,json=fromBlock,proto3" json:"from_block,omitempty"`
+	IncludePending  bool                   `protobuf:"varint,7,opt,name=include_pending,json=includePending,proto3" json:"include_pending,omitempty"`
+	Cursor          int64                  `protobuf:"varint,8,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit           int32                  `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListEventsRequest) Reset() {
+	*x = ListEventsRequest{}
+	mi := &file_staking_v1_staking_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsRequest) ProtoMessage() {}
+
+func (x *ListEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_v1_staking_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListEventsRequest) Descriptor() ([]byte, []int) {
+	return file_staking_v1_staking_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListEventsRequest) GetChainId() int64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetContractAddress() string {
+	if x != nil {
+		return x.ContractAddress
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetUserAddress() string {
+	if x != nil {
+		return x.UserAddress
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetPoolId() int64 {
+	if x != nil && x.PoolId != nil {
+		return *x.PoolId
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetFromBlock() int64 {
+	if x != nil {
+		return x.FromBlock
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetIncludePending() bool {
+	if x != nil {
+		return x.IncludePending
+	}
+	return false
+}
+
+func (x *ListEventsRequest) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextCursor    int64                  `protobuf:"varint,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEventsResponse) Reset() {
+	*x = ListEventsResponse{}
+	mi := &file_staking_v1_staking_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsResponse) ProtoMessage() {}
+
+func (x *ListEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_v1_staking_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListEventsResponse) Descriptor() ([]byte, []int) {
+	return file_staking_v1_staking_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListEventsResponse) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListEventsResponse) GetNextCursor() int64 {
+	if x != nil {
+		return x.NextCursor
+	}
+	return 0
+}
+
+type StreamEventsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ChainId         int64                  `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ContractAddress string                 `protobuf:"bytes,2,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	UserAddress     string                 `protobuf:"bytes,3,opt,name=user_address,json=userAddress,proto3" json:"user_address,omitempty"`
+	PoolId          *int64                 `protobuf:"varint,4,opt,name=pool_id,json=poolId,proto3,oneof" json:"pool_id,omitempty"`
+	// 为 false 时只推送已确认事件，pending 事件在确认后推送
+	IncludePending bool `protobuf:"varint,5,opt,name=include_pending,json=includePending,proto3" json:"include_pending,omitempty"`
+	// 从该位置之后恢复，为空时只推送实时事件
+	ResumeFrom    *EventCursor `protobuf:"bytes,6,opt,name=resume_from,json=resumeFrom,proto3" json:"resume_from,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	mi := &file_staking_v1_staking_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_v1_staking_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_staking_v1_staking_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StreamEventsRequest) GetChainId() int64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *StreamEventsRequest) GetContractAddress() string {
+	if x != nil {
+		return x.ContractAddress
+	}
+	return ""
+}
+
+func (x *StreamEventsRequest) GetUserAddress() string {
+	if x != nil {
+		return x.UserAddress
+	}
+	return ""
+}
+
+func (x *StreamEventsRequest) GetPoolId() int64 {
+	if x != nil && x.PoolId != nil {
+		return *x.PoolId
+	}
+	return 0
+}
+
+func (x *StreamEventsRequest) GetIncludePending() bool {
+	if x != nil {
+		return x.IncludePending
+	}
+	return false
+}
+
+func (x *StreamEventsRequest) GetResumeFrom() *EventCursor {
+	if x != nil {
+		return x.ResumeFrom
+	}
+	return nil
+}
+
+// Retraction 重组回滚通知
+type Retraction struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ChainId           int64                  `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ContractAddresses []string               `protobuf:"bytes,2,rep,name=contract_addresses,json=contractAddresses,proto3" json:"contract_addresses,omitempty"`
+	FromBlock         int64                  `protobuf:"varint,3,opt,name=from_block,json=fromBlock,proto3" json:"from_block,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Retraction) Reset() {
+	*x = Retraction{}
+	mi := &file_staking_v1_staking_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Retraction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Retraction) ProtoMessage() {}
+
+func (x *Retraction) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_v1_staking_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Retraction.ProtoReflect.Descriptor instead.
+func (*Retraction) Descriptor() ([]byte, []int) {
+	return file_staking_v1_staking_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Retraction) GetChainId() int64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *Retraction) GetContractAddresses() []string {
+	if x != nil {
+		return x.ContractAddresses
+	}
+	return nil
+}
+
+func (x *Retraction) GetFromBlock() int64 {
+	if x != nil {
+		return x.FromBlock
+	}
+	return 0
+}
+
+type StreamEventsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*StreamEventsResponse_Event
+	//	*StreamEventsResponse_Retraction
+	Payload       isStreamEventsResponse_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsResponse) Reset() {
+	*x = StreamEventsResponse{}
+	mi := &file_staking_v1_staking_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsResponse) ProtoMessage() {}
+
+func (x *StreamEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_v1_staking_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsResponse.ProtoReflect.Descriptor instead.
+func (*StreamEventsResponse) Descriptor() ([]byte, []int) {
+	return file_staking_v1_staking_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StreamEventsResponse) GetPayload() isStreamEventsResponse_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *StreamEventsResponse) GetEvent() *Event {
+	if x != nil {
+		if x, ok := x.Payload.(*StreamEventsResponse_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+func (x *StreamEventsResponse) GetRetraction() *Retraction {
+	if x != nil {
+		if x, ok := x.Payload.(*StreamEventsResponse_Retraction); ok {
+			return x.Retraction
+		}
+	}
+	return nil
+}
+
+type isStreamEventsResponse_Payload interface {
+	isStreamEventsResponse_Payload()
+}
+
+type StreamEventsResponse_Event struct {
+	Event *Event `protobuf:"bytes,1,opt,name=event,proto3,oneof"`
+}
+
+type StreamEventsResponse_Retraction struct {
+	Retraction *Retraction `protobuf:"bytes,2,opt,name=retraction,proto3,oneof"`
+}
+
+func (*StreamEventsResponse_Event) isStreamEventsResponse_Payload() {}
+
+func (*StreamEventsResponse_Retraction) isStreamEventsResponse_Payload() {}
+
+var File_staking_v1_staking_proto protoreflect.FileDescriptor
+
+const file_staking_v1_staking_proto_rawDesc = "" +
+	"\n" +
+	"\x18staking/v1/staking.proto\x12\n" +
+	"staking.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x89\x04\n" +
+	"\x04Pool\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x03R\achainId\x12)\n" +
+	"\x10contract_address\x18\x02 \x01(\tR\x0fcontractAddress\x12\x17\n" +
+	"\apool_id\x18\x03 \x01(\x03R\x06poolId\x12(\n" +
+	"\x10st_token_address\x18\x04 \x01(\tR\x0estTokenAddress\x12\x1f\n" +
+	"\vpool_weight\x18\x05 \x01(\x03R\n" +
+	"poolWeight\x12*\n" +
+	"\x11last_reward_block\x18\x06 \x01(\x03R\x0flastRewardBlock\x120\n" +
+	"\x15acc_zero_token_per_st\x18\a \x01(\tR\x11accZeroTokenPerSt\x12&\n" +
+	"\x0fst_token_amount\x18\b \x01(\tR\rstTokenAmount\x12,\n" +
+	"\x12min_deposit_amount\x18\t \x01(\tR\x10minDepositAmount\x122\n" +
+	"\x15unstake_locked_blocks\x18\n" +
+	" \x01(\x03R\x13unstakeLockedBlocks\x12'\n" +
+	"\x0fpending_unstake\x18\v \x01(\tR\x0ependingUnstake\x12!\n" +
+	"\fstaker_count\x18\f \x01(\x03R\vstakerCount\x12#\n" +
+	"\rtotal_claimed\x18\r \x01(\tR\ftotalClaimed\"\xb2\x02\n" +
+	"\bPosition\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x03R\achainId\x12)\n" +
+	"\x10contract_address\x18\x02 \x01(\tR\x0fcontractAddress\x12\x17\n" +
+	"\apool_id\x18\x03 \x01(\x03R\x06poolId\x12!\n" +
+	"\fuser_address\x18\x04 \x01(\tR\vuserAddress\x12#\n" +
+	"\rstaked_amount\x18\x05 \x01(\tR\fstakedAmount\x12\x1f\n" +
+	"\vreward_debt\x18\x06 \x01(\tR\n" +
+	"rewardDebt\x12#\n" +
+	"\rtotal_claimed\x18\a \x01(\tR\ftotalClaimed\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x95\x03\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bchain_id\x18\x02 \x01(\x03R\achainId\x12)\n" +
+	"\x10contract_address\x18\x03 \x01(\tR\x0fcontractAddress\x12\x17\n" +
+	"\apool_id\x18\x04 \x01(\x03R\x06poolId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x05 \x01(\tR\teventType\x12!\n" +
+	"\fuser_address\x18\x06 \x01(\tR\vuserAddress\x12\x16\n" +
+	"\x06amount\x18\a \x01(\tR\x06amount\x12!\n" +
+	"\fblock_number\x18\b \x01(\x03R\vblockNumber\x12\x17\n" +
+	"\atx_hash\x18\t \x01(\tR\x06txHash\x12\x1b\n" +
+	"\tlog_index\x18\n" +
+	" \x01(\x05R\blogIndex\x12/\n" +
+	"\x13confirmation_status\x18\v \x01(\tR\x12confirmationStatus\x129\n" +
+	"\n" +
+	"block_time\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tblockTime\"M\n" +
+	"\vEventCursor\x12!\n" +
+	"\fblock_number\x18\x01 \x01(\x03R\vblockNumber\x12\x1b\n" +
+	"\tlog_index\x18\x02 \x01(\x05R\blogIndex\"o\n" +
+	"\x0eGetPoolRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x03R\achainId\x12)\n" +
+	"\x10contract_address\x18\x02 \x01(\tR\x0fcontractAddress\x12\x17\n" +
+	"\apool_id\x18\x03 \x01(\x03R\x06poolId\"\xd6\x01\n" +
+	"\x14ListPositionsRequest\x12!\n" +
+	"\fuser_address\x18\x01 \x01(\tR\vuserAddress\x12\x19\n" +
+	"\bchain_id\x18\x02 \x01(\x03R\achainId\x12)\n" +
+	"\x10contract_address\x18\x03 \x01(\tR\x0fcontractAddress\x12'\n" +
+	"\x0finclude_pending\x18\x04 \x01(\bR\x0eincludePending\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\x03R\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\"l\n" +
+	"\x15ListPositionsResponse\x122\n" +
+	"\tpositions\x18\x01 \x03(\v2\x14.staking.v1.PositionR\tpositions\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\x03R\n" +
+	"nextCursor\"\xbb\x02\n" +
+	"\x11ListEventsRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x03R\achainId\x12)\n" +
+	"\x10contract_address\x18\x02 \x01(\tR\x0fcontractAddress\x12!\n" +
+	"\fuser_address\x18\x03 \x01(\tR\vuserAddress\x12\x1c\n" +
+	"\apool_id\x18\x04 \x01(\x03H\x00R\x06poolId\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x05 \x01(\tR\teventType\x12\x1d\n" +
+	"\n" +
+	"from_block\x18\x06 \x01(\x03R\tfromBlock\x12'\n" +
+	"\x0finclude_pending\x18\a \x01(\bR\x0eincludePending\x12\x16\n" +
+	"\x06cursor\x18\b \x01(\x03R\x06cursor\x12\x14\n" +
+	"\x05limit\x18\t \x01(\x05R\x05limitB\n" +
+	"\n" +
+	"\b_pool_id\"`\n" +
+	"\x12ListEventsResponse\x12)\n" +
+	"\x06events\x18\x01 \x03(\v2\x11.staking.v1.EventR\x06events\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\x03R\n" +
+	"nextCursor\"\x8b\x02\n" +
+	"\x13StreamEventsRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x03R\achainId\x12)\n" +
+	"\x10contract_address\x18\x02 \x01(\tR\x0fcontractAddress\x12!\n" +
+	"\fuser_address\x18\x03 \x01(\tR\vuserAddress\x12\x1c\n" +
+	"\apool_id\x18\x04 \x01(\x03H\x00R\x06poolId\x88\x01\x01\x12'\n" +
+	"\x0finclude_pending\x18\x05 \x01(\bR\x0eincludePending\x128\n" +
+	"\vresume_from\x18\x06 \x01(\v2\x17.staking.v1.EventCursorR\n" +
+	"resumeFromB\n" +
+	"\n" +
+	"\b_pool_id\"u\n" +
+	"\n" +
+	"Retraction\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x03R\achainId\x12-\n" +
+	"\x12contract_addresses\x18\x02 \x03(\tR\x11contractAddresses\x12\x1d\n" +
+	"\n" +
+	"from_block\x18\x03 \x01(\x03R\tfromBlock\"\x86\x01\n" +
+	"\x14StreamEventsResponse\x12)\n" +
+	"\x05event\x18\x01 \x01(\v2\x11.staking.v1.EventH\x00R\x05event\x128\n" +
+	"\n" +
+	"retraction\x18\x02 \x01(\v2\x16.staking.v1.RetractionH\x00R\n" +
+	"retractionB\t\n" +
+	"\apayload2\xc1\x02\n" +
+	"\x0eStakingService\x127\n" +
+	"\aGetPool\x12\x1a.staking.v1.GetPoolRequest\x1a\x10.staking.v1.Pool\x12T\n" +
+	"\rListPositions\x12 .staking.v1.ListPositionsRequest\x1a!.staking.v1.ListPositionsResponse\x12K\n" +
+	"\n" +
+	"ListEvents\x12\x1d.staking.v1.ListEventsRequest\x1a\x1e.staking.v1.ListEventsResponse\x12S\n" +
+	"\fStreamEvents\x12\x1f.staking.v1.StreamEventsRequest\x1a .staking.v1.StreamEventsResponse0\x01BLZJgithub.com/dijiacoder/staking-indexer/internal/gen/pb/staking/v1;stakingv1b\x06proto3"
+
+var (
+	file_staking_v1_staking_proto_rawDescOnce sync.Once
+	file_staking_v1_staking_proto_rawDescData []byte
+)
+
+func file_staking_v1_staking_proto_rawDescGZIP() []byte {
+	file_staking_v1_staking_proto_rawDescOnce.Do(func() {
+		file_staking_v1_staking_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_staking_v1_staking_proto_rawDesc), len(file_staking_v1_staking_proto_rawDesc)))
+	})
+	return file_staking_v1_staking_proto_rawDescData
+}
+
+var file_staking_v1_staking_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_staking_v1_staking_proto_goTypes = []any{
+	(*Pool)(nil),                  // 0: staking.v1.Pool
+	(*Position)(nil),              // 1: staking.v1.Position
+	(*Event)(nil),                 // 2: staking.v1.Event
+	(*EventCursor)(nil),           // 3: staking.v1.EventCursor
+	(*GetPoolRequest)(nil),        // 4: staking.v1.GetPoolRequest
+	(*ListPositionsRequest)(nil),  // 5: staking.v1.ListPositionsRequest
+	(*ListPositionsResponse)(nil), // 6: staking.v1.ListPositionsResponse
+	(*ListEventsRequest)(nil),     // 7: staking.v1.ListEventsRequest
+	(*ListEventsResponse)(nil),    // 8: staking.v1.ListEventsResponse
+	(*StreamEventsRequest)(nil),   // 9: staking.v1.StreamEventsRequest
+	(*Retraction)(nil),            // 10: staking.v1.Retraction
+	(*StreamEventsResponse)(nil),  // 11: staking.v1.StreamEventsResponse
+	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
+}
+var file_staking_v1_staking_proto_depIdxs = []int32{
+	12, // 0: staking.v1.Position.updated_at:type_name -> google.protobuf.Timestamp
+	12, // 1: staking.v1.Event.block_time:type_name -> google.protobuf.Timestamp
+	1,  // 2: staking.v1.ListPositionsResponse.positions:type_name -> staking.v1.Position
+	2,  // 3: staking.v1.ListEventsResponse.events:type_name -> staking.v1.Event
+	3,  // 4: staking.v1.StreamEventsRequest.resume_from:type_name -> staking.v1.EventCursor
+	2,  // 5: staking.v1.StreamEventsResponse.event:type_name -> staking.v1.Event
+	10, // 6: staking.v1.StreamEventsResponse.retraction:type_name -> staking.v1.Retraction
+	4,  // 7: staking.v1.StakingService.GetPool:input_type -> staking.v1.GetPoolRequest
+	5,  // 8: staking.v1.StakingService.ListPositions:input_type -> staking.v1.ListPositionsRequest
+	7,  // 9: staking.v1.StakingService.ListEvents:input_type -> staking.v1.ListEventsRequest
+	9,  // 10: staking.v1.StakingService.StreamEvents:input_type -> staking.v1.StreamEventsRequest
+	0,  // 11: staking.v1.StakingService.GetPool:output_type -> staking.v1.Pool
+	6,  // 12: staking.v1.StakingService.ListPositions:output_type -> staking.v1.ListPositionsResponse
+	8,  // 13: staking.v1.StakingService.ListEvents:output_type -> staking.v1.ListEventsResponse
+	11, // 14: staking.v1.StakingService.StreamEvents:output_type -> staking.v1.StreamEventsResponse
+	11, // [11:15] is the sub-list for method output_type
+	7,  // [7:11] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_staking_v1_staking_proto_init() }
+func file_staking_v1_staking_proto_init() {
+	if File_staking_v1_staking_proto != nil {
+		return
+	}
+	file_staking_v1_staking_proto_msgTypes[7].OneofWrappers = []any{}
+	file_staking_v1_staking_proto_msgTypes[9].OneofWrappers = []any{}
+	file_staking_v1_staking_proto_msgTypes[11].OneofWrappers = []any{
+		(*StreamEventsResponse_Event)(nil),
+		(*StreamEventsResponse_Retraction)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_staking_v1_staking_proto_rawDesc), len(file_staking_v1_staking_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_staking_v1_staking_proto_goTypes,
+		DependencyIndexes: file_staking_v1_staking_proto_depIdxs,
+		MessageInfos:      file_staking_v1_staking_proto_msgTypes,
+	}.Build()
+	File_staking_v1_staking_proto = out.File
+	file_staking_v1_staking_proto_goTypes = nil
+	file_staking_v1_staking_proto_depIdxs = nil
+}
@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: staking/v1/staking.proto
+
+package stakingv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StakingService_GetPool_FullMethodName       = "/staking.v1.StakingService/GetPool"
+	StakingService_ListPositions_FullMethodName = "/staking.v1.StakingService/ListPositions"
+	StakingService_ListEvents_FullMethodName    = "/staking.v1.StakingService/ListEvents"
+	StakingService_StreamEvents_FullMethodName  = "/staking.v1.StakingService/StreamEvents"
+)
+
+// StakingServiceClient is the client API for StakingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StakingService 质押数据查询与事件推送
+type StakingServiceClient interface {
+	// GetPool 查询单个质押池
+	GetPool(ctx context.Context, in *GetPoolRequest, opts ...grpc.CallOption) (*Pool, error)
+	// ListPositions 查询用户持仓
+	ListPositions(ctx context.Context, in *ListPositionsRequest, opts ...grpc.CallOption) (*ListPositionsResponse, error)
+	// ListEvents 查询质押事件
+	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error)
+	// StreamEvents 推送扫描器新提交的事件。
+	// 指定 resume_from 时先从数据库补发该位置之后的事件，再切换到实时推送；
+	// 发生重组时推送 Retraction，高于 from_block 的事件作废并会在新分叉上重新推送。
+	// 投递语义为至少一次，客户端按 (block_number, log_index, confirmation_status) 去重
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamEventsResponse], error)
+}
+
+type stakingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStakingServiceClient(cc grpc.ClientConnInterface) StakingServiceClient {
+	return &stakingServiceClient{cc}
+}
+
+func (c *stakingServiceClient) GetPool(ctx context.Context, in *GetPoolRequest, opts ...grpc.CallOption) (*Pool, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Pool)
+	err := c.cc.Invoke(ctx, StakingService_GetPool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakingServiceClient) ListPositions(ctx context.Context, in *ListPositionsRequest, opts ...grpc.CallOption) (*ListPositionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPositionsResponse)
+	err := c.cc.Invoke(ctx, StakingService_ListPositions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakingServiceClient) ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEventsResponse)
+	err := c.cc.Invoke(ctx, StakingService_ListEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakingServiceClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamEventsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StakingService_ServiceDesc.Streams[0], StakingService_StreamEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamEventsRequest, StreamEventsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StakingService_StreamEventsClient = grpc.ServerStreamingClient[StreamEventsResponse]
+
+// StakingServiceServer is the server API for StakingService service.
+// All implementations must embed UnimplementedStakingServiceServer
+// for forward compatibility.
+//
+// StakingService 质押数据查询与事件推送
+type StakingServiceServer interface {
+	// GetPool 查询单个质押池
+	GetPool(context.Context, *GetPoolRequest) (*Pool, error)
+	// ListPositions 查询用户持仓
+	ListPositions(context.Context, *ListPositionsRequest) (*ListPositionsResponse, error)
+	// ListEvents 查询质押事件
+	ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error)
+	// StreamEvents 推送扫描器新提交的事件。
+	// 指定 resume_from 时先从数据库补发该位置之后的事件，再切换到实时推送；
+	// 发生重组时推送 Retraction，高于 from_block 的事件作废并会在新分叉上重新推送。
+	// 投递语义为至少一次，客户端按 (block_number, log_index, confirmation_status) 去重
+	StreamEvents(*StreamEventsRequest, grpc.ServerStreamingServer[StreamEventsResponse]) error
+	mustEmbedUnimplementedStakingServiceServer()
+}
+
+// UnimplementedStakingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStakingServiceServer struct{}
+
+func (UnimplementedStakingServiceServer) GetPool(context.Context, *GetPoolRequest) (*Pool, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPool not implemented")
+}
+func (UnimplementedStakingServiceServer) ListPositions(context.Context, *ListPositionsRequest) (*ListPositionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPositions not implemented")
+}
+func (UnimplementedStakingServiceServer) ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListEvents not implemented")
+}
+func (UnimplementedStakingServiceServer) StreamEvents(*StreamEventsRequest, grpc.ServerStreamingServer[StreamEventsResponse]) error {
+	return status.Error(codes.Unimplemented, "method StreamEvents not implemented")
+}
+func (UnimplementedStakingServiceServer) mustEmbedUnimplementedStakingServiceServer() {}
+func (UnimplementedStakingServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeStakingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StakingServiceServer will
+// result in compilation errors.
+type UnsafeStakingServiceServer interface {
+	mustEmbedUnimplementedStakingServiceServer()
+}
+
+func RegisterStakingServiceServer(s grpc.ServiceRegistrar, srv StakingServiceServer) {
+	// If the following call panics, it indicates UnimplementedStakingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StakingService_ServiceDesc, srv)
+}
+
+func _StakingService_GetPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakingServiceServer).GetPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StakingService_GetPool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakingServiceServer).GetPool(ctx, req.(*GetPoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StakingService_ListPositions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPositionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakingServiceServer).ListPositions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StakingService_ListPositions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakingServiceServer).ListPositions(ctx, req.(*ListPositionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StakingService_ListEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakingServiceServer).ListEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StakingService_ListEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakingServiceServer).ListEvents(ctx, req.(*ListEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StakingService_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StakingServiceServer).StreamEvents(m, &grpc.GenericServerStream[StreamEventsRequest, StreamEventsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StakingService_StreamEventsServer = grpc.ServerStreamingServer[StreamEventsResponse]
+
+// StakingService_ServiceDesc is the grpc.ServiceDesc for StakingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StakingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "staking.v1.StakingService",
+	HandlerType: (*StakingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPool",
+			Handler:    _StakingService_GetPool_Handler,
+		},
+		{
+			MethodName: "ListPositions",
+			Handler:    _StakingService_ListPositions_Handler,
+		},
+		{
+			MethodName: "ListEvents",
+			Handler:    _StakingService_ListEvents_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _StakingService_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "staking/v1/staking.proto",
+}
@@ -34,6 +34,7 @@ func newChainBlock(db *gorm.DB, opts ...gen.DOOption) chainBlock {
 	_chainBlock.BlockHash = field.NewString(tableName, "block_hash")
 	_chainBlock.ParentHash = field.NewString(tableName, "parent_hash")
 	_chainBlock.IsConfirmed = field.NewInt32(tableName, "is_confirmed")
+	_chainBlock.BlockTime = field.NewTime(tableName, "block_time")
 	_chainBlock.CreatedAt = field.NewTime(tableName, "created_at")
 
 	_chainBlock.fillFieldMap()
@@ -52,6 +53,7 @@ type chainBlock struct {
 	BlockHash   field.String // 区块Hash
 	ParentHash  field.String // 父区块Hash
 	IsConfirmed field.Int32  // 是否已确认
+	BlockTime   field.Time   // 区块时间（UTC）
 	CreatedAt   field.Time   // 记录时间
 
 	fieldMap map[string]field.Expr
@@ -75,6 +77,7 @@ func (c *chainBlock) updateTableName(table string) *chainBlock {
 	c.BlockHash = field.NewString(table, "block_hash")
 	c.ParentHash = field.NewString(table, "parent_hash")
 	c.IsConfirmed = field.NewInt32(table, "is_confirmed")
+	c.BlockTime = field.NewTime(table, "block_time")
 	c.CreatedAt = field.NewTime(table, "created_at")
 
 	c.fillFieldMap()
@@ -92,13 +95,14 @@ func (c *chainBlock) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (c *chainBlock) fillFieldMap() {
-	c.fieldMap = make(map[string]field.Expr, 7)
+	c.fieldMap = make(map[string]field.Expr, 8)
 	c.fieldMap["id"] = c.ID
 	c.fieldMap["chain_id"] = c.ChainID
 	c.fieldMap["block_number"] = c.BlockNumber
 	c.fieldMap["block_hash"] = c.BlockHash
 	c.fieldMap["parent_hash"] = c.ParentHash
 	c.fieldMap["is_confirmed"] = c.IsConfirmed
+	c.fieldMap["block_time"] = c.BlockTime
 	c.fieldMap["created_at"] = c.CreatedAt
 }
 
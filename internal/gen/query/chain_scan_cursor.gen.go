@@ -36,6 +36,9 @@ func newChainScanCursor(db *gorm.DB, opts ...gen.DOOption) chainScanCursor {
 	_chainScanCursor.LastConfirmedBlock = field.NewInt64(tableName, "last_confirmed_block")
 	_chainScanCursor.ConfirmationBlocks = field.NewInt32(tableName, "confirmation_blocks")
 	_chainScanCursor.ScanStatus = field.NewInt32(tableName, "scan_status")
+	_chainScanCursor.LastSnapshotBlock = field.NewInt64(tableName, "last_snapshot_block")
+	_chainScanCursor.LastReorgBlock = field.NewInt64(tableName, "last_reorg_block")
+	_chainScanCursor.LastReorgAt = field.NewTime(tableName, "last_reorg_at")
 	_chainScanCursor.CreatedAt = field.NewTime(tableName, "created_at")
 	_chainScanCursor.UpdatedAt = field.NewTime(tableName, "updated_at")
 
@@ -56,7 +59,10 @@ type chainScanCursor struct {
 	LastScannedBlock   field.Int64  // 最近已扫描区块（可能未确认）
 	LastConfirmedBlock field.Int64  // 最近已确认区块高度
 	ConfirmationBlocks field.Int32  // 确认区块数
-	ScanStatus         field.Int32  // 扫描状态：1-正常 2-回滚中 3-暂停
+	ScanStatus         field.Int32  // 扫描状态：1-正常 2-回滚中（回滚深度超限待人工处理） 3-暂停
+	LastSnapshotBlock  field.Int64  // 最近生成持仓快照的区块
+	LastReorgBlock     field.Int64  // 最近一次重组回滚到的区块
+	LastReorgAt        field.Time   // 最近一次重组时间
 	CreatedAt          field.Time   // 创建时间
 	UpdatedAt          field.Time   // 更新时间
 
@@ -83,6 +89,9 @@ func (c *chainScanCursor) updateTableName(table string) *chainScanCursor {
 	c.LastConfirmedBlock = field.NewInt64(table, "last_confirmed_block")
 	c.ConfirmationBlocks = field.NewInt32(table, "confirmation_blocks")
 	c.ScanStatus = field.NewInt32(table, "scan_status")
+	c.LastSnapshotBlock = field.NewInt64(table, "last_snapshot_block")
+	c.LastReorgBlock = field.NewInt64(table, "last_reorg_block")
+	c.LastReorgAt = field.NewTime(table, "last_reorg_at")
 	c.CreatedAt = field.NewTime(table, "created_at")
 	c.UpdatedAt = field.NewTime(table, "updated_at")
 
@@ -101,7 +110,7 @@ func (c *chainScanCursor) GetFieldByName(fieldName string) (field.OrderExpr, boo
 }
 
 func (c *chainScanCursor) fillFieldMap() {
-	c.fieldMap = make(map[string]field.Expr, 10)
+	c.fieldMap = make(map[string]field.Expr, 13)
 	c.fieldMap["id"] = c.ID
 	c.fieldMap["chain_id"] = c.ChainID
 	c.fieldMap["contract_address"] = c.ContractAddress
@@ -110,6 +119,9 @@ func (c *chainScanCursor) fillFieldMap() {
 	c.fieldMap["last_confirmed_block"] = c.LastConfirmedBlock
 	c.fieldMap["confirmation_blocks"] = c.ConfirmationBlocks
 	c.fieldMap["scan_status"] = c.ScanStatus
+	c.fieldMap["last_snapshot_block"] = c.LastSnapshotBlock
+	c.fieldMap["last_reorg_block"] = c.LastReorgBlock
+	c.fieldMap["last_reorg_at"] = c.LastReorgAt
 	c.fieldMap["created_at"] = c.CreatedAt
 	c.fieldMap["updated_at"] = c.UpdatedAt
 }
@@ -16,54 +16,119 @@ import (
 )
 
 var (
-	Q                   = new(Query)
-	ChainBlock          *chainBlock
-	ChainScanCursor     *chainScanCursor
-	StakingEvent        *stakingEvent
-	StakingPool         *stakingPool
-	StakingUserPosition *stakingUserPosition
+	Q                       = new(Query)
+	AdminAuditLog           *adminAuditLog
+	AdminJob                *adminJob
+	ChainBlock              *chainBlock
+	ChainScanCursor         *chainScanCursor
+	EventOutbox             *eventOutbox
+	OutboxCheckpoint        *outboxCheckpoint
+	RawLog                  *rawLog
+	ReconcileDrift          *reconcileDrift
+	StakingClaimDaily       *stakingClaimDaily
+	StakingEvent            *stakingEvent
+	StakingPool             *stakingPool
+	StakingPoolHistory      *stakingPoolHistory
+	StakingPositionSnapshot *stakingPositionSnapshot
+	StakingRewardParam      *stakingRewardParam
+	StakingUserPosition     *stakingUserPosition
+	WebhookDelivery         *webhookDelivery
+	WebhookDeliveryLog      *webhookDeliveryLog
+	WebhookSubscription     *webhookSubscription
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
+	AdminAuditLog = &Q.AdminAuditLog
+	AdminJob = &Q.AdminJob
 	ChainBlock = &Q.ChainBlock
 	ChainScanCursor = &Q.ChainScanCursor
+	EventOutbox = &Q.EventOutbox
+	OutboxCheckpoint = &Q.OutboxCheckpoint
+	RawLog = &Q.RawLog
+	ReconcileDrift = &Q.ReconcileDrift
+	StakingClaimDaily = &Q.StakingClaimDaily
 	StakingEvent = &Q.StakingEvent
 	StakingPool = &Q.StakingPool
+	StakingPoolHistory = &Q.StakingPoolHistory
+	StakingPositionSnapshot = &Q.StakingPositionSnapshot
+	StakingRewardParam = &Q.StakingRewardParam
 	StakingUserPosition = &Q.StakingUserPosition
+	WebhookDelivery = &Q.WebhookDelivery
+	WebhookDeliveryLog = &Q.WebhookDeliveryLog
+	WebhookSubscription = &Q.WebhookSubscription
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:                  db,
-		ChainBlock:          newChainBlock(db, opts...),
-		ChainScanCursor:     newChainScanCursor(db, opts...),
-		StakingEvent:        newStakingEvent(db, opts...),
-		StakingPool:         newStakingPool(db, opts...),
-		StakingUserPosition: newStakingUserPosition(db, opts...),
+		db:                      db,
+		AdminAuditLog:           newAdminAuditLog(db, opts...),
+		AdminJob:                newAdminJob(db, opts...),
+		ChainBlock:              newChainBlock(db, opts...),
+		ChainScanCursor:         newChainScanCursor(db, opts...),
+		EventOutbox:             newEventOutbox(db, opts...),
+		OutboxCheckpoint:        newOutboxCheckpoint(db, opts...),
+		RawLog:                  newRawLog(db, opts...),
+		ReconcileDrift:          newReconcileDrift(db, opts...),
+		StakingClaimDaily:       newStakingClaimDaily(db, opts...),
+		StakingEvent:            newStakingEvent(db, opts...),
+		StakingPool:             newStakingPool(db, opts...),
+		StakingPoolHistory:      newStakingPoolHistory(db, opts...),
+		StakingPositionSnapshot: newStakingPositionSnapshot(db, opts...),
+		StakingRewardParam:      newStakingRewardParam(db, opts...),
+		StakingUserPosition:     newStakingUserPosition(db, opts...),
+		WebhookDelivery:         newWebhookDelivery(db, opts...),
+		WebhookDeliveryLog:      newWebhookDeliveryLog(db, opts...),
+		WebhookSubscription:     newWebhookSubscription(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	ChainBlock          chainBlock
-	ChainScanCursor     chainScanCursor
-	StakingEvent        stakingEvent
-	StakingPool         stakingPool
-	StakingUserPosition stakingUserPosition
+	AdminAuditLog           adminAuditLog
+	AdminJob                adminJob
+	ChainBlock              chainBlock
+	ChainScanCursor         chainScanCursor
+	EventOutbox             eventOutbox
+	OutboxCheckpoint        outboxCheckpoint
+	RawLog                  rawLog
+	ReconcileDrift          reconcileDrift
+	StakingClaimDaily       stakingClaimDaily
+	StakingEvent            stakingEvent
+	StakingPool             stakingPool
+	StakingPoolHistory      stakingPoolHistory
+	StakingPositionSnapshot stakingPositionSnapshot
+	StakingRewardParam      stakingRewardParam
+	StakingUserPosition     stakingUserPosition
+	WebhookDelivery         webhookDelivery
+	WebhookDeliveryLog      webhookDeliveryLog
+	WebhookSubscription     webhookSubscription
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:                  db,
-		ChainBlock:          q.ChainBlock.clone(db),
-		ChainScanCursor:     q.ChainScanCursor.clone(db),
-		StakingEvent:        q.StakingEvent.clone(db),
-		StakingPool:         q.StakingPool.clone(db),
-		StakingUserPosition: q.StakingUserPosition.clone(db),
+		db:                      db,
+		AdminAuditLog:           q.AdminAuditLog.clone(db),
+		AdminJob:                q.AdminJob.clone(db),
+		ChainBlock:              q.ChainBlock.clone(db),
+		ChainScanCursor:         q.ChainScanCursor.clone(db),
+		EventOutbox:             q.EventOutbox.clone(db),
+		OutboxCheckpoint:        q.OutboxCheckpoint.clone(db),
+		RawLog:                  q.RawLog.clone(db),
+		ReconcileDrift:          q.ReconcileDrift.clone(db),
+		StakingClaimDaily:       q.StakingClaimDaily.clone(db),
+		StakingEvent:            q.StakingEvent.clone(db),
+		StakingPool:             q.StakingPool.clone(db),
+		StakingPoolHistory:      q.StakingPoolHistory.clone(db),
+		StakingPositionSnapshot: q.StakingPositionSnapshot.clone(db),
+		StakingRewardParam:      q.StakingRewardParam.clone(db),
+		StakingUserPosition:     q.StakingUserPosition.clone(db),
+		WebhookDelivery:         q.WebhookDelivery.clone(db),
+		WebhookDeliveryLog:      q.WebhookDeliveryLog.clone(db),
+		WebhookSubscription:     q.WebhookSubscription.clone(db),
 	}
 }
 
@@ -77,30 +142,69 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:                  db,
-		ChainBlock:          q.ChainBlock.replaceDB(db),
-		ChainScanCursor:     q.ChainScanCursor.replaceDB(db),
-		StakingEvent:        q.StakingEvent.replaceDB(db),
-		StakingPool:         q.StakingPool.replaceDB(db),
-		StakingUserPosition: q.StakingUserPosition.replaceDB(db),
+		db:                      db,
+		AdminAuditLog:           q.AdminAuditLog.replaceDB(db),
+		AdminJob:                q.AdminJob.replaceDB(db),
+		ChainBlock:              q.ChainBlock.replaceDB(db),
+		ChainScanCursor:         q.ChainScanCursor.replaceDB(db),
+		EventOutbox:             q.EventOutbox.replaceDB(db),
+		OutboxCheckpoint:        q.OutboxCheckpoint.replaceDB(db),
+		RawLog:                  q.RawLog.replaceDB(db),
+		ReconcileDrift:          q.ReconcileDrift.replaceDB(db),
+		StakingClaimDaily:       q.StakingClaimDaily.replaceDB(db),
+		StakingEvent:            q.StakingEvent.replaceDB(db),
+		StakingPool:             q.StakingPool.replaceDB(db),
+		StakingPoolHistory:      q.StakingPoolHistory.replaceDB(db),
+		StakingPositionSnapshot: q.StakingPositionSnapshot.replaceDB(db),
+		StakingRewardParam:      q.StakingRewardParam.replaceDB(db),
+		StakingUserPosition:     q.StakingUserPosition.replaceDB(db),
+		WebhookDelivery:         q.WebhookDelivery.replaceDB(db),
+		WebhookDeliveryLog:      q.WebhookDeliveryLog.replaceDB(db),
+		WebhookSubscription:     q.WebhookSubscription.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	ChainBlock          IChainBlockDo
-	ChainScanCursor     IChainScanCursorDo
-	StakingEvent        IStakingEventDo
-	StakingPool         IStakingPoolDo
-	StakingUserPosition IStakingUserPositionDo
+	AdminAuditLog           IAdminAuditLogDo
+	AdminJob                IAdminJobDo
+	ChainBlock              IChainBlockDo
+	ChainScanCursor         IChainScanCursorDo
+	EventOutbox             IEventOutboxDo
+	OutboxCheckpoint        IOutboxCheckpointDo
+	RawLog                  IRawLogDo
+	ReconcileDrift          IReconcileDriftDo
+	StakingClaimDaily       IStakingClaimDailyDo
+	StakingEvent            IStakingEventDo
+	StakingPool             IStakingPoolDo
+	StakingPoolHistory      IStakingPoolHistoryDo
+	StakingPositionSnapshot IStakingPositionSnapshotDo
+	StakingRewardParam      IStakingRewardParamDo
+	StakingUserPosition     IStakingUserPositionDo
+	WebhookDelivery         IWebhookDeliveryDo
+	WebhookDeliveryLog      IWebhookDeliveryLogDo
+	WebhookSubscription     IWebhookSubscriptionDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		ChainBlock:          q.ChainBlock.WithContext(ctx),
-		ChainScanCursor:     q.ChainScanCursor.WithContext(ctx),
-		StakingEvent:        q.StakingEvent.WithContext(ctx),
-		StakingPool:         q.StakingPool.WithContext(ctx),
-		StakingUserPosition: q.StakingUserPosition.WithContext(ctx),
+		AdminAuditLog:           q.AdminAuditLog.WithContext(ctx),
+		AdminJob:                q.AdminJob.WithContext(ctx),
+		ChainBlock:              q.ChainBlock.WithContext(ctx),
+		ChainScanCursor:         q.ChainScanCursor.WithContext(ctx),
+		EventOutbox:             q.EventOutbox.WithContext(ctx),
+		OutboxCheckpoint:        q.OutboxCheckpoint.WithContext(ctx),
+		RawLog:                  q.RawLog.WithContext(ctx),
+		ReconcileDrift:          q.ReconcileDrift.WithContext(ctx),
+		StakingClaimDaily:       q.StakingClaimDaily.WithContext(ctx),
+		StakingEvent:            q.StakingEvent.WithContext(ctx),
+		StakingPool:             q.StakingPool.WithContext(ctx),
+		StakingPoolHistory:      q.StakingPoolHistory.WithContext(ctx),
+		StakingPositionSnapshot: q.StakingPositionSnapshot.WithContext(ctx),
+		StakingRewardParam:      q.StakingRewardParam.WithContext(ctx),
+		StakingUserPosition:     q.StakingUserPosition.WithContext(ctx),
+		WebhookDelivery:         q.WebhookDelivery.WithContext(ctx),
+		WebhookDeliveryLog:      q.WebhookDeliveryLog.WithContext(ctx),
+		WebhookSubscription:     q.WebhookSubscription.WithContext(ctx),
 	}
 }
 
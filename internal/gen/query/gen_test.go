@@ -77,11 +77,24 @@ func Test_WithContext(t *testing.T) {
 	qCtx := query.WithContext(context.WithValue(context.Background(), key, value))
 
 	for _, ctx := range []context.Context{
+		qCtx.AdminAuditLog.UnderlyingDB().Statement.Context,
+		qCtx.AdminJob.UnderlyingDB().Statement.Context,
 		qCtx.ChainBlock.UnderlyingDB().Statement.Context,
 		qCtx.ChainScanCursor.UnderlyingDB().Statement.Context,
+		qCtx.EventOutbox.UnderlyingDB().Statement.Context,
+		qCtx.OutboxCheckpoint.UnderlyingDB().Statement.Context,
+		qCtx.RawLog.UnderlyingDB().Statement.Context,
+		qCtx.ReconcileDrift.UnderlyingDB().Statement.Context,
+		qCtx.StakingClaimDaily.UnderlyingDB().Statement.Context,
 		qCtx.StakingEvent.UnderlyingDB().Statement.Context,
 		qCtx.StakingPool.UnderlyingDB().Statement.Context,
+		qCtx.StakingPoolHistory.UnderlyingDB().Statement.Context,
+		qCtx.StakingPositionSnapshot.UnderlyingDB().Statement.Context,
+		qCtx.StakingRewardParam.UnderlyingDB().Statement.Context,
 		qCtx.StakingUserPosition.UnderlyingDB().Statement.Context,
+		qCtx.WebhookDelivery.UnderlyingDB().Statement.Context,
+		qCtx.WebhookDeliveryLog.UnderlyingDB().Statement.Context,
+		qCtx.WebhookSubscription.UnderlyingDB().Statement.Context,
 	} {
 		if v := ctx.Value(key); v != value {
 			t.Errorf("get value from context fail, expect %q, got %q", value, v)
@@ -34,10 +34,12 @@ func newStakingEvent(db *gorm.DB, opts ...gen.DOOption) stakingEvent {
 	_stakingEvent.PoolID = field.NewInt64(tableName, "pool_id")
 	_stakingEvent.EventType = field.NewString(tableName, "event_type")
 	_stakingEvent.UserAddress = field.NewString(tableName, "user_address")
-	_stakingEvent.Amount = field.NewFloat64(tableName, "amount")
+	_stakingEvent.Amount = field.NewField(tableName, "amount")
 	_stakingEvent.BlockNumber = field.NewInt64(tableName, "block_number")
+	_stakingEvent.BlockTime = field.NewTime(tableName, "block_time")
 	_stakingEvent.TxHash = field.NewString(tableName, "tx_hash")
 	_stakingEvent.LogIndex = field.NewInt32(tableName, "log_index")
+	_stakingEvent.ConfirmationStatus = field.NewString(tableName, "confirmation_status")
 	_stakingEvent.CreatedAt = field.NewTime(tableName, "created_at")
 
 	_stakingEvent.fillFieldMap()
@@ -49,18 +51,20 @@ func newStakingEvent(db *gorm.DB, opts ...gen.DOOption) stakingEvent {
 type stakingEvent struct {
 	stakingEventDo
 
-	ALL             field.Asterisk
-	ID              field.Int64   // 主键
-	ChainID         field.Int64   // 链ID
-	ContractAddress field.String  // 合约地址
-	PoolID          field.Int64   // Pool ID
-	EventType       field.String  // 事件类型：Deposit / Withdraw / Claim
-	UserAddress     field.String  // 用户地址
-	Amount          field.Float64 // 数量（wei）
-	BlockNumber     field.Int64   // 区块高度
-	TxHash          field.String  // 交易Hash
-	LogIndex        field.Int32   // 日志索引
-	CreatedAt       field.Time    // 创建时间
+	ALL                field.Asterisk
+	ID                 field.Int64  // 主键
+	ChainID            field.Int64  // 链ID
+	ContractAddress    field.String // 合约地址
+	PoolID             field.Int64  // Pool ID
+	EventType          field.String // 事件类型：Deposit / Withdraw / Claim
+	UserAddress        field.String // 用户地址
+	Amount             field.Field  // 数量（wei）
+	BlockNumber        field.Int64  // 区块高度
+	BlockTime          field.Time   // 区块时间（UTC）
+	TxHash             field.String // 交易Hash
+	LogIndex           field.Int32  // 日志索引
+	ConfirmationStatus field.String // 确认状态：pending / confirmed / orphaned
+	CreatedAt          field.Time   // 创建时间
 
 	fieldMap map[string]field.Expr
 }
@@ -83,10 +87,12 @@ func (s *stakingEvent) updateTableName(table string) *stakingEvent {
 	s.PoolID = field.NewInt64(table, "pool_id")
 	s.EventType = field.NewString(table, "event_type")
 	s.UserAddress = field.NewString(table, "user_address")
-	s.Amount = field.NewFloat64(table, "amount")
+	s.Amount = field.NewField(table, "amount")
 	s.BlockNumber = field.NewInt64(table, "block_number")
+	s.BlockTime = field.NewTime(table, "block_time")
 	s.TxHash = field.NewString(table, "tx_hash")
 	s.LogIndex = field.NewInt32(table, "log_index")
+	s.ConfirmationStatus = field.NewString(table, "confirmation_status")
 	s.CreatedAt = field.NewTime(table, "created_at")
 
 	s.fillFieldMap()
@@ -104,7 +110,7 @@ func (s *stakingEvent) GetFieldByName(fieldName string) (field.OrderExpr, bool)
 }
 
 func (s *stakingEvent) fillFieldMap() {
-	s.fieldMap = make(map[string]field.Expr, 11)
+	s.fieldMap = make(map[string]field.Expr, 13)
 	s.fieldMap["id"] = s.ID
 	s.fieldMap["chain_id"] = s.ChainID
 	s.fieldMap["contract_address"] = s.ContractAddress
@@ -113,8 +119,10 @@ func (s *stakingEvent) fillFieldMap() {
 	s.fieldMap["user_address"] = s.UserAddress
 	s.fieldMap["amount"] = s.Amount
 	s.fieldMap["block_number"] = s.BlockNumber
+	s.fieldMap["block_time"] = s.BlockTime
 	s.fieldMap["tx_hash"] = s.TxHash
 	s.fieldMap["log_index"] = s.LogIndex
+	s.fieldMap["confirmation_status"] = s.ConfirmationStatus
 	s.fieldMap["created_at"] = s.CreatedAt
 }
 
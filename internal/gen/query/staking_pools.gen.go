@@ -36,9 +36,12 @@ func newStakingPool(db *gorm.DB, opts ...gen.DOOption) stakingPool {
 	_stakingPool.PoolWeight = field.NewInt64(tableName, "pool_weight")
 	_stakingPool.LastRewardBlock = field.NewInt64(tableName, "last_reward_block")
 	_stakingPool.AccZeroTokenPerSt = field.NewInt64(tableName, "acc_zero_token_per_st")
-	_stakingPool.StTokenAmount = field.NewInt64(tableName, "st_token_amount")
+	_stakingPool.StTokenAmount = field.NewField(tableName, "st_token_amount")
+	_stakingPool.PendingUnstake = field.NewField(tableName, "pending_unstake")
+	_stakingPool.StakerCount = field.NewInt64(tableName, "staker_count")
 	_stakingPool.MinDepositAmount = field.NewInt64(tableName, "min_deposit_amount")
 	_stakingPool.UnstakeLockedBlocks = field.NewInt64(tableName, "unstake_locked_blocks")
+	_stakingPool.TotalClaimed = field.NewField(tableName, "total_claimed")
 	_stakingPool.CreatedAt = field.NewTime(tableName, "created_at")
 	_stakingPool.UpdatedAt = field.NewTime(tableName, "updated_at")
 
@@ -60,9 +63,12 @@ type stakingPool struct {
 	PoolWeight          field.Int64  // 不同资金池所占的权重
 	LastRewardBlock     field.Int64  // 最后一次分配奖励的区块号
 	AccZeroTokenPerSt   field.Int64  // 质押 1个ETH经过1个区块高度，能拿到 n 个ZeroToken
-	StTokenAmount       field.Int64  // 质押的代币数量
+	StTokenAmount       field.Field  // 质押的代币数量（Deposit 减 RequestUnstake）
+	PendingUnstake      field.Field  // 已申请赎回未提取数量（RequestUnstake 减 Withdraw）
+	StakerCount         field.Int64  // 质押数量扣除待提取后大于 0 的用户数
 	MinDepositAmount    field.Int64  // 最小质押数量
 	UnstakeLockedBlocks field.Int64  // 解质押锁定的区块高度
+	TotalClaimed        field.Field  // 累计领取奖励
 	CreatedAt           field.Time   // 创建时间
 	UpdatedAt           field.Time   // 更新时间
 
@@ -89,9 +95,12 @@ func (s *stakingPool) updateTableName(table string) *stakingPool {
 	s.PoolWeight = field.NewInt64(table, "pool_weight")
 	s.LastRewardBlock = field.NewInt64(table, "last_reward_block")
 	s.AccZeroTokenPerSt = field.NewInt64(table, "acc_zero_token_per_st")
-	s.StTokenAmount = field.NewInt64(table, "st_token_amount")
+	s.StTokenAmount = field.NewField(table, "st_token_amount")
+	s.PendingUnstake = field.NewField(table, "pending_unstake")
+	s.StakerCount = field.NewInt64(table, "staker_count")
 	s.MinDepositAmount = field.NewInt64(table, "min_deposit_amount")
 	s.UnstakeLockedBlocks = field.NewInt64(table, "unstake_locked_blocks")
+	s.TotalClaimed = field.NewField(table, "total_claimed")
 	s.CreatedAt = field.NewTime(table, "created_at")
 	s.UpdatedAt = field.NewTime(table, "updated_at")
 
@@ -110,7 +119,7 @@ func (s *stakingPool) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (s *stakingPool) fillFieldMap() {
-	s.fieldMap = make(map[string]field.Expr, 13)
+	s.fieldMap = make(map[string]field.Expr, 16)
 	s.fieldMap["id"] = s.ID
 	s.fieldMap["chain_id"] = s.ChainID
 	s.fieldMap["contract_address"] = s.ContractAddress
@@ -120,8 +129,11 @@ func (s *stakingPool) fillFieldMap() {
 	s.fieldMap["last_reward_block"] = s.LastRewardBlock
 	s.fieldMap["acc_zero_token_per_st"] = s.AccZeroTokenPerSt
 	s.fieldMap["st_token_amount"] = s.StTokenAmount
+	s.fieldMap["pending_unstake"] = s.PendingUnstake
+	s.fieldMap["staker_count"] = s.StakerCount
 	s.fieldMap["min_deposit_amount"] = s.MinDepositAmount
 	s.fieldMap["unstake_locked_blocks"] = s.UnstakeLockedBlocks
+	s.fieldMap["total_claimed"] = s.TotalClaimed
 	s.fieldMap["created_at"] = s.CreatedAt
 	s.fieldMap["updated_at"] = s.UpdatedAt
 }
@@ -33,8 +33,10 @@ func newStakingUserPosition(db *gorm.DB, opts ...gen.DOOption) stakingUserPositi
 	_stakingUserPosition.ContractAddress = field.NewString(tableName, "contract_address")
 	_stakingUserPosition.PoolID = field.NewInt64(tableName, "pool_id")
 	_stakingUserPosition.UserAddress = field.NewString(tableName, "user_address")
-	_stakingUserPosition.StakedAmount = field.NewFloat64(tableName, "staked_amount")
-	_stakingUserPosition.RewardDebt = field.NewFloat64(tableName, "reward_debt")
+	_stakingUserPosition.StakedAmount = field.NewField(tableName, "staked_amount")
+	_stakingUserPosition.PendingUnstake = field.NewField(tableName, "pending_unstake")
+	_stakingUserPosition.RewardDebt = field.NewField(tableName, "reward_debt")
+	_stakingUserPosition.TotalClaimed = field.NewField(tableName, "total_claimed")
 	_stakingUserPosition.UpdatedAt = field.NewTime(tableName, "updated_at")
 
 	_stakingUserPosition.fillFieldMap()
@@ -47,14 +49,16 @@ type stakingUserPosition struct {
 	stakingUserPositionDo
 
 	ALL             field.Asterisk
-	ID              field.Int64   // 主键
-	ChainID         field.Int64   // 链ID
-	ContractAddress field.String  // 合约地址
-	PoolID          field.Int64   // Pool ID
-	UserAddress     field.String  // 用户地址
-	StakedAmount    field.Float64 // 当前质押数量
-	RewardDebt      field.Float64 // 奖励债务
-	UpdatedAt       field.Time    // 更新时间
+	ID              field.Int64  // 主键
+	ChainID         field.Int64  // 链ID
+	ContractAddress field.String // 合约地址
+	PoolID          field.Int64  // Pool ID
+	UserAddress     field.String // 用户地址
+	StakedAmount    field.Field  // 当前质押数量
+	PendingUnstake  field.Field  // 已申请赎回未提取数量
+	RewardDebt      field.Field  // 奖励债务
+	TotalClaimed    field.Field  // 累计领取奖励
+	UpdatedAt       field.Time   // 更新时间
 
 	fieldMap map[string]field.Expr
 }
@@ -76,8 +80,10 @@ func (s *stakingUserPosition) updateTableName(table string) *stakingUserPosition
 	s.ContractAddress = field.NewString(table, "contract_address")
 	s.PoolID = field.NewInt64(table, "pool_id")
 	s.UserAddress = field.NewString(table, "user_address")
-	s.StakedAmount = field.NewFloat64(table, "staked_amount")
-	s.RewardDebt = field.NewFloat64(table, "reward_debt")
+	s.StakedAmount = field.NewField(table, "staked_amount")
+	s.PendingUnstake = field.NewField(table, "pending_unstake")
+	s.RewardDebt = field.NewField(table, "reward_debt")
+	s.TotalClaimed = field.NewField(table, "total_claimed")
 	s.UpdatedAt = field.NewTime(table, "updated_at")
 
 	s.fillFieldMap()
@@ -95,14 +101,16 @@ func (s *stakingUserPosition) GetFieldByName(fieldName string) (field.OrderExpr,
 }
 
 func (s *stakingUserPosition) fillFieldMap() {
-	s.fieldMap = make(map[string]field.Expr, 8)
+	s.fieldMap = make(map[string]field.Expr, 10)
 	s.fieldMap["id"] = s.ID
 	s.fieldMap["chain_id"] = s.ChainID
 	s.fieldMap["contract_address"] = s.ContractAddress
 	s.fieldMap["pool_id"] = s.PoolID
 	s.fieldMap["user_address"] = s.UserAddress
 	s.fieldMap["staked_amount"] = s.StakedAmount
+	s.fieldMap["pending_unstake"] = s.PendingUnstake
 	s.fieldMap["reward_debt"] = s.RewardDebt
+	s.fieldMap["total_claimed"] = s.TotalClaimed
 	s.fieldMap["updated_at"] = s.UpdatedAt
 }
 
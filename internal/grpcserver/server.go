@@ -0,0 +1,243 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/decimal"
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	stakingv1 "github.com/dijiacoder/staking-indexer/internal/gen/pb/staking/v1"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/broadcast"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server gRPC 查询与事件推送服务
+type Server struct {
+	stakingv1.UnimplementedStakingServiceServer
+
+	repo        repository.StakingQueryRepository
+	broadcaster *broadcast.Broadcaster
+}
+
+func NewServer(repo repository.StakingQueryRepository, broadcaster *broadcast.Broadcaster) *Server {
+	return &Server{
+		repo:        repo,
+		broadcaster: broadcaster,
+	}
+}
+
+// ListenAndServe 启动 gRPC 服务，ctx 取消时优雅关闭
+func (s *Server) ListenAndServe(ctx context.Context, port int) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+
+	srv := grpc.NewServer()
+	stakingv1.RegisterStakingServiceServer(srv, s)
+
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+
+	logger.Logger.Info("Starting gRPC server", zap.String("address", lis.Addr().String()))
+	return srv.Serve(lis)
+}
+
+func (s *Server) GetPool(ctx context.Context, req *stakingv1.GetPoolRequest) (*stakingv1.Pool, error) {
+	contract, err := optionalAddress("contract_address", req.GetContractAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	poolID := req.GetPoolId()
+	pools, err := s.repo.ListPools(ctx, repository.PoolFilter{
+		ChainID:         req.GetChainId(),
+		ContractAddress: contract,
+		PoolID:          &poolID,
+		Limit:           2,
+	})
+	if err != nil {
+		return nil, internalError("get pool", err)
+	}
+	switch len(pools) {
+	case 0:
+		return nil, status.Error(codes.NotFound, "pool not found")
+	case 1:
+		return newPool(pools[0]), nil
+	default:
+		return nil, status.Error(codes.InvalidArgument, "pool id matches multiple contracts, specify chain_id and contract_address")
+	}
+}
+
+func (s *Server) ListPositions(ctx context.Context, req *stakingv1.ListPositionsRequest) (*stakingv1.ListPositionsResponse, error) {
+	if !common.IsHexAddress(req.GetUserAddress()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_address")
+	}
+	contract, err := optionalAddress("contract_address", req.GetContractAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repository.PositionFilter{
+		ChainID:         req.GetChainId(),
+		ContractAddress: contract,
+		UserAddress:     common.HexToAddress(req.GetUserAddress()).Hex(),
+		IncludePending:  req.GetIncludePending(),
+		AfterID:         req.GetCursor(),
+		Limit:           int(req.GetLimit()),
+	}
+	positions, err := s.repo.ListUserPositions(ctx, filter)
+	if err != nil {
+		return nil, internalError("list positions", err)
+	}
+
+	resp := &stakingv1.ListPositionsResponse{
+		Positions: make([]*stakingv1.Position, 0, len(positions)),
+	}
+	for _, p := range positions {
+		resp.Positions = append(resp.Positions, newPosition(p))
+	}
+	if len(positions) == repository.PageSize(filter.Limit) {
+		resp.NextCursor = positions[len(positions)-1].ID
+	}
+	return resp, nil
+}
+
+func (s *Server) ListEvents(ctx context.Context, req *stakingv1.ListEventsRequest) (*stakingv1.ListEventsResponse, error) {
+	filter, err := eventFilter(req.GetChainId(), req.GetContractAddress(), req.GetUserAddress(), req.PoolId, req.GetIncludePending())
+	if err != nil {
+		return nil, err
+	}
+	filter.EventType = req.GetEventType()
+	filter.FromBlock = req.GetFromBlock()
+	filter.AfterID = req.GetCursor()
+	filter.Limit = int(req.GetLimit())
+
+	events, err := s.repo.ListEvents(ctx, filter)
+	if err != nil {
+		return nil, internalError("list events", err)
+	}
+
+	resp := &stakingv1.ListEventsResponse{
+		Events: make([]*stakingv1.Event, 0, len(events)),
+	}
+	for _, e := range events {
+		resp.Events = append(resp.Events, newEvent(e))
+	}
+	if len(events) == repository.PageSize(filter.Limit) {
+		resp.NextCursor = events[len(events)-1].ID
+	}
+	return resp, nil
+}
+
+// eventFilter 构建事件过滤条件，地址统一为 checksum 格式
+func eventFilter(chainID int64, contractAddress, userAddress string, poolID *int64, includePending bool) (repository.EventFilter, error) {
+	contract, err := optionalAddress("contract_address", contractAddress)
+	if err != nil {
+		return repository.EventFilter{}, err
+	}
+	user, err := optionalAddress("user_address", userAddress)
+	if err != nil {
+		return repository.EventFilter{}, err
+	}
+	return repository.EventFilter{
+		ChainID:         chainID,
+		ContractAddress: contract,
+		UserAddress:     user,
+		PoolID:          poolID,
+		IncludePending:  includePending,
+	}, nil
+}
+
+func optionalAddress(name, raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+	if !common.IsHexAddress(raw) {
+		return "", status.Errorf(codes.InvalidArgument, "invalid %s", name)
+	}
+	return common.HexToAddress(raw).Hex(), nil
+}
+
+func internalError(op string, err error) error {
+	logger.Logger.Error("grpc query error", zap.String("op", op), zap.Error(err))
+	return status.Error(codes.Internal, "internal error")
+}
+
+func newPool(p *model.StakingPool) *stakingv1.Pool {
+	return &stakingv1.Pool{
+		ChainId:             p.ChainID,
+		ContractAddress:     p.ContractAddress,
+		PoolId:              p.PoolID,
+		StTokenAddress:      p.StTokenAddress,
+		PoolWeight:          p.PoolWeight,
+		LastRewardBlock:     p.LastRewardBlock,
+		AccZeroTokenPerSt:   strconv.FormatInt(p.AccZeroTokenPerSt, 10),
+		StTokenAmount:       decimal.Deref(p.StTokenAmount).String(),
+		MinDepositAmount:    strconv.FormatInt(p.MinDepositAmount, 10),
+		UnstakeLockedBlocks: p.UnstakeLockedBlocks,
+		PendingUnstake:      decimal.Deref(p.PendingUnstake).String(),
+		StakerCount:         int64Value(p.StakerCount),
+		TotalClaimed:        decimal.Deref(p.TotalClaimed).String(),
+	}
+}
+
+func newPosition(p *model.StakingUserPosition) *stakingv1.Position {
+	return &stakingv1.Position{
+		ChainId:         p.ChainID,
+		ContractAddress: p.ContractAddress,
+		PoolId:          p.PoolID,
+		UserAddress:     p.UserAddress,
+		StakedAmount:    decimal.Deref(p.StakedAmount).String(),
+		RewardDebt:      decimal.Deref(p.RewardDebt).String(),
+		TotalClaimed:    decimal.Deref(p.TotalClaimed).String(),
+		UpdatedAt:       timestamp(p.UpdatedAt),
+	}
+}
+
+func newEvent(e *model.StakingEvent) *stakingv1.Event {
+	ev := &stakingv1.Event{
+		Id:              e.ID,
+		ChainId:         e.ChainID,
+		ContractAddress: e.ContractAddress,
+		PoolId:          e.PoolID,
+		EventType:       e.EventType,
+		UserAddress:     e.UserAddress,
+		Amount:          e.Amount.String(),
+		BlockNumber:     e.BlockNumber,
+		BlockTime:       timestamp(e.BlockTime),
+		TxHash:          e.TxHash,
+		LogIndex:        e.LogIndex,
+	}
+	if e.ConfirmationStatus != nil {
+		ev.ConfirmationStatus = *e.ConfirmationStatus
+	}
+	return ev
+}
+
+func int64Value(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// timestamp 转换为 protobuf 时间，nil 时字段留空
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,83 @@
+package grpcserver
+
+import (
+	"errors"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	stakingv1 "github.com/dijiacoder/staking-indexer/internal/gen/pb/staking/v1"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/broadcast"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) StreamEvents(req *stakingv1.StreamEventsRequest, stream grpc.ServerStreamingServer[stakingv1.StreamEventsResponse]) error {
+	if req.GetChainId() == 0 {
+		return status.Error(codes.InvalidArgument, "chain_id is required")
+	}
+	if s.broadcaster == nil {
+		return status.Error(codes.Unavailable, "event streaming is disabled")
+	}
+	filter, err := eventFilter(req.GetChainId(), req.GetContractAddress(), req.GetUserAddress(), req.PoolId, req.GetIncludePending())
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	follower := broadcast.Follow(s.broadcaster, s.repo, filter)
+	defer follower.Close()
+
+	// 1. 从 resume_from 之后补发数据库中的事件
+	if from := req.GetResumeFrom(); from != nil {
+		pos := repository.EventPosition{BlockNumber: from.GetBlockNumber(), LogIndex: from.GetLogIndex()}
+		if err := follower.Replay(ctx, pos, func(e *model.StakingEvent) error {
+			return stream.Send(eventResponse(e))
+		}); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return internalError("replay events", err)
+		}
+	}
+
+	// 2. 实时推送
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-follower.C():
+			if !ok {
+				if errors.Is(follower.Err(), broadcast.ErrLagged) {
+					return status.Error(codes.ResourceExhausted, broadcast.ErrLagged.Error())
+				}
+				return status.Error(codes.Unavailable, follower.Err().Error())
+			}
+			if !follower.Accept(msg) {
+				continue
+			}
+			if err := stream.Send(streamResponse(msg)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func streamResponse(msg broadcast.Message) *stakingv1.StreamEventsResponse {
+	if msg.Kind == broadcast.KindRetract {
+		return &stakingv1.StreamEventsResponse{
+			Payload: &stakingv1.StreamEventsResponse_Retraction{Retraction: &stakingv1.Retraction{
+				ChainId:           msg.ChainID,
+				ContractAddresses: msg.ContractAddresses,
+				FromBlock:         msg.FromBlock,
+			}},
+		}
+	}
+	return eventResponse(msg.Event)
+}
+
+func eventResponse(e *model.StakingEvent) *stakingv1.StreamEventsResponse {
+	return &stakingv1.StreamEventsResponse{
+		Payload: &stakingv1.StreamEventsResponse_Event{Event: newEvent(e)},
+	}
+}
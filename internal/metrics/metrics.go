@@ -39,6 +39,14 @@ var (
 		[]string{"chain_id", "contract_address"},
 	)
 
+	ScanStatus = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "staking_indexer_scan_status",
+			Help: "游标扫描状态（1-正常 2-回滚中，回滚深度超限待人工处理 3-暂停）",
+		},
+		[]string{"chain_id", "contract_address"},
+	)
+
 	// 性能指标
 	BlocksPerSecond = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -91,6 +99,23 @@ var (
 		[]string{"chain_id", "contract_address", "event_type"},
 	)
 
+	// 链 worker 指标
+	ChainWorkerUp = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "staking_indexer_chain_worker_up",
+			Help: "链扫描worker是否运行中（1-运行）",
+		},
+		[]string{"chain_id"},
+	)
+
+	ChainWorkerRestartsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "staking_indexer_chain_worker_restarts_total",
+			Help: "链扫描worker重启次数",
+		},
+		[]string{"chain_id"},
+	)
+
 	// Reorg 指标
 	ReorgTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -115,4 +140,112 @@ var (
 		},
 		[]string{"chain_id", "contract_address"},
 	)
+
+	LogBlockHashMismatchTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "staking_indexer_log_block_hash_mismatch_total",
+			Help: "日志区块Hash与区块头不一致次数",
+		},
+		[]string{"chain_id", "contract_address"},
+	)
+
+	ReorgDepthExceeded = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "staking_indexer_reorg_depth_exceeded",
+			Help: "Reorg深度超过最大回滚深度，扫描器已停止（1-告警）",
+		},
+		[]string{"chain_id", "contract_address"},
+	)
+
+	// Webhook 指标
+	WebhookDeliveriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "staking_indexer_webhook_deliveries_total",
+			Help: "Webhook投递次数（result: success / retry / failed）",
+		},
+		[]string{"result"},
+	)
+
+	WebhookDueDeliveries = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "staking_indexer_webhook_due_deliveries",
+			Help: "最近一次轮询到期待投递的Webhook消息数",
+		},
+	)
+
+	// Sink 指标
+	SinkRecordsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "staking_indexer_sink_records_total",
+			Help: "写入事件输出的消息数（result: success / failed）",
+		},
+		[]string{"sink", "result"},
+	)
+
+	// 发件箱指标
+	OutboxRelayedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "staking_indexer_outbox_relayed_total",
+			Help: "发件箱已处理的消息数",
+		},
+		[]string{"consumer", "chain_id"},
+	)
+
+	OutboxRelayErrorsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "staking_indexer_outbox_relay_errors_total",
+			Help: "发件箱消息处理失败次数（失败的批次会重试）",
+		},
+		[]string{"consumer"},
+	)
+
+	// 链上对账指标
+	ReconcileDriftsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "staking_indexer_reconcile_drifts_total",
+			Help: "链上对账发现的差异数",
+		},
+		[]string{"chain_id", "contract_address", "field"},
+	)
+
+	ReconcileDrifts = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "staking_indexer_reconcile_drifts",
+			Help: "最近一次链上对账的差异数",
+		},
+		[]string{"chain_id", "contract_address"},
+	)
+
+	ReconcileRepairsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "staking_indexer_reconcile_repairs_total",
+			Help: "链上对账自动修复的记录数",
+		},
+		[]string{"chain_id", "contract_address", "field"},
+	)
+
+	// 质押池总量指标
+	PoolStTokenAmount = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "staking_indexer_pool_st_token_amount",
+			Help: "质押池质押数量（Deposit 减 RequestUnstake，wei）",
+		},
+		[]string{"chain_id", "contract_address", "pool_id"},
+	)
+
+	PoolPendingUnstake = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "staking_indexer_pool_pending_unstake",
+			Help: "质押池已申请赎回未提取数量（wei）",
+		},
+		[]string{"chain_id", "contract_address", "pool_id"},
+	)
+
+	PoolStakers = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "staking_indexer_pool_stakers",
+			Help: "质押池中质押数量扣除待提取后大于 0 的用户数",
+		},
+		[]string{"chain_id", "contract_address", "pool_id"},
+	)
 )
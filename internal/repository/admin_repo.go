@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/gen/query"
+	"gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+// admin_jobs.kind 取值
+const (
+	AdminJobRescan = "rescan"
+)
+
+// admin_jobs.status 取值
+const (
+	AdminJobPending = "pending"
+	AdminJobRunning = "running"
+	AdminJobDone    = "done"
+	AdminJobFailed  = "failed"
+)
+
+// admin_jobs.error 与 admin_audit_logs.error 的最大长度
+const maxAdminErrorLength = 512
+
+// admin_audit_logs.result 取值
+const (
+	AuditResultOK    = "ok"
+	AuditResultError = "error"
+)
+
+// AdminJobFilter 管理任务查询条件，零值字段不过滤
+type AdminJobFilter struct {
+	ChainID         int64
+	ContractAddress string
+	Status          string
+	Limit           int
+}
+
+type AdminRepository interface {
+	CreateJob(ctx context.Context, job *model.AdminJob) error
+
+	GetJob(ctx context.Context, id int64) (*model.AdminJob, error)
+
+	// ListJobs 按 id 倒序返回任务
+	ListJobs(ctx context.Context, filter AdminJobFilter) ([]*model.AdminJob, error)
+
+	// ClaimJob 领取最早的待执行任务，运行中但 staleBefore 之后没有进度的任务视为执行者已退出，可被重新领取。
+	// 没有可领取的任务时返回 nil
+	ClaimJob(ctx context.Context, staleBefore time.Time) (*model.AdminJob, error)
+
+	// UpdateJobProgress 记录任务已处理到的区块，同时刷新 updated_at
+	UpdateJobProgress(ctx context.Context, id int64, currentBlock int64) error
+
+	// FinishJob 结束任务，jobErr 为 nil 时标记为 done，否则标记为 failed
+	FinishJob(ctx context.Context, id int64, jobErr error) error
+
+	WriteAudit(ctx context.Context, log *model.AdminAuditLog) error
+
+	// ListCursors 返回全部合约游标，按链和合约排序
+	ListCursors(ctx context.Context) ([]*model.ChainScanCursor, error)
+}
+
+type adminRepository struct {
+	q *query.Query
+}
+
+func NewAdminRepository(db *gorm.DB) AdminRepository {
+	return &adminRepository{
+		q: query.Use(db),
+	}
+}
+
+func (r *adminRepository) CreateJob(ctx context.Context, job *model.AdminJob) error {
+	return r.q.AdminJob.WithContext(ctx).Create(job)
+}
+
+func (r *adminRepository) GetJob(ctx context.Context, id int64) (*model.AdminJob, error) {
+	j := r.q.AdminJob
+	return j.WithContext(ctx).Where(j.ID.Eq(id)).First()
+}
+
+func (r *adminRepository) ListJobs(ctx context.Context, filter AdminJobFilter) ([]*model.AdminJob, error) {
+	j := r.q.AdminJob
+	var conds []gen.Condition
+	if filter.ChainID != 0 {
+		conds = append(conds, j.ChainID.Eq(filter.ChainID))
+	}
+	if filter.ContractAddress != "" {
+		conds = append(conds, j.ContractAddress.Eq(filter.ContractAddress))
+	}
+	if filter.Status != "" {
+		conds = append(conds, j.Status.Eq(filter.Status))
+	}
+	return j.WithContext(ctx).Where(conds...).Order(j.ID.Desc()).Limit(PageSize(filter.Limit)).Find()
+}
+
+func (r *adminRepository) ClaimJob(ctx context.Context, staleBefore time.Time) (*model.AdminJob, error) {
+	j := r.q.AdminJob
+	jobs, err := j.WithContext(ctx).Where(
+		j.WithContext(ctx).Where(j.Status.Eq(AdminJobPending)).
+			Or(j.Status.Eq(AdminJobRunning), j.UpdatedAt.Lt(staleBefore)),
+	).Order(j.ID).Limit(1).Find()
+	if err != nil || len(jobs) == 0 {
+		return nil, err
+	}
+	job := jobs[0]
+
+	// 条件更新，多个副本同时领取时只有一个成功
+	now := time.Now()
+	conds := []gen.Condition{j.ID.Eq(job.ID), j.Status.Eq(job.Status)}
+	if job.Status == AdminJobRunning {
+		conds = append(conds, j.UpdatedAt.Lt(staleBefore))
+	}
+	info, err := j.WithContext(ctx).Where(conds...).Updates(map[string]interface{}{
+		"status":     AdminJobRunning,
+		"updated_at": now,
+	})
+	if err != nil || info.RowsAffected == 0 {
+		return nil, err
+	}
+	job.Status = AdminJobRunning
+	job.UpdatedAt = &now
+	return job, nil
+}
+
+func (r *adminRepository) UpdateJobProgress(ctx context.Context, id int64, currentBlock int64) error {
+	j := r.q.AdminJob
+	_, err := j.WithContext(ctx).Where(j.ID.Eq(id)).Updates(map[string]interface{}{
+		"current_block": currentBlock,
+		"updated_at":    time.Now(),
+	})
+	return err
+}
+
+func (r *adminRepository) FinishJob(ctx context.Context, id int64, jobErr error) error {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":      AdminJobDone,
+		"error":       "",
+		"updated_at":  now,
+		"finished_at": now,
+	}
+	if jobErr != nil {
+		updates["status"] = AdminJobFailed
+		updates["error"] = truncateAdminError(jobErr.Error())
+	}
+	j := r.q.AdminJob
+	_, err := j.WithContext(ctx).Where(j.ID.Eq(id)).Updates(updates)
+	return err
+}
+
+func (r *adminRepository) WriteAudit(ctx context.Context, log *model.AdminAuditLog) error {
+	log.Error = truncateAdminError(log.Error)
+	return r.q.AdminAuditLog.WithContext(ctx).Create(log)
+}
+
+func (r *adminRepository) ListCursors(ctx context.Context) ([]*model.ChainScanCursor, error) {
+	c := r.q.ChainScanCursor
+	return c.WithContext(ctx).Order(c.ChainID, c.ContractAddress).Find()
+}
+
+func truncateAdminError(msg string) string {
+	if len(msg) > maxAdminErrorLength {
+		return msg[:maxAdminErrorLength]
+	}
+	return msg
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/gen/query"
+	"gorm.io/gen"
+)
+
+// ClaimDailyFilter 每日领取汇总查询条件，零值字段不参与过滤
+type ClaimDailyFilter struct {
+	ChainID         int64
+	ContractAddress string
+	PoolID          *int64
+	// From / To 为 UTC 日期（含），nil 时不限制
+	From *time.Time
+	To   *time.Time
+	// AfterID 游标分页：只返回 id 大于该值的记录
+	AfterID int64
+	Limit   int
+}
+
+// applyClaim 把 Claim 事件计入质押池累计领取和每日汇总，sign 为 -1 时撤销（回滚）
+func applyClaim(ctx context.Context, tx *query.Query, ev *model.StakingEvent, sign int64) error {
+	amount := ev.Amount.Mul(sign)
+
+	// 1. 质押池累计领取，质押池尚未写入时只计入用户持仓与每日汇总
+	p := tx.StakingPool
+	if _, err := p.WithContext(ctx).Where(
+		p.ChainID.Eq(ev.ChainID),
+		p.ContractAddress.Eq(ev.ContractAddress),
+		p.PoolID.Eq(ev.PoolID),
+	).UpdateSimple(addAmount(p.TotalClaimed, amount)); err != nil {
+		return err
+	}
+
+	// 2. 每日汇总按事件的区块时间分桶，没有记录区块时间的事件不计入
+	day := claimDay(ev.BlockTime)
+	if day == nil {
+		return nil
+	}
+	d := tx.StakingClaimDaily
+	conds := []gen.Condition{
+		d.ChainID.Eq(ev.ChainID),
+		d.ContractAddress.Eq(ev.ContractAddress),
+		d.PoolID.Eq(ev.PoolID),
+		d.Day.Eq(*day),
+	}
+	count := d.ClaimCount.Add(1)
+	if sign < 0 {
+		count = d.ClaimCount.Sub(1)
+	}
+	info, err := d.WithContext(ctx).Where(conds...).UpdateSimple(addAmount(d.ClaimedAmount, amount), count)
+	if err != nil {
+		return err
+	}
+	if sign > 0 {
+		if info.RowsAffected > 0 {
+			return nil
+		}
+		return d.WithContext(ctx).Create(&model.StakingClaimDaily{
+			ChainID:         ev.ChainID,
+			ContractAddress: ev.ContractAddress,
+			PoolID:          ev.PoolID,
+			Day:             *day,
+			ClaimedAmount:   amount,
+			ClaimCount:      1,
+		})
+	}
+	_, err = d.WithContext(ctx).Where(append(conds, d.ClaimCount.Lte(0))...).Delete()
+	return err
+}
+
+// claimDay 区块时间所在的 UTC 日期，区块时间为空时返回 nil
+func claimDay(blockTime *time.Time) *time.Time {
+	if blockTime == nil {
+		return nil
+	}
+	t := blockTime.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return &day
+}
+
+// discardClaimDaily 删除合约的每日领取汇总，重建时由事件重新计入
+func discardClaimDaily(ctx context.Context, tx *query.Query, chainID int64, contractAddress string) error {
+	d := tx.StakingClaimDaily
+	_, err := d.WithContext(ctx).Where(
+		d.ChainID.Eq(chainID),
+		d.ContractAddress.Eq(contractAddress),
+	).Delete()
+	return err
+}
+
+func (r *stakingQueryRepository) ListClaimDaily(ctx context.Context, filter ClaimDailyFilter) ([]*model.StakingClaimDaily, error) {
+	d := r.q.StakingClaimDaily
+	conds := []gen.Condition{d.ID.Gt(filter.AfterID)}
+	if filter.ChainID != 0 {
+		conds = append(conds, d.ChainID.Eq(filter.ChainID))
+	}
+	if filter.ContractAddress != "" {
+		conds = append(conds, d.ContractAddress.Eq(filter.ContractAddress))
+	}
+	if filter.PoolID != nil {
+		conds = append(conds, d.PoolID.Eq(*filter.PoolID))
+	}
+	if filter.From != nil {
+		conds = append(conds, d.Day.Gte(*filter.From))
+	}
+	if filter.To != nil {
+		conds = append(conds, d.Day.Lte(*filter.To))
+	}
+	return d.WithContext(ctx).Where(conds...).Order(d.ID).Limit(PageSize(filter.Limit)).Find()
+}
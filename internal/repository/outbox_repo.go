@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/gen/query"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// event_outbox.kind 取值
+const (
+	OutboxKindEvent   = "event"
+	OutboxKindRetract = "retract"
+)
+
+type OutboxRepository interface {
+	// ListOutboxAfter 按 id 顺序返回链上 afterID 之后的发件箱记录
+	ListOutboxAfter(ctx context.Context, chainID int64, afterID int64, limit int) ([]*model.EventOutbox, error)
+
+	// ListOutboxIDs 按顺序返回全部链在 (afterID, toID] 内已提交的发件箱 id，用于发现未提交事务占用的 id 空洞
+	ListOutboxIDs(ctx context.Context, afterID int64, toID int64) ([]int64, error)
+
+	// GetCheckpoint 返回消费者在链上已处理的最大 id，没有记录时返回 0
+	GetCheckpoint(ctx context.Context, consumer string, chainID int64) (int64, error)
+
+	SaveCheckpoint(ctx context.Context, consumer string, chainID int64, lastID int64) error
+
+	// DeleteOutboxBefore 删除 before 之前写入的记录，单次最多 limit 条，返回删除数量
+	DeleteOutboxBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+type outboxRepository struct {
+	q *query.Query
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{
+		q: query.Use(db),
+	}
+}
+
+func (r *outboxRepository) ListOutboxAfter(ctx context.Context, chainID int64, afterID int64, limit int) ([]*model.EventOutbox, error) {
+	o := r.q.EventOutbox
+	return o.WithContext(ctx).Where(
+		o.ChainID.Eq(chainID),
+		o.ID.Gt(afterID),
+	).Order(o.ID).Limit(limit).Find()
+}
+
+func (r *outboxRepository) ListOutboxIDs(ctx context.Context, afterID int64, toID int64) ([]int64, error) {
+	o := r.q.EventOutbox
+	var ids []int64
+	err := o.WithContext(ctx).Where(o.ID.Gt(afterID), o.ID.Lte(toID)).Order(o.ID).Pluck(o.ID, &ids)
+	return ids, err
+}
+
+func (r *outboxRepository) GetCheckpoint(ctx context.Context, consumer string, chainID int64) (int64, error) {
+	c := r.q.OutboxCheckpoint
+	checkpoints, err := c.WithContext(ctx).Where(
+		c.Consumer.Eq(consumer),
+		c.ChainID.Eq(chainID),
+	).Limit(1).Find()
+	if err != nil || len(checkpoints) == 0 {
+		return 0, err
+	}
+	return checkpoints[0].LastID, nil
+}
+
+func (r *outboxRepository) SaveCheckpoint(ctx context.Context, consumer string, chainID int64, lastID int64) error {
+	return r.q.OutboxCheckpoint.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "consumer"}, {Name: "chain_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_id", "updated_at"}),
+	}).Create(&model.OutboxCheckpoint{
+		Consumer: consumer,
+		ChainID:  chainID,
+		LastID:   lastID,
+	})
+}
+
+func (r *outboxRepository) DeleteOutboxBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	o := r.q.EventOutbox
+	info, err := o.WithContext(ctx).Where(o.CreatedAt.Lt(before)).Limit(limit).Delete()
+	return info.RowsAffected, err
+}
+
+// writeOutboxEvents 在事务内为事件写入发件箱记录
+func writeOutboxEvents(ctx context.Context, tx *query.Query, events []*model.StakingEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	entries := make([]*model.EventOutbox, 0, len(events))
+	for _, ev := range events {
+		payload, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, &model.EventOutbox{
+			ChainID:         ev.ChainID,
+			ContractAddress: ev.ContractAddress,
+			Kind:            OutboxKindEvent,
+			BlockNumber:     ev.BlockNumber,
+			Payload:         string(payload),
+		})
+	}
+	return tx.EventOutbox.WithContext(ctx).Create(entries...)
+}
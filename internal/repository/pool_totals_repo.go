@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dijiacoder/staking-indexer/internal/decimal"
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/gen/query"
+	"gorm.io/gen"
+)
+
+// PoolHistoryFilter 质押池总量历史查询条件，零值字段不参与过滤
+type PoolHistoryFilter struct {
+	ChainID         int64
+	ContractAddress string
+	PoolID          int64
+	FromBlock       int64
+	// ToBlock 为 0 时不限制结束区块
+	ToBlock int64
+	// AfterBlock 游标分页：只返回区块大于该值的记录
+	AfterBlock int64
+	Limit      int
+}
+
+// poolTotalsDelta 事件对质押池总量的影响
+type poolTotalsDelta struct {
+	stTokenAmount  decimal.Int
+	pendingUnstake decimal.Int
+	stakerCount    int64
+}
+
+func (d poolTotalsDelta) zero() bool {
+	return d.stTokenAmount.IsZero() && d.pendingUnstake.IsZero() && d.stakerCount == 0
+}
+
+// newPoolTotalsDelta 事件使用户有效质押数量从 before 变为 after 时质押池总量的变化。
+// stTokenAmount 与合约一致在 RequestUnstake 时扣减，质押人数与之同一口径，
+// 按有效质押数量（staked_amount 扣除 pending_unstake，见 activeStake）是否大于 0 计算
+func newPoolTotalsDelta(ev *model.StakingEvent, before, after decimal.Int) poolTotalsDelta {
+	var d poolTotalsDelta
+	switch ev.EventType {
+	case "Deposit":
+		d.stTokenAmount = ev.Amount
+	case "RequestUnstake":
+		d.stTokenAmount = ev.Amount.Neg()
+		d.pendingUnstake = ev.Amount
+	case "Withdraw":
+		d.pendingUnstake = ev.Amount.Neg()
+	}
+	switch {
+	case before.Sign() <= 0 && after.Sign() > 0:
+		d.stakerCount = 1
+	case before.Sign() > 0 && after.Sign() <= 0:
+		d.stakerCount = -1
+	}
+	return d
+}
+
+// applyPositionEvent 把事件计入（sign = 1）或撤销（sign = -1）用户持仓，返回质押池总量的变化。
+// 撤销时的变化与计入时相反，质押人数按撤销前后的有效质押数量重新判断
+func applyPositionEvent(pos *model.StakingUserPosition, ev *model.StakingEvent, sign int64) poolTotalsDelta {
+	before := activeStake(pos)
+	if pos.StakedAmount != nil {
+		*pos.StakedAmount = pos.StakedAmount.Add(stakedAmountDelta(ev).Mul(sign))
+	}
+	if pos.PendingUnstake != nil {
+		*pos.PendingUnstake = pos.PendingUnstake.Add(pendingUnstakeDelta(ev).Mul(sign))
+	}
+	if pos.TotalClaimed != nil {
+		*pos.TotalClaimed = pos.TotalClaimed.Add(claimedAmountDelta(ev).Mul(sign))
+	}
+	d := newPoolTotalsDelta(ev, before, activeStake(pos))
+	d.stTokenAmount = d.stTokenAmount.Mul(sign)
+	d.pendingUnstake = d.pendingUnstake.Mul(sign)
+	return d
+}
+
+// activeStake 用户仍计入 stTokenAmount 的质押数量，即质押数量扣除已申请赎回未提取的部分
+func activeStake(pos *model.StakingUserPosition) decimal.Int {
+	return decimal.Deref(pos.StakedAmount).Sub(decimal.Deref(pos.PendingUnstake))
+}
+
+// applyPoolTotals 把变化计入质押池总量，history 为 true 时同时写入按区块的历史
+func applyPoolTotals(ctx context.Context, tx *query.Query, ev *model.StakingEvent, d poolTotalsDelta, history bool) error {
+	if d.zero() {
+		return nil
+	}
+	p := tx.StakingPool
+	if _, err := p.WithContext(ctx).Where(
+		p.ChainID.Eq(ev.ChainID),
+		p.ContractAddress.Eq(ev.ContractAddress),
+		p.PoolID.Eq(ev.PoolID),
+	).UpdateSimple(
+		addAmount(p.StTokenAmount, d.stTokenAmount),
+		addAmount(p.PendingUnstake, d.pendingUnstake),
+		p.StakerCount.Add(d.stakerCount),
+	); err != nil {
+		return err
+	}
+	if !history {
+		return nil
+	}
+	return recordPoolHistory(ctx, tx, ev, d)
+}
+
+// recordPoolHistory 把变化计入事件所在区块及之后的历史记录。
+// 按区块顺序扫描时只会写入最新区块，补录较早区块的事件时之后的记录一并调整
+func recordPoolHistory(ctx context.Context, tx *query.Query, ev *model.StakingEvent, d poolTotalsDelta) error {
+	h := tx.StakingPoolHistory
+	conds := []gen.Condition{
+		h.ChainID.Eq(ev.ChainID),
+		h.ContractAddress.Eq(ev.ContractAddress),
+		h.PoolID.Eq(ev.PoolID),
+	}
+	info, err := h.WithContext(ctx).Where(append(conds, h.BlockNumber.Gte(ev.BlockNumber))...).UpdateSimple(
+		addAmount(h.StTokenAmount, d.stTokenAmount),
+		addAmount(h.PendingUnstake, d.pendingUnstake),
+		h.StakerCount.Add(d.stakerCount),
+	)
+	if err != nil {
+		return err
+	}
+	if info.RowsAffected > 0 {
+		exists, err := h.WithContext(ctx).Where(append(conds, h.BlockNumber.Eq(ev.BlockNumber))...).Count()
+		if err != nil || exists > 0 {
+			return err
+		}
+	}
+
+	// 该区块还没有记录，从之前最近的记录开始累加
+	row := &model.StakingPoolHistory{
+		ChainID:         ev.ChainID,
+		ContractAddress: ev.ContractAddress,
+		PoolID:          ev.PoolID,
+		BlockNumber:     ev.BlockNumber,
+	}
+	prev, err := h.WithContext(ctx).Where(append(conds, h.BlockNumber.Lt(ev.BlockNumber))...).
+		Order(h.BlockNumber.Desc()).Limit(1).Find()
+	if err != nil {
+		return err
+	}
+	if len(prev) > 0 {
+		row.StTokenAmount = prev[0].StTokenAmount
+		row.PendingUnstake = prev[0].PendingUnstake
+		row.StakerCount = prev[0].StakerCount
+	}
+	row.StTokenAmount = row.StTokenAmount.Add(d.stTokenAmount)
+	row.PendingUnstake = row.PendingUnstake.Add(d.pendingUnstake)
+	row.StakerCount += d.stakerCount
+	return h.WithContext(ctx).Create(row)
+}
+
+// discardPoolHistory 删除 afterBlock 之后的质押池总量历史
+func discardPoolHistory(ctx context.Context, tx *query.Query, chainID int64, contractAddresses []string, afterBlock int64) error {
+	h := tx.StakingPoolHistory
+	_, err := h.WithContext(ctx).Where(
+		h.ChainID.Eq(chainID),
+		h.ContractAddress.In(contractAddresses...),
+		h.BlockNumber.Gt(afterBlock),
+	).Delete()
+	return err
+}
+
+func (r *stakingQueryRepository) ListPoolHistory(ctx context.Context, filter PoolHistoryFilter) ([]*model.StakingPoolHistory, error) {
+	h := r.q.StakingPoolHistory
+	conds := []gen.Condition{
+		h.ChainID.Eq(filter.ChainID),
+		h.ContractAddress.Eq(filter.ContractAddress),
+		h.PoolID.Eq(filter.PoolID),
+		h.BlockNumber.Gte(filter.FromBlock),
+		h.BlockNumber.Gt(filter.AfterBlock),
+	}
+	if filter.ToBlock > 0 {
+		conds = append(conds, h.BlockNumber.Lte(filter.ToBlock))
+	}
+	// 同一质押池每个区块只有一条记录，按区块排序便于绘制曲线
+	return h.WithContext(ctx).Where(conds...).Order(h.BlockNumber).Limit(PageSize(filter.Limit)).Find()
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dijiacoder/staking-indexer/internal/decimal"
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+)
+
+func TestPoolTotalsStakerCount(t *testing.T) {
+	type step struct {
+		eventType string
+		amount    string
+		// 计入事件后的质押池总量
+		stTokenAmount  string
+		pendingUnstake string
+		stakerCount    int64
+	}
+	cases := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "full unstake then withdraw",
+			steps: []step{
+				{"Deposit", "100", "100", "0", 1},
+				{"RequestUnstake", "100", "0", "100", 0},
+				{"Withdraw", "100", "0", "0", 0},
+			},
+		},
+		{
+			name: "partial unstake keeps staker",
+			steps: []step{
+				{"Deposit", "100", "100", "0", 1},
+				{"RequestUnstake", "40", "60", "40", 1},
+				{"Withdraw", "40", "60", "0", 1},
+				{"RequestUnstake", "60", "0", "60", 0},
+			},
+		},
+		{
+			name: "deposit again while unstake pending",
+			steps: []step{
+				{"Deposit", "100", "100", "0", 1},
+				{"RequestUnstake", "100", "0", "100", 0},
+				{"Deposit", "50", "50", "100", 1},
+				{"Withdraw", "100", "50", "0", 1},
+				{"Claim", "7", "50", "0", 1},
+			},
+		},
+		{
+			name: "amounts above 2^53 stay exact",
+			steps: []step{
+				{"Deposit", "1000000000000000001", "1000000000000000001", "0", 1},
+				{"RequestUnstake", "1", "1000000000000000000", "1", 1},
+				{"Withdraw", "1", "1000000000000000000", "0", 1},
+				{"RequestUnstake", "1000000000000000000", "0", "1000000000000000000", 0},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pos := &model.StakingUserPosition{
+				StakedAmount:   decimal.Ptr(decimal.Zero),
+				PendingUnstake: decimal.Ptr(decimal.Zero),
+				TotalClaimed:   decimal.Ptr(decimal.Zero),
+			}
+			var totals poolTotalsDelta
+			var applied []*model.StakingEvent
+			history := []poolTotalsDelta{totals}
+			for i, s := range tc.steps {
+				ev := &model.StakingEvent{EventType: s.eventType, Amount: mustParse(t, s.amount)}
+				totals = addTotals(totals, applyPositionEvent(pos, ev, 1))
+				want := poolTotalsDelta{mustParse(t, s.stTokenAmount), mustParse(t, s.pendingUnstake), s.stakerCount}
+				if !equalTotals(totals, want) {
+					t.Fatalf("step %d %s: totals = %s, want %s", i, s.eventType, formatTotals(totals), formatTotals(want))
+				}
+				applied = append(applied, ev)
+				history = append(history, totals)
+			}
+
+			// 按相反顺序回滚，每一步都回到计入该事件之前的总量
+			for i := len(applied) - 1; i >= 0; i-- {
+				totals = addTotals(totals, applyPositionEvent(pos, applied[i], -1))
+				if !equalTotals(totals, history[i]) {
+					t.Fatalf("revert step %d %s: totals = %s, want %s", i, applied[i].EventType, formatTotals(totals), formatTotals(history[i]))
+				}
+			}
+			if !pos.StakedAmount.IsZero() || !pos.PendingUnstake.IsZero() || !pos.TotalClaimed.IsZero() {
+				t.Fatalf("position after revert = (%s, %s, %s), want zero", pos.StakedAmount, pos.PendingUnstake, pos.TotalClaimed)
+			}
+		})
+	}
+}
+
+func mustParse(t *testing.T, s string) decimal.Int {
+	t.Helper()
+	v, err := decimal.Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func addTotals(a, b poolTotalsDelta) poolTotalsDelta {
+	return poolTotalsDelta{a.stTokenAmount.Add(b.stTokenAmount), a.pendingUnstake.Add(b.pendingUnstake), a.stakerCount + b.stakerCount}
+}
+
+func equalTotals(a, b poolTotalsDelta) bool {
+	return a.stTokenAmount.Cmp(b.stTokenAmount) == 0 && a.pendingUnstake.Cmp(b.pendingUnstake) == 0 && a.stakerCount == b.stakerCount
+}
+
+func formatTotals(d poolTotalsDelta) string {
+	return fmt.Sprintf("{st_token_amount:%s pending_unstake:%s staker_count:%d}", d.stTokenAmount, d.pendingUnstake, d.stakerCount)
+}
@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/decimal"
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/gen/query"
+	"gorm.io/gen"
+	"gorm.io/gorm/clause"
+)
+
+// ErrBlockTimeNotIndexed 时间晚于已索引的最新区块或早于第一个记录了时间的区块
+var ErrBlockTimeNotIndexed = errors.New("no indexed block covers the requested time")
+
+// PositionAtFilter 历史持仓查询条件，ChainID 与 UserAddress 必填
+type PositionAtFilter struct {
+	ChainID         int64
+	ContractAddress string
+	UserAddress     string
+	PoolID          *int64
+	// BlockNumber 包含该区块及之前的事件
+	BlockNumber int64
+	// IncludePending 为 false 时只统计已确认事件
+	IncludePending bool
+}
+
+// PositionAt 用户在某个区块的持仓。StakedAmount 与 staking_user_positions 一致（提取后扣减），
+// 其中 PendingUnstake 为已申请赎回尚未提取的部分
+type PositionAt struct {
+	ChainID         int64
+	ContractAddress string
+	PoolID          int64
+	UserAddress     string
+	BlockNumber     int64
+	StakedAmount    decimal.Int
+	PendingUnstake  decimal.Int
+	ClaimedAmount   decimal.Int
+}
+
+// positionTotals 按事件累加的持仓数量
+type positionTotals struct {
+	staked         decimal.Int
+	pendingUnstake decimal.Int
+	claimed        decimal.Int
+}
+
+func (t *positionTotals) apply(eventType string, amount decimal.Int) {
+	ev := &model.StakingEvent{EventType: eventType, Amount: amount}
+	t.staked = t.staked.Add(stakedAmountDelta(ev))
+	t.pendingUnstake = t.pendingUnstake.Add(pendingUnstakeDelta(ev))
+	t.claimed = t.claimed.Add(claimedAmountDelta(ev))
+}
+
+// eventTypeSum 按事件类型汇总的数量
+type eventTypeSum struct {
+	PoolID      int64
+	UserAddress string
+	EventType   string
+	Amount      decimal.Int
+}
+
+func (r *stakingQueryRepository) GetPositionsAt(ctx context.Context, filter PositionAtFilter) ([]*PositionAt, error) {
+	e := r.q.StakingEvent
+	conds := []gen.Condition{
+		e.ChainID.Eq(filter.ChainID),
+		e.UserAddress.Eq(filter.UserAddress),
+		e.BlockNumber.Lte(filter.BlockNumber),
+	}
+	if filter.ContractAddress != "" {
+		conds = append(conds, e.ContractAddress.Eq(filter.ContractAddress))
+	}
+	if filter.PoolID != nil {
+		conds = append(conds, e.PoolID.Eq(*filter.PoolID))
+	}
+	if filter.IncludePending {
+		conds = append(conds, e.ConfirmationStatus.Neq(ConfirmationStatusOrphaned))
+	} else {
+		conds = append(conds, e.ConfirmationStatus.Eq(ConfirmationStatusConfirmed))
+	}
+
+	// 1. 用户在该区块前有过事件的池
+	keys, err := e.WithContext(ctx).Distinct(e.ContractAddress, e.PoolID).Where(conds...).
+		Order(e.ContractAddress, e.PoolID).Find()
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]*PositionAt, 0, len(keys))
+	for _, key := range keys {
+		// 2. 从最近的快照开始累加之后的事件
+		s := r.q.StakingPositionSnapshot
+		snapshots, err := s.WithContext(ctx).Where(
+			s.ChainID.Eq(filter.ChainID),
+			s.ContractAddress.Eq(key.ContractAddress),
+			s.UserAddress.Eq(filter.UserAddress),
+			s.PoolID.Eq(key.PoolID),
+			s.BlockNumber.Lte(filter.BlockNumber),
+		).Order(s.BlockNumber.Desc()).Limit(1).Find()
+		if err != nil {
+			return nil, err
+		}
+		var totals positionTotals
+		fromBlock := int64(-1)
+		if len(snapshots) > 0 {
+			snap := snapshots[0]
+			totals = positionTotals{staked: snap.StakedAmount, pendingUnstake: snap.PendingUnstake, claimed: snap.ClaimedAmount}
+			fromBlock = snap.BlockNumber
+		}
+
+		var sums []eventTypeSum
+		if err := e.WithContext(ctx).Select(e.EventType, e.Amount.Sum().As("amount")).Where(append(conds,
+			e.ContractAddress.Eq(key.ContractAddress),
+			e.PoolID.Eq(key.PoolID),
+			e.BlockNumber.Gt(fromBlock),
+		)...).Group(e.EventType).Scan(&sums); err != nil {
+			return nil, err
+		}
+		for _, sum := range sums {
+			totals.apply(sum.EventType, sum.Amount)
+		}
+
+		positions = append(positions, &PositionAt{
+			ChainID:         filter.ChainID,
+			ContractAddress: key.ContractAddress,
+			PoolID:          key.PoolID,
+			UserAddress:     filter.UserAddress,
+			BlockNumber:     filter.BlockNumber,
+			StakedAmount:    totals.staked,
+			PendingUnstake:  totals.pendingUnstake,
+			ClaimedAmount:   totals.claimed,
+		})
+	}
+	return positions, nil
+}
+
+func (r *stakingQueryRepository) GetBlockNumberAt(ctx context.Context, chainID int64, at time.Time) (int64, error) {
+	b := r.q.ChainBlock
+	// 之后还有已索引的区块，才能确定 at 时刻的最新区块
+	later, err := b.WithContext(ctx).Where(
+		b.ChainID.Eq(chainID),
+		b.BlockTime.Gt(at),
+	).Limit(1).Find()
+	if err != nil {
+		return 0, err
+	}
+	blocks, err := b.WithContext(ctx).Where(
+		b.ChainID.Eq(chainID),
+		b.BlockTime.Lte(at),
+	).Order(b.BlockTime.Desc(), b.BlockNumber.Desc()).Limit(1).Find()
+	if err != nil {
+		return 0, err
+	}
+	if len(later) == 0 || len(blocks) == 0 {
+		return 0, ErrBlockTimeNotIndexed
+	}
+	return blocks[0].BlockNumber, nil
+}
+
+// SnapshotPositions 为 (last_snapshot_block, toBlock] 内有已确认事件的用户生成 toBlock 的持仓快照，
+// 并推进游标的 last_snapshot_block。toBlock 不应超过已确认区块，返回生成的快照数量
+func (r *scannerRepository) SnapshotPositions(ctx context.Context, chainID int64, contractAddress string, toBlock int64) (int, error) {
+	created := 0
+	err := r.q.Transaction(func(tx *query.Query) error {
+		c := tx.ChainScanCursor
+		cursor, err := c.WithContext(ctx).Where(
+			c.ChainID.Eq(chainID),
+			c.ContractAddress.Eq(contractAddress),
+		).First()
+		if err != nil {
+			return err
+		}
+		var fromBlock int64
+		if cursor.LastSnapshotBlock != nil {
+			fromBlock = *cursor.LastSnapshotBlock
+		}
+		// 游标可能在快照生成前被回退
+		if toBlock <= fromBlock || toBlock > cursor.LastConfirmedBlock {
+			return nil
+		}
+
+		// 1. 区间内按用户、事件类型汇总
+		e := tx.StakingEvent
+		var sums []eventTypeSum
+		if err := e.WithContext(ctx).Select(e.PoolID, e.UserAddress, e.EventType, e.Amount.Sum().As("amount")).Where(
+			e.ChainID.Eq(chainID),
+			e.ContractAddress.Eq(contractAddress),
+			e.BlockNumber.Gt(fromBlock),
+			e.BlockNumber.Lte(toBlock),
+			e.ConfirmationStatus.Eq(ConfirmationStatusConfirmed),
+		).Group(e.PoolID, e.UserAddress, e.EventType).Scan(&sums); err != nil {
+			return err
+		}
+
+		deltas := make(map[userPool][]eventTypeSum)
+		order := make([]userPool, 0)
+		for _, sum := range sums {
+			key := userPool{poolID: sum.PoolID, user: sum.UserAddress}
+			if _, ok := deltas[key]; !ok {
+				order = append(order, key)
+			}
+			deltas[key] = append(deltas[key], sum)
+		}
+
+		// 2. 在上一个快照的基础上累加
+		s := tx.StakingPositionSnapshot
+		snapshots := make([]*model.StakingPositionSnapshot, 0, len(order))
+		for _, key := range order {
+			prev, err := s.WithContext(ctx).Where(
+				s.ChainID.Eq(chainID),
+				s.ContractAddress.Eq(contractAddress),
+				s.UserAddress.Eq(key.user),
+				s.PoolID.Eq(key.poolID),
+				s.BlockNumber.Lte(fromBlock),
+			).Order(s.BlockNumber.Desc()).Limit(1).Find()
+			if err != nil {
+				return err
+			}
+			var totals positionTotals
+			if len(prev) > 0 {
+				totals = positionTotals{staked: prev[0].StakedAmount, pendingUnstake: prev[0].PendingUnstake, claimed: prev[0].ClaimedAmount}
+			}
+			for _, sum := range deltas[key] {
+				totals.apply(sum.EventType, sum.Amount)
+			}
+			snapshots = append(snapshots, &model.StakingPositionSnapshot{
+				ChainID:         chainID,
+				ContractAddress: contractAddress,
+				PoolID:          key.poolID,
+				UserAddress:     key.user,
+				BlockNumber:     toBlock,
+				StakedAmount:    totals.staked,
+				PendingUnstake:  totals.pendingUnstake,
+				ClaimedAmount:   totals.claimed,
+			})
+		}
+		if len(snapshots) > 0 {
+			if err := s.WithContext(ctx).Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "chain_id"}, {Name: "contract_address"}, {Name: "user_address"}, {Name: "pool_id"}, {Name: "block_number"}},
+				DoUpdates: clause.AssignmentColumns([]string{"staked_amount", "pending_unstake", "claimed_amount"}),
+			}).CreateInBatches(snapshots, 500); err != nil {
+				return err
+			}
+		}
+
+		// 3. 推进快照进度
+		if _, err := c.WithContext(ctx).Where(c.ID.Eq(cursor.ID)).Update(c.LastSnapshotBlock, toBlock); err != nil {
+			return err
+		}
+		created = len(snapshots)
+		return nil
+	})
+	return created, err
+}
+
+// DiscardSnapshots 删除 afterBlock 之后的持仓快照并回退 last_snapshot_block，扫描器随后重新生成
+func (r *scannerRepository) DiscardSnapshots(ctx context.Context, chainID int64, contractAddress string, afterBlock int64) error {
+	return r.q.Transaction(func(tx *query.Query) error {
+		return discardSnapshots(ctx, tx, chainID, []string{contractAddress}, afterBlock)
+	})
+}
+
+func discardSnapshots(ctx context.Context, tx *query.Query, chainID int64, contractAddresses []string, afterBlock int64) error {
+	afterBlock = max(afterBlock, 0)
+	s := tx.StakingPositionSnapshot
+	if _, err := s.WithContext(ctx).Where(
+		s.ChainID.Eq(chainID),
+		s.ContractAddress.In(contractAddresses...),
+		s.BlockNumber.Gt(afterBlock),
+	).Delete(); err != nil {
+		return err
+	}
+	c := tx.ChainScanCursor
+	_, err := c.WithContext(ctx).Where(
+		c.ChainID.Eq(chainID),
+		c.ContractAddress.In(contractAddresses...),
+		c.LastSnapshotBlock.Gt(afterBlock),
+	).Update(c.LastSnapshotBlock, afterBlock)
+	return err
+}
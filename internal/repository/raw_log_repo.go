@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/gen/query"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm/clause"
+)
+
+// RawLogFilter 归档日志查询条件
+type RawLogFilter struct {
+	ChainID         int64
+	ContractAddress string
+	// ToBlock 为 0 时不限制结束区块
+	ToBlock int64
+	// Topic0 非空时只返回这些事件签名的日志
+	Topic0 []string
+	Limit  int
+}
+
+// SaveRawLogs 归档日志，同一日志重复写入时更新所在区块
+func (r *scannerRepository) SaveRawLogs(ctx context.Context, logs []*model.RawLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	return r.q.RawLog.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chain_id"}, {Name: "tx_hash"}, {Name: "log_index"}},
+		DoUpdates: clause.AssignmentColumns([]string{"block_number", "block_hash", "tx_index", "topic0", "topics", "data"}),
+	}).Create(logs...)
+}
+
+// ListRawLogsAfterPosition 按 (block_number, log_index) 顺序返回 after 之后的归档日志
+func (r *scannerRepository) ListRawLogsAfterPosition(ctx context.Context, filter RawLogFilter, after EventPosition) ([]*model.RawLog, error) {
+	return listRawLogsAfterPosition(ctx, r.q, filter, after)
+}
+
+func listRawLogsAfterPosition(ctx context.Context, q *query.Query, filter RawLogFilter, after EventPosition) ([]*model.RawLog, error) {
+	l := q.RawLog
+	conds := []gen.Condition{
+		l.ChainID.Eq(filter.ChainID),
+		l.ContractAddress.Eq(filter.ContractAddress),
+		field.Or(
+			l.BlockNumber.Gt(after.BlockNumber),
+			field.And(l.BlockNumber.Eq(after.BlockNumber), l.LogIndex.Gt(after.LogIndex)),
+		),
+	}
+	if filter.ToBlock > 0 {
+		conds = append(conds, l.BlockNumber.Lte(filter.ToBlock))
+	}
+	if len(filter.Topic0) > 0 {
+		conds = append(conds, l.Topic0.In(filter.Topic0...))
+	}
+	return l.WithContext(ctx).Where(conds...).Order(l.BlockNumber, l.LogIndex).Limit(PageSize(filter.Limit)).Find()
+}
+
+// discardRawLogs 删除 afterBlock 之后的归档日志，重新扫描时按新分叉写入
+func discardRawLogs(ctx context.Context, tx *query.Query, chainID int64, contractAddresses []string, afterBlock int64) error {
+	_, err := tx.RawLog.WithContext(ctx).Where(
+		tx.RawLog.ChainID.Eq(chainID),
+		tx.RawLog.ContractAddress.In(contractAddresses...),
+		tx.RawLog.BlockNumber.Gt(afterBlock),
+	).Delete()
+	return err
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/gen/query"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ResetProjections 清空暂停中合约的派生数据（用户持仓、持仓快照、质押池及其历史、每日领取汇总），staking_events 保持不变。
+// 质押池由重放 AddPool 等日志重新生成
+func (r *scannerRepository) ResetProjections(ctx context.Context, chainID int64, contractAddress string) error {
+	return r.q.Transaction(func(tx *query.Query) error {
+		c := tx.ChainScanCursor
+		cursor, err := c.WithContext(ctx).Where(
+			c.ChainID.Eq(chainID),
+			c.ContractAddress.Eq(contractAddress),
+		).Clauses(clause.Locking{Strength: "UPDATE"}).First()
+		if err != nil {
+			return err
+		}
+		if cursor.ScanStatus == nil || *cursor.ScanStatus != ScanStatusPaused {
+			return ErrCursorNotPaused
+		}
+
+		p := tx.StakingUserPosition
+		if _, err := p.WithContext(ctx).Where(
+			p.ChainID.Eq(chainID),
+			p.ContractAddress.Eq(contractAddress),
+		).Delete(); err != nil {
+			return err
+		}
+
+		sp := tx.StakingPool
+		if _, err := sp.WithContext(ctx).Where(
+			sp.ChainID.Eq(chainID),
+			sp.ContractAddress.Eq(contractAddress),
+		).Delete(); err != nil {
+			return err
+		}
+		if err := discardPoolHistory(ctx, tx, chainID, []string{contractAddress}, -1); err != nil {
+			return err
+		}
+		if err := discardClaimDaily(ctx, tx, chainID, contractAddress); err != nil {
+			return err
+		}
+		return discardSnapshots(ctx, tx, chainID, []string{contractAddress}, 0)
+	})
+}
+
+// replayRepository 重建模式下交给事件处理器的仓储。
+// 事件已经在 staking_events 中，只计入持仓，不重复写入事件和发件箱
+type replayRepository struct {
+	*scannerRepository
+}
+
+func NewReplayRepository(db *gorm.DB) ScannerRepository {
+	return &replayRepository{
+		scannerRepository: &scannerRepository{
+			db: db,
+			q:  query.Use(db),
+		},
+	}
+}
+
+func (r *replayRepository) SaveEventsAndProcessPositions(ctx context.Context, events []*model.StakingEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return r.q.Transaction(func(tx *query.Query) error {
+		return applyPositions(ctx, tx, events)
+	})
+}
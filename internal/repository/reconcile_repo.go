@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dijiacoder/staking-indexer/internal/decimal"
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/gen/query"
+	"gorm.io/gen"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// reconcile_drifts.field 取值
+const (
+	DriftPoolCount           = "pool_count"
+	DriftStTokenAddress      = "st_token_address"
+	DriftPoolWeight          = "pool_weight"
+	DriftMinDepositAmount    = "min_deposit_amount"
+	DriftUnstakeLockedBlocks = "unstake_locked_blocks"
+	DriftStTokenAmount       = "st_token_amount"
+	DriftStakedAmount        = "staked_amount"
+	DriftPendingUnstake      = "pending_unstake"
+)
+
+// IndexedPosition 用户持仓在对账区块的索引值
+type IndexedPosition struct {
+	PoolID      int64
+	UserAddress string
+	// StakedAmount staking_user_positions.staked_amount 扣除对账区块之后的事件，与链上 stakingBalance + requestAmount 对应
+	StakedAmount decimal.Int
+	// PendingUnstake 已确认的 RequestUnstake 减 Withdraw，与链上 requestAmount 对应
+	PendingUnstake decimal.Int
+}
+
+// ReconcileState 合约在对账区块的索引状态
+type ReconcileState struct {
+	BlockNumber int64
+	Pools       []*model.StakingPool
+	// PoolStaked 按质押池汇总的已确认 Deposit 减 RequestUnstake，与链上 stTokenAmount 对应
+	PoolStaked map[int64]decimal.Int
+	Positions  []*IndexedPosition
+}
+
+type ReconcileRepository interface {
+	// LoadState 在同一事务内读取合约在 blockNumber（不超过已确认区块）的质押池和用户持仓
+	LoadState(ctx context.Context, chainID int64, contractAddress string, blockNumber int64) (*ReconcileState, error)
+
+	// SaveDrifts 记录对账差异
+	SaveDrifts(ctx context.Context, drifts []*model.ReconcileDrift) error
+
+	// RepairPosition 把用户在 blockNumber 的质押数量修正为 stakedAmount，之后的事件照常计入
+	RepairPosition(ctx context.Context, chainID int64, contractAddress string, poolID int64, userAddress string, blockNumber int64, stakedAmount decimal.Int) error
+
+	// RepairPool 按链上值写入质押池参数，质押池不存在时创建
+	RepairPool(ctx context.Context, pool *model.StakingPool) error
+}
+
+type reconcileRepository struct {
+	db *gorm.DB
+	q  *query.Query
+}
+
+func NewReconcileRepository(db *gorm.DB) ReconcileRepository {
+	return &reconcileRepository{
+		db: db,
+		q:  query.Use(db),
+	}
+}
+
+func (r *reconcileRepository) LoadState(ctx context.Context, chainID int64, contractAddress string, blockNumber int64) (*ReconcileState, error) {
+	state := &ReconcileState{BlockNumber: blockNumber, PoolStaked: make(map[int64]decimal.Int)}
+	err := r.q.Transaction(func(tx *query.Query) error {
+		pools, err := tx.StakingPool.WithContext(ctx).Where(
+			tx.StakingPool.ChainID.Eq(chainID),
+			tx.StakingPool.ContractAddress.Eq(contractAddress),
+		).Order(tx.StakingPool.PoolID).Find()
+		if err != nil {
+			return err
+		}
+		state.Pools = pools
+
+		positions, err := tx.StakingUserPosition.WithContext(ctx).Where(
+			tx.StakingUserPosition.ChainID.Eq(chainID),
+			tx.StakingUserPosition.ContractAddress.Eq(contractAddress),
+		).Order(tx.StakingUserPosition.PoolID, tx.StakingUserPosition.UserAddress).Find()
+		if err != nil {
+			return err
+		}
+
+		// 1. 持仓已计入对账区块之后的事件，需要扣除
+		above, err := sumEventsAbove(ctx, tx, chainID, contractAddress, blockNumber, nil)
+		if err != nil {
+			return err
+		}
+
+		// 2. 已确认事件汇总出赎回中的数量和质押池总量
+		e := tx.StakingEvent
+		var sums []eventTypeSum
+		if err := e.WithContext(ctx).Select(e.PoolID, e.UserAddress, e.EventType, e.Amount.Sum().As("amount")).Where(
+			e.ChainID.Eq(chainID),
+			e.ContractAddress.Eq(contractAddress),
+			e.BlockNumber.Lte(blockNumber),
+			e.ConfirmationStatus.Eq(ConfirmationStatusConfirmed),
+		).Group(e.PoolID, e.UserAddress, e.EventType).Scan(&sums); err != nil {
+			return err
+		}
+		pending := make(map[userPool]decimal.Int)
+		for _, sum := range sums {
+			key := userPool{poolID: sum.PoolID, user: sum.UserAddress}
+			switch sum.EventType {
+			case "Deposit":
+				state.PoolStaked[sum.PoolID] = state.PoolStaked[sum.PoolID].Add(sum.Amount)
+			case "RequestUnstake":
+				state.PoolStaked[sum.PoolID] = state.PoolStaked[sum.PoolID].Sub(sum.Amount)
+				pending[key] = pending[key].Add(sum.Amount)
+			case "Withdraw":
+				pending[key] = pending[key].Sub(sum.Amount)
+			}
+		}
+
+		state.Positions = make([]*IndexedPosition, 0, len(positions))
+		for _, pos := range positions {
+			key := userPool{poolID: pos.PoolID, user: pos.UserAddress}
+			state.Positions = append(state.Positions, &IndexedPosition{
+				PoolID:         pos.PoolID,
+				UserAddress:    pos.UserAddress,
+				StakedAmount:   decimal.Deref(pos.StakedAmount).Sub(above[key]),
+				PendingUnstake: pending[key],
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (r *reconcileRepository) SaveDrifts(ctx context.Context, drifts []*model.ReconcileDrift) error {
+	if len(drifts) == 0 {
+		return nil
+	}
+	return r.q.ReconcileDrift.WithContext(ctx).CreateInBatches(drifts, 500)
+}
+
+func (r *reconcileRepository) RepairPosition(ctx context.Context, chainID int64, contractAddress string, poolID int64, userAddress string,
+	blockNumber int64, stakedAmount decimal.Int) error {
+	return r.q.Transaction(func(tx *query.Query) error {
+		// 锁定持仓，避免与扫描器同时写入
+		p := tx.StakingUserPosition
+		conds := []gen.Condition{
+			p.ChainID.Eq(chainID),
+			p.ContractAddress.Eq(contractAddress),
+			p.PoolID.Eq(poolID),
+			p.UserAddress.Eq(userAddress),
+		}
+		pos, err := p.WithContext(ctx).Where(conds...).Clauses(clause.Locking{Strength: "UPDATE"}).First()
+		if err != nil {
+			return err
+		}
+		key := userPool{poolID: poolID, user: userAddress}
+		above, err := sumEventsAbove(ctx, tx, chainID, contractAddress, blockNumber, &key)
+		if err != nil {
+			return err
+		}
+		repaired := stakedAmount.Add(above[key])
+		if _, err := p.WithContext(ctx).Where(conds...).Update(p.StakedAmount, repaired); err != nil {
+			return err
+		}
+
+		// 修复可能改变质押人数，质押数量由事件汇总，不做调整
+		before := activeStake(pos)
+		pos.StakedAmount = &repaired
+		ev := &model.StakingEvent{ChainID: chainID, ContractAddress: contractAddress, PoolID: poolID}
+		return applyPoolTotals(ctx, tx, ev, newPoolTotalsDelta(ev, before, activeStake(pos)), false)
+	})
+}
+
+func (r *reconcileRepository) RepairPool(ctx context.Context, pool *model.StakingPool) error {
+	return r.q.StakingPool.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chain_id"}, {Name: "contract_address"}, {Name: "pool_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"st_token_address", "pool_weight", "min_deposit_amount", "unstake_locked_blocks", "updated_at"}),
+	}).Create(pool)
+}
+
+// userPool 用户在某个质押池的持仓
+type userPool struct {
+	poolID int64
+	user   string
+}
+
+// sumEventsAbove 汇总 blockNumber 之后已计入持仓的事件对质押数量的影响，only 非空时只统计该用户
+func sumEventsAbove(ctx context.Context, tx *query.Query, chainID int64, contractAddress string, blockNumber int64, only *userPool) (map[userPool]decimal.Int, error) {
+	e := tx.StakingEvent
+	do := e.WithContext(ctx).Select(e.PoolID, e.UserAddress, e.EventType, e.Amount.Sum().As("amount")).Where(
+		e.ChainID.Eq(chainID),
+		e.ContractAddress.Eq(contractAddress),
+		e.BlockNumber.Gt(blockNumber),
+		e.ConfirmationStatus.Neq(ConfirmationStatusOrphaned),
+	)
+	if only != nil {
+		do = do.Where(e.PoolID.Eq(only.poolID), e.UserAddress.Eq(only.user))
+	}
+	var sums []eventTypeSum
+	if err := do.Group(e.PoolID, e.UserAddress, e.EventType).Scan(&sums); err != nil {
+		return nil, err
+	}
+	deltas := make(map[userPool]decimal.Int, len(sums))
+	for _, sum := range sums {
+		key := userPool{poolID: sum.PoolID, user: sum.UserAddress}
+		deltas[key] = deltas[key].Add(stakedAmountDelta(&model.StakingEvent{EventType: sum.EventType, Amount: sum.Amount}))
+	}
+	return deltas, nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/gen/query"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RewardRepository interface {
+	GetCursor(ctx context.Context, chainID int64, contractAddress string) (*model.ChainScanCursor, error)
+
+	// GetRewardParams 合约的奖励参数，未同步时返回 nil
+	GetRewardParams(ctx context.Context, chainID int64, contractAddress string) (*model.StakingRewardParam, error)
+
+	// SaveRewardParams 写入奖励参数，已存在时保持不变
+	SaveRewardParams(ctx context.Context, params *model.StakingRewardParam) error
+
+	// ListRawLogsAfterPosition 按 (block_number, log_index) 顺序返回 after 之后的归档日志
+	ListRawLogsAfterPosition(ctx context.Context, filter RawLogFilter, after EventPosition) ([]*model.RawLog, error)
+
+	// CountRawLogs 合约在 through（含）之前的归档日志数量，用于发现补录或回滚
+	CountRawLogs(ctx context.Context, chainID int64, contractAddress string, through EventPosition) (int64, error)
+
+	// RawLogExists 归档日志是否仍然存在，回滚后重新写入的日志 id 会变化
+	RawLogExists(ctx context.Context, id int64) (bool, error)
+}
+
+type rewardRepository struct {
+	db *gorm.DB
+	q  *query.Query
+}
+
+func NewRewardRepository(db *gorm.DB) RewardRepository {
+	return &rewardRepository{
+		db: db,
+		q:  query.Use(db),
+	}
+}
+
+func (r *rewardRepository) GetCursor(ctx context.Context, chainID int64, contractAddress string) (*model.ChainScanCursor, error) {
+	return r.q.ChainScanCursor.WithContext(ctx).Where(
+		r.q.ChainScanCursor.ChainID.Eq(chainID),
+		r.q.ChainScanCursor.ContractAddress.Eq(contractAddress),
+	).First()
+}
+
+func (r *rewardRepository) GetRewardParams(ctx context.Context, chainID int64, contractAddress string) (*model.StakingRewardParam, error) {
+	p := r.q.StakingRewardParam
+	params, err := p.WithContext(ctx).Where(
+		p.ChainID.Eq(chainID),
+		p.ContractAddress.Eq(contractAddress),
+	).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return params, err
+}
+
+func (r *rewardRepository) SaveRewardParams(ctx context.Context, params *model.StakingRewardParam) error {
+	return r.q.StakingRewardParam.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(params)
+}
+
+func (r *rewardRepository) ListRawLogsAfterPosition(ctx context.Context, filter RawLogFilter, after EventPosition) ([]*model.RawLog, error) {
+	return listRawLogsAfterPosition(ctx, r.q, filter, after)
+}
+
+func (r *rewardRepository) CountRawLogs(ctx context.Context, chainID int64, contractAddress string, through EventPosition) (int64, error) {
+	l := r.q.RawLog
+	return l.WithContext(ctx).Where(
+		l.ChainID.Eq(chainID),
+		l.ContractAddress.Eq(contractAddress),
+		field.Or(
+			l.BlockNumber.Lt(through.BlockNumber),
+			field.And(l.BlockNumber.Eq(through.BlockNumber), l.LogIndex.Lte(through.LogIndex)),
+		),
+	).Count()
+}
+
+func (r *rewardRepository) RawLogExists(ctx context.Context, id int64) (bool, error) {
+	count, err := r.q.RawLog.WithContext(ctx).Where(r.q.RawLog.ID.Eq(id)).Count()
+	return count > 0, err
+}
@@ -2,29 +2,116 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/dijiacoder/staking-indexer/internal/decimal"
 	"github.com/dijiacoder/staking-indexer/internal/gen/model"
 	"github.com/dijiacoder/staking-indexer/internal/gen/query"
 	"github.com/dijiacoder/staking-indexer/internal/logger"
 	"go.uber.org/zap"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+// chain_scan_cursor.scan_status 取值
+const (
+	ScanStatusNormal      int32 = 1 // 正常
+	ScanStatusRollingBack int32 = 2 // 回滚中
+	ScanStatusPaused      int32 = 3 // 暂停
+)
+
+// ScanStatusName 返回 scan_status 的名称：normal / rolling_back / paused
+func ScanStatusName(status *int32) string {
+	if status == nil {
+		return "normal"
+	}
+	switch *status {
+	case ScanStatusNormal:
+		return "normal"
+	case ScanStatusRollingBack:
+		return "rolling_back"
+	case ScanStatusPaused:
+		return "paused"
+	}
+	return fmt.Sprintf("unknown(%d)", *status)
+}
+
+var (
+	// ErrCursorNotPaused 回退游标前需要先暂停合约扫描
+	ErrCursorNotPaused = errors.New("cursor is not paused")
+	// ErrInvalidRewind 回退的目标区块不在 [0, last_scanned_block) 范围内
+	ErrInvalidRewind = errors.New("invalid rewind block")
+)
+
+// staking_events.confirmation_status 取值
+const (
+	ConfirmationStatusPending   = "pending"
+	ConfirmationStatusConfirmed = "confirmed"
+	ConfirmationStatusOrphaned  = "orphaned"
+)
+
 type ScannerRepository interface {
+	// UpdateCursor 推进游标，暂停中的游标不更新，避免覆盖暂停期间的人工回退
 	UpdateCursor(ctx context.Context, chainID int64, contractAddress string, lastScanned int64, lastConfirmed int64) error
 
+	UpdateScanStatus(ctx context.Context, chainID int64, contractAddress string, status int32) error
+
 	GetBlockByNumber(ctx context.Context, chainID int64, blockNumber int64) (*model.ChainBlock, error)
 
+	GetBlocksInRange(ctx context.Context, chainID int64, fromBlock int64, toBlock int64) ([]*model.ChainBlock, error)
+
+	// GetBlocksByNumbers 查询指定区块号的区块头，没有索引的区块不返回
+	GetBlocksByNumbers(ctx context.Context, chainID int64, blockNumbers []int64) ([]*model.ChainBlock, error)
+
 	SaveBlock(ctx context.Context, block *model.ChainBlock) error
 
 	SaveEventsAndProcessPositions(ctx context.Context, events []*model.StakingEvent) error
 
-	HandleReorg(ctx context.Context, chainID int64, contractAddress string, rollbackToBlock int64) error
+	HandleReorg(ctx context.Context, chainID int64, contractAddresses []string, rollbackToBlock int64) error
+
+	// RewindCursor 把暂停中的合约游标回退到 toBlock 并回滚之后的派生数据
+	RewindCursor(ctx context.Context, chainID int64, contractAddress string, toBlock int64) error
+
+	// SetCursor 直接设置暂停中的合约游标，不修改已索引的数据
+	SetCursor(ctx context.Context, chainID int64, contractAddress string, lastScanned int64, lastConfirmed int64) error
+
+	// ResetProjections 清空暂停中合约的用户持仓、持仓快照与质押池，用于从 staking_events 与归档日志重建
+	ResetProjections(ctx context.Context, chainID int64, contractAddress string) error
+
+	// ConfirmBlocks 将 confirmedBlock 及之前的 pending 区块和事件提升为 confirmed，返回被提升的事件。
+	// 只处理正常状态的游标，暂停或回滚中的合约不提升
+	ConfirmBlocks(ctx context.Context, chainID int64, contractAddress string, confirmedBlock int64) ([]*model.StakingEvent, error)
+
+	// GetEventsByBlock 查询合约在指定区块内的有效事件，按 log_index 排序
+	GetEventsByBlock(ctx context.Context, chainID int64, contractAddresses []string, blockNumber int64) ([]*model.StakingEvent, error)
 
 	GetCursor(ctx context.Context, chainID int64, contractAddress string) (*model.ChainScanCursor, error)
 
+	EnsureCursor(ctx context.Context, cursor *model.ChainScanCursor) (*model.ChainScanCursor, error)
+
 	SavePool(ctx context.Context, pool *model.StakingPool) error
+
+	// UpdatePool 按列名更新质押池参数，质押池不存在时不做修改
+	UpdatePool(ctx context.Context, chainID int64, contractAddress string, poolID int64, columns map[string]interface{}) error
+
+	// ListPools 查询合约的全部质押池，按 pool_id 排序
+	ListPools(ctx context.Context, chainID int64, contractAddress string) ([]*model.StakingPool, error)
+
+	// SnapshotPositions 生成 toBlock 的持仓快照并推进 last_snapshot_block，返回生成的快照数量
+	SnapshotPositions(ctx context.Context, chainID int64, contractAddress string, toBlock int64) (int, error)
+
+	// SaveRawLogs 归档合约的原始日志
+	SaveRawLogs(ctx context.Context, logs []*model.RawLog) error
+
+	// ListRawLogsAfterPosition 按链上顺序分页读取归档日志
+	ListRawLogsAfterPosition(ctx context.Context, filter RawLogFilter, after EventPosition) ([]*model.RawLog, error)
+
+	// DiscardSnapshots 删除 afterBlock 之后的持仓快照，补录历史事件后由扫描器重新生成
+	DiscardSnapshots(ctx context.Context, chainID int64, contractAddress string, afterBlock int64) error
 }
 
 type scannerRepository struct {
@@ -61,6 +148,24 @@ func (r *scannerRepository) SavePool(ctx context.Context, pool *model.StakingPoo
 	return r.q.StakingPool.WithContext(ctx).Create(pool)
 }
 
+func (r *scannerRepository) UpdatePool(ctx context.Context, chainID int64, contractAddress string, poolID int64, columns map[string]interface{}) error {
+	p := r.q.StakingPool
+	_, err := p.WithContext(ctx).Where(
+		p.ChainID.Eq(chainID),
+		p.ContractAddress.Eq(contractAddress),
+		p.PoolID.Eq(poolID),
+	).Updates(columns)
+	return err
+}
+
+func (r *scannerRepository) ListPools(ctx context.Context, chainID int64, contractAddress string) ([]*model.StakingPool, error) {
+	p := r.q.StakingPool
+	return p.WithContext(ctx).Where(
+		p.ChainID.Eq(chainID),
+		p.ContractAddress.Eq(contractAddress),
+	).Order(p.PoolID).Find()
+}
+
 func (r *scannerRepository) GetCursor(ctx context.Context, chainID int64, contractAddress string) (*model.ChainScanCursor, error) {
 	return r.q.ChainScanCursor.WithContext(ctx).Where(
 		r.q.ChainScanCursor.ChainID.Eq(chainID),
@@ -68,10 +173,23 @@ func (r *scannerRepository) GetCursor(ctx context.Context, chainID int64, contra
 	).First()
 }
 
+// EnsureCursor 返回合约游标，不存在时按传入的初始值创建
+func (r *scannerRepository) EnsureCursor(ctx context.Context, cursor *model.ChainScanCursor) (*model.ChainScanCursor, error) {
+	return r.q.ChainScanCursor.WithContext(ctx).Where(
+		r.q.ChainScanCursor.ChainID.Eq(cursor.ChainID),
+		r.q.ChainScanCursor.ContractAddress.Eq(cursor.ContractAddress),
+	).Attrs(
+		r.q.ChainScanCursor.ContractName.Value(cursor.ContractName),
+		r.q.ChainScanCursor.LastScannedBlock.Value(cursor.LastScannedBlock),
+		r.q.ChainScanCursor.LastConfirmedBlock.Value(cursor.LastConfirmedBlock),
+	).FirstOrCreate()
+}
+
 func (r *scannerRepository) UpdateCursor(ctx context.Context, chainID int64, contractAddress string, lastScanned int64, lastConfirmed int64) error {
 	_, err := r.q.ChainScanCursor.WithContext(ctx).Where(
 		r.q.ChainScanCursor.ChainID.Eq(chainID),
 		r.q.ChainScanCursor.ContractAddress.Eq(contractAddress),
+		r.q.ChainScanCursor.ScanStatus.Neq(ScanStatusPaused),
 	).Updates(&model.ChainScanCursor{
 		LastScannedBlock:   lastScanned,
 		LastConfirmedBlock: lastConfirmed,
@@ -79,6 +197,14 @@ func (r *scannerRepository) UpdateCursor(ctx context.Context, chainID int64, con
 	return err
 }
 
+func (r *scannerRepository) UpdateScanStatus(ctx context.Context, chainID int64, contractAddress string, status int32) error {
+	_, err := r.q.ChainScanCursor.WithContext(ctx).Where(
+		r.q.ChainScanCursor.ChainID.Eq(chainID),
+		r.q.ChainScanCursor.ContractAddress.Eq(contractAddress),
+	).Update(r.q.ChainScanCursor.ScanStatus, status)
+	return err
+}
+
 func (r *scannerRepository) GetBlockByNumber(ctx context.Context, chainID int64, blockNumber int64) (*model.ChainBlock, error) {
 	return r.q.ChainBlock.WithContext(ctx).Where(
 		r.q.ChainBlock.ChainID.Eq(chainID),
@@ -86,10 +212,27 @@ func (r *scannerRepository) GetBlockByNumber(ctx context.Context, chainID int64,
 	).First()
 }
 
+func (r *scannerRepository) GetBlocksInRange(ctx context.Context, chainID int64, fromBlock int64, toBlock int64) ([]*model.ChainBlock, error) {
+	return r.q.ChainBlock.WithContext(ctx).Where(
+		r.q.ChainBlock.ChainID.Eq(chainID),
+		r.q.ChainBlock.BlockNumber.Between(fromBlock, toBlock),
+	).Order(r.q.ChainBlock.BlockNumber).Find()
+}
+
+func (r *scannerRepository) GetBlocksByNumbers(ctx context.Context, chainID int64, blockNumbers []int64) ([]*model.ChainBlock, error) {
+	if len(blockNumbers) == 0 {
+		return nil, nil
+	}
+	return r.q.ChainBlock.WithContext(ctx).Where(
+		r.q.ChainBlock.ChainID.Eq(chainID),
+		r.q.ChainBlock.BlockNumber.In(blockNumbers...),
+	).Find()
+}
+
 func (r *scannerRepository) SaveBlock(ctx context.Context, block *model.ChainBlock) error {
 	return r.q.ChainBlock.WithContext(ctx).Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "chain_id"}, {Name: "block_number"}},
-		DoUpdates: clause.AssignmentColumns([]string{"block_hash", "parent_hash", "is_confirmed"}),
+		DoUpdates: clause.AssignmentColumns([]string{"block_hash", "parent_hash", "is_confirmed", "block_time"}),
 	}).Create(block)
 }
 
@@ -99,122 +242,408 @@ func (r *scannerRepository) SaveEventsAndProcessPositions(ctx context.Context, e
 	}
 
 	return r.q.Transaction(func(tx *query.Query) error {
-		// 1. Save Events
+		// 1. Skip events already applied to positions (block re-processed, pending promoted)
+		applied := make(map[*model.StakingEvent]bool, len(events))
+		// 新写入或状态变化的事件写入发件箱，重复处理的区块不会产生重复消息
+		changed := make([]*model.StakingEvent, 0, len(events))
+		for _, ev := range events {
+			existing, err := tx.StakingEvent.WithContext(ctx).Where(
+				tx.StakingEvent.TxHash.Eq(ev.TxHash),
+				tx.StakingEvent.LogIndex.Eq(ev.LogIndex),
+			).Limit(1).Find()
+			if err != nil {
+				return err
+			}
+			if len(existing) > 0 && isActiveEvent(existing[0]) {
+				applied[ev] = true
+			}
+			if ev.ConfirmationStatus == nil {
+				status := ConfirmationStatusConfirmed
+				ev.ConfirmationStatus = &status
+			}
+			if len(existing) == 0 || existing[0].ConfirmationStatus == nil ||
+				*existing[0].ConfirmationStatus != *ev.ConfirmationStatus ||
+				existing[0].BlockNumber != ev.BlockNumber {
+				changed = append(changed, ev)
+			}
+		}
+
+		// 2. Save Events
 		if err := tx.StakingEvent.WithContext(ctx).Clauses(clause.OnConflict{
 			Columns:   []clause.Column{{Name: "tx_hash"}, {Name: "log_index"}},
-			DoUpdates: clause.AssignmentColumns([]string{"amount", "event_type", "block_number"}),
+			DoUpdates: clause.AssignmentColumns([]string{"amount", "event_type", "block_number", "block_time", "confirmation_status"}),
 		}).Create(events...); err != nil {
 			return err
 		}
 
-		// 2. Update Positions
+		// 3. Update Positions
+		toApply := make([]*model.StakingEvent, 0, len(events))
 		for _, ev := range events {
-			pos, err := tx.StakingUserPosition.WithContext(ctx).Where(
-				tx.StakingUserPosition.ChainID.Eq(ev.ChainID),
-				tx.StakingUserPosition.ContractAddress.Eq(ev.ContractAddress),
-				tx.StakingUserPosition.PoolID.Eq(ev.PoolID),
-				tx.StakingUserPosition.UserAddress.Eq(ev.UserAddress),
-			).FirstOrInit()
-			if err != nil {
-				return err
+			if !applied[ev] {
+				toApply = append(toApply, ev)
 			}
+		}
+		if err := applyPositions(ctx, tx, toApply); err != nil {
+			return err
+		}
 
-			// Initialize fields if new
-			if pos.StakedAmount == nil {
-				zero := 0.0
-				pos.StakedAmount = &zero
-			}
-			if pos.RewardDebt == nil {
-				zero := 0.0
-				pos.RewardDebt = &zero
-			}
-			pos.ChainID = ev.ChainID
-			pos.ContractAddress = ev.ContractAddress
-			pos.PoolID = ev.PoolID
-			pos.UserAddress = ev.UserAddress
-
-			switch ev.EventType {
-			case "Deposit":
-				*pos.StakedAmount += ev.Amount
-			case "Withdraw":
-				*pos.StakedAmount -= ev.Amount
-			case "Claim":
-				// Handle claim logic if needed, for now just update reward debt if it was relevant
-				// Usually Claim doesn't change StakedAmount
-			}
+		// 4. Write outbox
+		return writeOutboxEvents(ctx, tx, changed)
+	})
+}
+
+// applyPositions 把事件对质押数量的影响计入用户持仓
+func applyPositions(ctx context.Context, tx *query.Query, events []*model.StakingEvent) error {
+	for _, ev := range events {
+		pos, err := tx.StakingUserPosition.WithContext(ctx).Where(
+			tx.StakingUserPosition.ChainID.Eq(ev.ChainID),
+			tx.StakingUserPosition.ContractAddress.Eq(ev.ContractAddress),
+			tx.StakingUserPosition.PoolID.Eq(ev.PoolID),
+			tx.StakingUserPosition.UserAddress.Eq(ev.UserAddress),
+		).FirstOrInit()
+		if err != nil {
+			return err
+		}
 
-			if err := tx.StakingUserPosition.WithContext(ctx).Clauses(clause.OnConflict{
-				Columns:   []clause.Column{{Name: "chain_id"}, {Name: "contract_address"}, {Name: "pool_id"}, {Name: "user_address"}},
-				DoUpdates: clause.AssignmentColumns([]string{"staked_amount", "reward_debt", "updated_at"}),
-			}).Create(pos); err != nil {
+		// Initialize fields if new
+		if pos.StakedAmount == nil {
+			pos.StakedAmount = decimal.Ptr(decimal.Zero)
+		}
+		if pos.PendingUnstake == nil {
+			pos.PendingUnstake = decimal.Ptr(decimal.Zero)
+		}
+		if pos.RewardDebt == nil {
+			pos.RewardDebt = decimal.Ptr(decimal.Zero)
+		}
+		if pos.TotalClaimed == nil {
+			pos.TotalClaimed = decimal.Ptr(decimal.Zero)
+		}
+		pos.ChainID = ev.ChainID
+		pos.ContractAddress = ev.ContractAddress
+		pos.PoolID = ev.PoolID
+		pos.UserAddress = ev.UserAddress
+
+		// Claim doesn't change StakedAmount
+		d := applyPositionEvent(pos, ev, 1)
+
+		if err := tx.StakingUserPosition.WithContext(ctx).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "chain_id"}, {Name: "contract_address"}, {Name: "pool_id"}, {Name: "user_address"}},
+			DoUpdates: clause.AssignmentColumns([]string{"staked_amount", "pending_unstake", "reward_debt", "total_claimed", "updated_at"}),
+		}).Create(pos); err != nil {
+			return err
+		}
+
+		if err := applyPoolTotals(ctx, tx, ev, d, true); err != nil {
+			return err
+		}
+		if ev.EventType == "Claim" {
+			if err := applyClaim(ctx, tx, ev, 1); err != nil {
 				return err
 			}
 		}
+	}
+	return nil
+}
+
+// HandleReorg 在一个事务内回滚 rollbackToBlock 之后的数据，事务开始时锁定游标；
+// 事务失败时不留下任何修改，下一轮扫描重新检测。scan_status = 2 只在回滚深度超限时由扫描器写入。
+// 有事件作废的合约在同一事务内写入 retract 发件箱记录，撤销已发布的事件
+func (r *scannerRepository) HandleReorg(ctx context.Context, chainID int64, contractAddresses []string, rollbackToBlock int64) error {
+	return r.q.Transaction(func(tx *query.Query) error {
+		if _, err := tx.ChainScanCursor.WithContext(ctx).Where(
+			tx.ChainScanCursor.ChainID.Eq(chainID),
+			tx.ChainScanCursor.ContractAddress.In(contractAddresses...),
+		).Clauses(clause.Locking{Strength: "UPDATE"}).Find(); err != nil {
+			return err
+		}
+
+		if err := rollbackContracts(ctx, tx, chainID, contractAddresses, rollbackToBlock); err != nil {
+			return err
+		}
+
+		// Mark blocks as non-canonical (IsConfirmed = 0)
+		if _, err := tx.ChainBlock.WithContext(ctx).Where(
+			tx.ChainBlock.ChainID.Eq(chainID),
+			tx.ChainBlock.BlockNumber.Gt(rollbackToBlock),
+		).Update(tx.ChainBlock.IsConfirmed, 0); err != nil {
+			return err
+		}
+
+		// Record the reorg for status reporting
+		if _, err := tx.ChainScanCursor.WithContext(ctx).Where(
+			tx.ChainScanCursor.ChainID.Eq(chainID),
+			tx.ChainScanCursor.ContractAddress.In(contractAddresses...),
+		).Updates(map[string]interface{}{
+			"last_reorg_block": rollbackToBlock,
+			"last_reorg_at":    time.Now(),
+		}); err != nil {
+			return err
+		}
+
 		return nil
 	})
 }
 
-func (r *scannerRepository) HandleReorg(ctx context.Context, chainID int64, contractAddress string, rollbackToBlock int64) error {
+// RewindCursor 把暂停中的合约游标回退到 toBlock，回滚之后的事件、持仓与快照，游标保持暂停。
+// 区块头由整条链共享，不做修改
+func (r *scannerRepository) RewindCursor(ctx context.Context, chainID int64, contractAddress string, toBlock int64) error {
 	return r.q.Transaction(func(tx *query.Query) error {
-		// 1. Find events to rollback
-		events, err := tx.StakingEvent.WithContext(ctx).Where(
-			tx.StakingEvent.ChainID.Eq(chainID),
-			tx.StakingEvent.ContractAddress.Eq(contractAddress),
-			tx.StakingEvent.BlockNumber.Gt(rollbackToBlock),
-		).Find()
+		cursor, err := tx.ChainScanCursor.WithContext(ctx).Where(
+			tx.ChainScanCursor.ChainID.Eq(chainID),
+			tx.ChainScanCursor.ContractAddress.Eq(contractAddress),
+		).Clauses(clause.Locking{Strength: "UPDATE"}).First()
 		if err != nil {
 			return err
 		}
+		if cursor.ScanStatus == nil || *cursor.ScanStatus != ScanStatusPaused {
+			return ErrCursorNotPaused
+		}
+		if toBlock < 0 || toBlock >= cursor.LastScannedBlock {
+			return fmt.Errorf("%w: rewind to %d, last scanned %d", ErrInvalidRewind, toBlock, cursor.LastScannedBlock)
+		}
+		return rollbackContracts(ctx, tx, chainID, []string{contractAddress}, toBlock)
+	})
+}
 
-		// 2. Reverse positions
-		for _, ev := range events {
-			pos, err := tx.StakingUserPosition.WithContext(ctx).Where(
-				tx.StakingUserPosition.ChainID.Eq(ev.ChainID),
-				tx.StakingUserPosition.ContractAddress.Eq(ev.ContractAddress),
-				tx.StakingUserPosition.PoolID.Eq(ev.PoolID),
-				tx.StakingUserPosition.UserAddress.Eq(ev.UserAddress),
-			).First()
-			if err != nil {
-				continue // If position not found, maybe it was already corrected?
-			}
+func (r *scannerRepository) SetCursor(ctx context.Context, chainID int64, contractAddress string, lastScanned int64, lastConfirmed int64) error {
+	return r.q.Transaction(func(tx *query.Query) error {
+		c := tx.ChainScanCursor
+		cursor, err := c.WithContext(ctx).Where(
+			c.ChainID.Eq(chainID),
+			c.ContractAddress.Eq(contractAddress),
+		).Clauses(clause.Locking{Strength: "UPDATE"}).First()
+		if err != nil {
+			return err
+		}
+		if cursor.ScanStatus == nil || *cursor.ScanStatus != ScanStatusPaused {
+			return ErrCursorNotPaused
+		}
+		_, err = c.WithContext(ctx).Where(c.ID.Eq(cursor.ID)).Updates(map[string]interface{}{
+			"last_scanned_block":   lastScanned,
+			"last_confirmed_block": lastConfirmed,
+		})
+		return err
+	})
+}
 
-			switch ev.EventType {
-			case "Deposit":
-				*pos.StakedAmount -= ev.Amount
-			case "Withdraw":
-				*pos.StakedAmount += ev.Amount
-			}
+// rollbackContracts 回滚合约在 rollbackToBlock 之后的事件、持仓、快照、归档日志和游标，并写入回滚消息
+func rollbackContracts(ctx context.Context, tx *query.Query, chainID int64, contractAddresses []string, rollbackToBlock int64) error {
+	// 1. Find events to rollback
+	events, err := tx.StakingEvent.WithContext(ctx).Where(
+		tx.StakingEvent.ChainID.Eq(chainID),
+		tx.StakingEvent.ContractAddress.In(contractAddresses...),
+		tx.StakingEvent.BlockNumber.Gt(rollbackToBlock),
+		tx.StakingEvent.ConfirmationStatus.Neq(ConfirmationStatusOrphaned),
+	).Find()
+	if err != nil {
+		return err
+	}
+
+	// 2. Reverse positions
+	for _, ev := range events {
+		pos, err := tx.StakingUserPosition.WithContext(ctx).Where(
+			tx.StakingUserPosition.ChainID.Eq(ev.ChainID),
+			tx.StakingUserPosition.ContractAddress.Eq(ev.ContractAddress),
+			tx.StakingUserPosition.PoolID.Eq(ev.PoolID),
+			tx.StakingUserPosition.UserAddress.Eq(ev.UserAddress),
+		).First()
+		if err != nil {
+			continue // If position not found, maybe it was already corrected?
+		}
+
+		d := applyPositionEvent(pos, ev, -1)
+
+		if err := tx.StakingUserPosition.WithContext(ctx).Save(pos); err != nil {
+			return err
+		}
 
-			if err := tx.StakingUserPosition.WithContext(ctx).Save(pos); err != nil {
+		// 撤销事件对质押池总量的影响，历史记录在下面按区块删除
+		if err := applyPoolTotals(ctx, tx, ev, d, false); err != nil {
+			return err
+		}
+	}
+
+	// 2.1 Reverse pool and daily claimed totals
+	for _, ev := range events {
+		if ev.EventType == "Claim" {
+			if err := applyClaim(ctx, tx, ev, -1); err != nil {
 				return err
 			}
 		}
+	}
+	if err := discardPoolHistory(ctx, tx, chainID, contractAddresses, rollbackToBlock); err != nil {
+		return err
+	}
+
+	// 3. Mark events as orphaned, they are revived by the upsert if the new fork includes them
+	if _, err := tx.StakingEvent.WithContext(ctx).Where(
+		tx.StakingEvent.ChainID.Eq(chainID),
+		tx.StakingEvent.ContractAddress.In(contractAddresses...),
+		tx.StakingEvent.BlockNumber.Gt(rollbackToBlock),
+	).Update(tx.StakingEvent.ConfirmationStatus, ConfirmationStatusOrphaned); err != nil {
+		return err
+	}
 
-		// 3. Delete events
-		if _, err := tx.StakingEvent.WithContext(ctx).Where(
+	// 4. Drop position snapshots after the rollback point
+	if err := discardSnapshots(ctx, tx, chainID, contractAddresses, rollbackToBlock); err != nil {
+		return err
+	}
+
+	// 5. Drop archived logs after the rollback point
+	if err := discardRawLogs(ctx, tx, chainID, contractAddresses, rollbackToBlock); err != nil {
+		return err
+	}
+
+	// 6. Update cursor
+	if _, err := tx.ChainScanCursor.WithContext(ctx).Where(
+		tx.ChainScanCursor.ChainID.Eq(chainID),
+		tx.ChainScanCursor.ContractAddress.In(contractAddresses...),
+		tx.ChainScanCursor.LastScannedBlock.Gt(rollbackToBlock),
+	).Update(tx.ChainScanCursor.LastScannedBlock, rollbackToBlock); err != nil {
+		return err
+	}
+	if _, err := tx.ChainScanCursor.WithContext(ctx).Where(
+		tx.ChainScanCursor.ChainID.Eq(chainID),
+		tx.ChainScanCursor.ContractAddress.In(contractAddresses...),
+		tx.ChainScanCursor.LastConfirmedBlock.Gt(rollbackToBlock),
+	).Update(tx.ChainScanCursor.LastConfirmedBlock, rollbackToBlock); err != nil {
+		return err
+	}
+
+	// 7. Write compensating outbox entries
+	retracted := make(map[string]bool)
+	var entries []*model.EventOutbox
+	for _, ev := range events {
+		if retracted[ev.ContractAddress] {
+			continue
+		}
+		retracted[ev.ContractAddress] = true
+		entries = append(entries, &model.EventOutbox{
+			ChainID:         chainID,
+			ContractAddress: ev.ContractAddress,
+			Kind:            OutboxKindRetract,
+			BlockNumber:     rollbackToBlock,
+		})
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	return tx.EventOutbox.WithContext(ctx).Create(entries...)
+}
+
+func (r *scannerRepository) ConfirmBlocks(ctx context.Context, chainID int64, contractAddress string, confirmedBlock int64) ([]*model.StakingEvent, error) {
+	var promoted []*model.StakingEvent
+	err := r.q.Transaction(func(tx *query.Query) error {
+		// 0. Lock the cursor, promotion only runs while it is scanning normally
+		cursors, err := tx.ChainScanCursor.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where(
+			tx.ChainScanCursor.ChainID.Eq(chainID),
+			tx.ChainScanCursor.ContractAddress.Eq(contractAddress),
+			tx.ChainScanCursor.ScanStatus.Eq(ScanStatusNormal),
+		).Find()
+		if err != nil || len(cursors) == 0 {
+			return err
+		}
+
+		// 1. Promote pending events
+		pendingConds := []gen.Condition{
 			tx.StakingEvent.ChainID.Eq(chainID),
+			tx.StakingEvent.ConfirmationStatus.Eq(ConfirmationStatusPending),
+			tx.StakingEvent.BlockNumber.Lte(confirmedBlock),
 			tx.StakingEvent.ContractAddress.Eq(contractAddress),
-			tx.StakingEvent.BlockNumber.Gt(rollbackToBlock),
-		).Delete(); err != nil {
+		}
+		events, err := tx.StakingEvent.WithContext(ctx).Where(pendingConds...).
+			Order(tx.StakingEvent.BlockNumber, tx.StakingEvent.LogIndex).Find()
+		if err != nil {
 			return err
 		}
+		if len(events) > 0 {
+			if _, err := tx.StakingEvent.WithContext(ctx).Where(pendingConds...).
+				Update(tx.StakingEvent.ConfirmationStatus, ConfirmationStatusConfirmed); err != nil {
+				return err
+			}
+			status := ConfirmationStatusConfirmed
+			for _, ev := range events {
+				ev.ConfirmationStatus = &status
+			}
+			if err := writeOutboxEvents(ctx, tx, events); err != nil {
+				return err
+			}
+		}
 
-		// 4. Mark blocks as non-canonical (IsConfirmed = 0)
+		// 2. Promote pending blocks
 		if _, err := tx.ChainBlock.WithContext(ctx).Where(
 			tx.ChainBlock.ChainID.Eq(chainID),
-			tx.ChainBlock.BlockNumber.Gt(rollbackToBlock),
-		).Update(tx.ChainBlock.IsConfirmed, 0); err != nil {
+			tx.ChainBlock.IsConfirmed.Eq(0),
+			tx.ChainBlock.BlockNumber.Lte(confirmedBlock),
+		).Update(tx.ChainBlock.IsConfirmed, 1); err != nil {
 			return err
 		}
 
-		// 5. Update cursor
+		// 3. Update cursor
 		if _, err := tx.ChainScanCursor.WithContext(ctx).Where(
 			tx.ChainScanCursor.ChainID.Eq(chainID),
 			tx.ChainScanCursor.ContractAddress.Eq(contractAddress),
-		).Update(tx.ChainScanCursor.LastScannedBlock, rollbackToBlock); err != nil {
+			tx.ChainScanCursor.ScanStatus.Eq(ScanStatusNormal),
+		).Update(tx.ChainScanCursor.LastConfirmedBlock, confirmedBlock); err != nil {
 			return err
 		}
 
+		promoted = events
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return promoted, nil
+}
+
+func (r *scannerRepository) GetEventsByBlock(ctx context.Context, chainID int64, contractAddresses []string, blockNumber int64) ([]*model.StakingEvent, error) {
+	e := r.q.StakingEvent
+	return e.WithContext(ctx).Where(
+		e.ChainID.Eq(chainID),
+		e.ContractAddress.In(contractAddresses...),
+		e.BlockNumber.Eq(blockNumber),
+		e.ConfirmationStatus.Neq(ConfirmationStatusOrphaned),
+	).Order(e.LogIndex).Find()
+}
+
+// isActiveEvent 事件是否已计入持仓（pending 或 confirmed）
+func isActiveEvent(ev *model.StakingEvent) bool {
+	return ev.ConfirmationStatus == nil || *ev.ConfirmationStatus != ConfirmationStatusOrphaned
+}
+
+// stakedAmountDelta 事件对用户质押数量的影响
+func stakedAmountDelta(ev *model.StakingEvent) decimal.Int {
+	switch ev.EventType {
+	case "Deposit":
+		return ev.Amount
+	case "Withdraw":
+		return ev.Amount.Neg()
+	}
+	return decimal.Zero
+}
+
+// pendingUnstakeDelta 事件对用户已申请赎回未提取数量的影响
+func pendingUnstakeDelta(ev *model.StakingEvent) decimal.Int {
+	switch ev.EventType {
+	case "RequestUnstake":
+		return ev.Amount
+	case "Withdraw":
+		return ev.Amount.Neg()
+	}
+	return decimal.Zero
+}
+
+// claimedAmountDelta 事件对用户累计领取奖励的影响
+func claimedAmountDelta(ev *model.StakingEvent) decimal.Int {
+	if ev.EventType == "Claim" {
+		return ev.Amount
+	}
+	return decimal.Zero
+}
+
+// addAmount 在数据库中精确累加 DECIMAL 列。参数以字符串传入，转换为 DECIMAL 后再相加，
+// 避免 MySQL 把字符串参数按 DOUBLE 参与运算
+func addAmount(column field.Field, amount decimal.Int) field.AssignExpr {
+	return column.SetCol(column.AddCol(field.NewUnsafeFieldRaw("CAST(? AS DECIMAL(65,0))", amount.String())))
 }
@@ -0,0 +1,389 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/gen/query"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+)
+
+// 列表查询默认与最大条数
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+// PoolFilter 质押池查询条件，零值字段不参与过滤
+type PoolFilter struct {
+	ChainID         int64
+	ContractAddress string
+	PoolID          *int64
+	AfterID         int64
+	Limit           int
+}
+
+// EventFilter 事件查询条件，零值字段不参与过滤
+type EventFilter struct {
+	ChainID         int64
+	ContractAddress string
+	UserAddress     string
+	PoolID          *int64
+	EventType       string
+	FromBlock       int64
+	// ToBlock 为 0 时不限制结束区块
+	ToBlock int64
+	// FromTime / ToTime 按区块时间过滤（含两端），没有记录区块时间的事件不会匹配
+	FromTime *time.Time
+	ToTime   *time.Time
+	// IncludePending 为 false 时只返回已确认事件
+	IncludePending bool
+	// AfterID 游标分页：只返回 id 大于该值的事件
+	AfterID int64
+	Limit   int
+}
+
+// PositionFilter 持仓查询条件，零值字段不参与过滤
+type PositionFilter struct {
+	ChainID         int64
+	ContractAddress string
+	UserAddress     string
+	// IncludePending 为 false 时扣除未确认事件对持仓的影响
+	IncludePending bool
+	AfterID        int64
+	Limit          int
+}
+
+// PoolKey 质押池唯一键
+type PoolKey struct {
+	ChainID         int64
+	ContractAddress string
+	PoolID          int64
+}
+
+// EventPosition 事件在链上的位置，同一条链内 (block_number, log_index) 唯一
+type EventPosition struct {
+	BlockNumber int64
+	LogIndex    int32
+}
+
+// BlockKey 区块唯一键
+type BlockKey struct {
+	ChainID     int64
+	BlockNumber int64
+}
+
+// StakingQueryRepository 只读查询，供对外接口使用
+type StakingQueryRepository interface {
+	ListPools(ctx context.Context, filter PoolFilter) ([]*model.StakingPool, error)
+
+	ListEvents(ctx context.Context, filter EventFilter) ([]*model.StakingEvent, error)
+
+	// ListEventsAfterPosition 按 (block_number, log_index) 顺序返回 after 之后的事件，忽略 filter.AfterID
+	ListEventsAfterPosition(ctx context.Context, filter EventFilter, after EventPosition) ([]*model.StakingEvent, error)
+
+	ListUserPositions(ctx context.Context, filter PositionFilter) ([]*model.StakingUserPosition, error)
+
+	// GetPositionsAt 用户在 filter.BlockNumber 时的持仓，从最近的快照开始累加之后的事件
+	GetPositionsAt(ctx context.Context, filter PositionAtFilter) ([]*PositionAt, error)
+
+	// ListPoolHistory 质押池按区块的总量历史，按区块升序
+	ListPoolHistory(ctx context.Context, filter PoolHistoryFilter) ([]*model.StakingPoolHistory, error)
+
+	// ListClaimDaily 按质押池和 UTC 日期汇总的领取奖励
+	ListClaimDaily(ctx context.Context, filter ClaimDailyFilter) ([]*model.StakingClaimDaily, error)
+
+	// GetBlockNumberAt 返回区块时间不晚于 at 的最新区块，at 不在已索引区块范围内时返回 ErrBlockTimeNotIndexed
+	GetBlockNumberAt(ctx context.Context, chainID int64, at time.Time) (int64, error)
+
+	// 以下批量查询供 GraphQL loader 合并请求，避免 N+1
+
+	GetPoolsByKeys(ctx context.Context, keys []PoolKey) ([]*model.StakingPool, error)
+
+	GetBlocksByKeys(ctx context.Context, keys []BlockKey) ([]*model.ChainBlock, error)
+
+	// ListTopPositionsByPools 每个池按质押数量倒序取前 limit 个持仓
+	ListTopPositionsByPools(ctx context.Context, keys []PoolKey, limit int) ([]*model.StakingUserPosition, error)
+
+	// ListRecentEventsByPools 每个池按 id 倒序取最近 limit 个事件
+	ListRecentEventsByPools(ctx context.Context, keys []PoolKey, limit int, includePending bool) ([]*model.StakingEvent, error)
+}
+
+type stakingQueryRepository struct {
+	db *gorm.DB
+	q  *query.Query
+}
+
+func NewStakingQueryRepository(db *gorm.DB) StakingQueryRepository {
+	return &stakingQueryRepository{
+		db: db,
+		q:  query.Use(db),
+	}
+}
+
+func (r *stakingQueryRepository) ListPools(ctx context.Context, filter PoolFilter) ([]*model.StakingPool, error) {
+	p := r.q.StakingPool
+	conds := []gen.Condition{p.ID.Gt(filter.AfterID)}
+	if filter.ChainID != 0 {
+		conds = append(conds, p.ChainID.Eq(filter.ChainID))
+	}
+	if filter.ContractAddress != "" {
+		conds = append(conds, p.ContractAddress.Eq(filter.ContractAddress))
+	}
+	if filter.PoolID != nil {
+		conds = append(conds, p.PoolID.Eq(*filter.PoolID))
+	}
+
+	return p.WithContext(ctx).Where(conds...).Order(p.ID).Limit(PageSize(filter.Limit)).Find()
+}
+
+func (r *stakingQueryRepository) ListEvents(ctx context.Context, filter EventFilter) ([]*model.StakingEvent, error) {
+	e := r.q.StakingEvent
+	conds := append(r.eventConditions(filter), e.ID.Gt(filter.AfterID))
+	return e.WithContext(ctx).Where(conds...).Order(e.ID).Limit(PageSize(filter.Limit)).Find()
+}
+
+func (r *stakingQueryRepository) ListEventsAfterPosition(ctx context.Context, filter EventFilter, after EventPosition) ([]*model.StakingEvent, error) {
+	e := r.q.StakingEvent
+	conds := append(r.eventConditions(filter), field.Or(
+		e.BlockNumber.Gt(after.BlockNumber),
+		field.And(e.BlockNumber.Eq(after.BlockNumber), e.LogIndex.Gt(after.LogIndex)),
+	))
+	return e.WithContext(ctx).Where(conds...).Order(e.BlockNumber, e.LogIndex).Limit(PageSize(filter.Limit)).Find()
+}
+
+// eventConditions 事件过滤条件，不含分页
+func (r *stakingQueryRepository) eventConditions(filter EventFilter) []gen.Condition {
+	e := r.q.StakingEvent
+	conds := []gen.Condition{
+		e.ConfirmationStatus.In(visibleStatuses(filter.IncludePending)...),
+	}
+	if filter.ChainID != 0 {
+		conds = append(conds, e.ChainID.Eq(filter.ChainID))
+	}
+	if filter.ContractAddress != "" {
+		conds = append(conds, e.ContractAddress.Eq(filter.ContractAddress))
+	}
+	if filter.UserAddress != "" {
+		conds = append(conds, e.UserAddress.Eq(filter.UserAddress))
+	}
+	if filter.PoolID != nil {
+		conds = append(conds, e.PoolID.Eq(*filter.PoolID))
+	}
+	if filter.EventType != "" {
+		conds = append(conds, e.EventType.Eq(filter.EventType))
+	}
+	if filter.FromBlock > 0 {
+		conds = append(conds, e.BlockNumber.Gte(filter.FromBlock))
+	}
+	if filter.ToBlock > 0 {
+		conds = append(conds, e.BlockNumber.Lte(filter.ToBlock))
+	}
+	if filter.FromTime != nil {
+		conds = append(conds, e.BlockTime.Gte(*filter.FromTime))
+	}
+	if filter.ToTime != nil {
+		conds = append(conds, e.BlockTime.Lte(*filter.ToTime))
+	}
+	return conds
+}
+
+func (r *stakingQueryRepository) ListUserPositions(ctx context.Context, filter PositionFilter) ([]*model.StakingUserPosition, error) {
+	p := r.q.StakingUserPosition
+	conds := []gen.Condition{
+		p.ID.Gt(filter.AfterID),
+		p.UserAddress.Eq(filter.UserAddress),
+	}
+	if filter.ChainID != 0 {
+		conds = append(conds, p.ChainID.Eq(filter.ChainID))
+	}
+	if filter.ContractAddress != "" {
+		conds = append(conds, p.ContractAddress.Eq(filter.ContractAddress))
+	}
+
+	positions, err := p.WithContext(ctx).Where(conds...).Order(p.ID).Limit(PageSize(filter.Limit)).Find()
+	if err != nil || filter.IncludePending || len(positions) == 0 {
+		return positions, err
+	}
+
+	// 持仓包含 pending 事件的影响，只看已确认数据时需要扣除
+	e := r.q.StakingEvent
+	pending, err := e.WithContext(ctx).Where(
+		e.UserAddress.Eq(filter.UserAddress),
+		e.ConfirmationStatus.Eq(ConfirmationStatusPending),
+	).Find()
+	if err != nil {
+		return nil, err
+	}
+
+	type positionKey struct {
+		chainID         int64
+		contractAddress string
+		poolID          int64
+	}
+	byKey := make(map[positionKey]*model.StakingUserPosition, len(positions))
+	for _, pos := range positions {
+		byKey[positionKey{pos.ChainID, pos.ContractAddress, pos.PoolID}] = pos
+	}
+	for _, ev := range pending {
+		pos, ok := byKey[positionKey{ev.ChainID, ev.ContractAddress, ev.PoolID}]
+		if !ok {
+			continue
+		}
+		if pos.StakedAmount != nil {
+			*pos.StakedAmount = pos.StakedAmount.Sub(stakedAmountDelta(ev))
+		}
+		if pos.PendingUnstake != nil {
+			*pos.PendingUnstake = pos.PendingUnstake.Sub(pendingUnstakeDelta(ev))
+		}
+		if pos.TotalClaimed != nil {
+			*pos.TotalClaimed = pos.TotalClaimed.Sub(claimedAmountDelta(ev))
+		}
+	}
+	return positions, nil
+}
+
+func (r *stakingQueryRepository) GetPoolsByKeys(ctx context.Context, keys []PoolKey) ([]*model.StakingPool, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	p := r.q.StakingPool
+	chainIDs, contracts, poolIDs := splitPoolKeys(keys)
+	pools, err := p.WithContext(ctx).Where(
+		p.ChainID.In(chainIDs...),
+		p.ContractAddress.In(contracts...),
+		p.PoolID.In(poolIDs...),
+	).Find()
+	if err != nil {
+		return nil, err
+	}
+
+	// IN 条件是各列的笛卡尔积，过滤掉不在 keys 中的组合
+	wanted := make(map[PoolKey]bool, len(keys))
+	for _, k := range keys {
+		wanted[k] = true
+	}
+	result := pools[:0]
+	for _, pool := range pools {
+		if wanted[PoolKey{pool.ChainID, pool.ContractAddress, pool.PoolID}] {
+			result = append(result, pool)
+		}
+	}
+	return result, nil
+}
+
+func (r *stakingQueryRepository) GetBlocksByKeys(ctx context.Context, keys []BlockKey) ([]*model.ChainBlock, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	chainIDSet := make(map[int64]bool)
+	chainIDs := make([]int64, 0)
+	numbers := make([]int64, 0, len(keys))
+	wanted := make(map[BlockKey]bool, len(keys))
+	for _, k := range keys {
+		if !chainIDSet[k.ChainID] {
+			chainIDSet[k.ChainID] = true
+			chainIDs = append(chainIDs, k.ChainID)
+		}
+		numbers = append(numbers, k.BlockNumber)
+		wanted[k] = true
+	}
+
+	b := r.q.ChainBlock
+	blocks, err := b.WithContext(ctx).Where(
+		b.ChainID.In(chainIDs...),
+		b.BlockNumber.In(numbers...),
+	).Find()
+	if err != nil {
+		return nil, err
+	}
+
+	result := blocks[:0]
+	for _, block := range blocks {
+		if wanted[BlockKey{block.ChainID, block.BlockNumber}] {
+			result = append(result, block)
+		}
+	}
+	return result, nil
+}
+
+func (r *stakingQueryRepository) ListTopPositionsByPools(ctx context.Context, keys []PoolKey, limit int) ([]*model.StakingUserPosition, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	var positions []*model.StakingUserPosition
+	err := r.db.WithContext(ctx).Raw(`SELECT * FROM (
+	SELECT p.*, ROW_NUMBER() OVER (PARTITION BY chain_id, contract_address, pool_id ORDER BY staked_amount DESC, id) AS rn
+	FROM `+model.TableNameStakingUserPosition+` p
+	WHERE (chain_id, contract_address, pool_id) IN ?
+) t WHERE rn <= ? ORDER BY chain_id, contract_address, pool_id, rn`, poolKeyTuples(keys), PageSize(limit)).Scan(&positions).Error
+	return positions, err
+}
+
+func (r *stakingQueryRepository) ListRecentEventsByPools(ctx context.Context, keys []PoolKey, limit int, includePending bool) ([]*model.StakingEvent, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	var events []*model.StakingEvent
+	err := r.db.WithContext(ctx).Raw(`SELECT * FROM (
+	SELECT e.*, ROW_NUMBER() OVER (PARTITION BY chain_id, contract_address, pool_id ORDER BY id DESC) AS rn
+	FROM `+model.TableNameStakingEvent+` e
+	WHERE (chain_id, contract_address, pool_id) IN ? AND confirmation_status IN ?
+) t WHERE rn <= ? ORDER BY chain_id, contract_address, pool_id, rn`, poolKeyTuples(keys), visibleStatuses(includePending), PageSize(limit)).Scan(&events).Error
+	return events, err
+}
+
+func splitPoolKeys(keys []PoolKey) ([]int64, []string, []int64) {
+	chainIDSet, contractSet, poolIDSet := map[int64]bool{}, map[string]bool{}, map[int64]bool{}
+	var chainIDs, poolIDs []int64
+	var contracts []string
+	for _, k := range keys {
+		if !chainIDSet[k.ChainID] {
+			chainIDSet[k.ChainID] = true
+			chainIDs = append(chainIDs, k.ChainID)
+		}
+		if !contractSet[k.ContractAddress] {
+			contractSet[k.ContractAddress] = true
+			contracts = append(contracts, k.ContractAddress)
+		}
+		if !poolIDSet[k.PoolID] {
+			poolIDSet[k.PoolID] = true
+			poolIDs = append(poolIDs, k.PoolID)
+		}
+	}
+	return chainIDs, contracts, poolIDs
+}
+
+func poolKeyTuples(keys []PoolKey) [][]interface{} {
+	tuples := make([][]interface{}, 0, len(keys))
+	for _, k := range keys {
+		tuples = append(tuples, []interface{}{k.ChainID, k.ContractAddress, k.PoolID})
+	}
+	return tuples
+}
+
+// visibleStatuses 可见的事件确认状态
+func visibleStatuses(includePending bool) []string {
+	if includePending {
+		return []string{ConfirmationStatusConfirmed, ConfirmationStatusPending}
+	}
+	return []string{ConfirmationStatusConfirmed}
+}
+
+// PageSize 规范化分页条数
+func PageSize(limit int) int {
+	if limit <= 0 {
+		return DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		return MaxPageSize
+	}
+	return limit
+}
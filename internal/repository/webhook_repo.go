@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/gen/query"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// webhook_deliveries.status 取值
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// webhook_deliveries.message_type 取值
+const (
+	WebhookMessageEvent     = "event"
+	WebhookMessageRetracted = "retracted"
+)
+
+type WebhookRepository interface {
+	ListEnabledSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error)
+
+	GetSubscriptionsByIDs(ctx context.Context, ids []int64) ([]*model.WebhookSubscription, error)
+
+	// EnqueueDeliveries 写入投递队列，同一订阅下幂等键重复的消息忽略
+	EnqueueDeliveries(ctx context.Context, deliveries []*model.WebhookDelivery) error
+
+	// ListDueDeliveries 按 id 顺序返回到期待投递的消息
+	ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*model.WebhookDelivery, error)
+
+	// RecordAttempt 写入投递日志并更新投递状态
+	RecordAttempt(ctx context.Context, delivery *model.WebhookDelivery, attempt *model.WebhookDeliveryLog) error
+}
+
+type webhookRepository struct {
+	q *query.Query
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{
+		q: query.Use(db),
+	}
+}
+
+func (r *webhookRepository) ListEnabledSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	s := r.q.WebhookSubscription
+	return s.WithContext(ctx).Where(s.Enabled.Eq(1)).Order(s.ID).Find()
+}
+
+func (r *webhookRepository) GetSubscriptionsByIDs(ctx context.Context, ids []int64) ([]*model.WebhookSubscription, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	s := r.q.WebhookSubscription
+	return s.WithContext(ctx).Where(s.ID.In(ids...)).Find()
+}
+
+func (r *webhookRepository) EnqueueDeliveries(ctx context.Context, deliveries []*model.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.q.WebhookDelivery.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "message_key"}},
+		DoNothing: true,
+	}).Create(deliveries...)
+}
+
+func (r *webhookRepository) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*model.WebhookDelivery, error) {
+	d := r.q.WebhookDelivery
+	return d.WithContext(ctx).Where(
+		d.Status.Eq(WebhookDeliveryPending),
+		d.NextAttemptAt.Lte(now),
+	).Order(d.ID).Limit(limit).Find()
+}
+
+func (r *webhookRepository) RecordAttempt(ctx context.Context, delivery *model.WebhookDelivery, attempt *model.WebhookDeliveryLog) error {
+	return r.q.Transaction(func(tx *query.Query) error {
+		if err := tx.WebhookDeliveryLog.WithContext(ctx).Create(attempt); err != nil {
+			return err
+		}
+
+		d := tx.WebhookDelivery
+		_, err := d.WithContext(ctx).Where(d.ID.Eq(delivery.ID)).
+			Select(d.Status, d.Attempts, d.NextAttemptAt, d.LastError, d.DeliveredAt).
+			Updates(delivery)
+		return err
+	})
+}
@@ -0,0 +1,143 @@
+package broadcast
+
+import (
+	"sync"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+)
+
+// defaultBufferSize 每个订阅者的消息缓冲
+const defaultBufferSize = 1024
+
+// 消息类型
+const (
+	// KindEvent 事件已写入数据库（新事件或由 pending 提升为 confirmed）
+	KindEvent = "event"
+	// KindRetract 重组回滚：FromBlock 之后的事件已作废
+	KindRetract = "retract"
+)
+
+// Message 扫描器提交后广播的消息
+type Message struct {
+	// Seq 广播器内单调递增的序号
+	Seq               uint64
+	Kind              string
+	ChainID           int64
+	ContractAddresses []string
+	// FromBlock 回滚到的共同祖先区块，高于该区块的事件作废
+	FromBlock int64
+	Event     *model.StakingEvent
+}
+
+// Broadcaster 进程内的事件广播，扫描器在区块提交后发布，推送接口订阅。
+// 订阅者消费过慢时会被断开，由客户端从最后收到的位置恢复
+type Broadcaster struct {
+	mu         sync.Mutex
+	seq        uint64
+	bufferSize int
+	subs       map[*Subscription]struct{}
+}
+
+func NewBroadcaster(bufferSize int) *Broadcaster {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+	return &Broadcaster{
+		bufferSize: bufferSize,
+		subs:       make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscription 单个订阅，C 关闭表示订阅结束
+type Subscription struct {
+	C <-chan Message
+
+	ch     chan Message
+	b      *Broadcaster
+	lagged bool
+}
+
+// Lagged 订阅是否因消费过慢被断开
+func (s *Subscription) Lagged() bool {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+	return s.lagged
+}
+
+// Close 取消订阅
+func (s *Subscription) Close() {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+	if _, ok := s.b.subs[s]; ok {
+		delete(s.b.subs, s)
+		close(s.ch)
+	}
+}
+
+// Subscribe 订阅之后发布的消息
+func (b *Broadcaster) Subscribe() *Subscription {
+	ch := make(chan Message, b.bufferSize)
+	sub := &Subscription{C: ch, ch: ch, b: b}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+// Seq 最近一条消息的序号
+func (b *Broadcaster) Seq() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.seq
+}
+
+// Publish 发布消息，nil 广播器忽略
+func (b *Broadcaster) Publish(msgs ...Message) {
+	if b == nil || len(msgs) == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, msg := range msgs {
+		b.seq++
+		msg.Seq = b.seq
+		for sub := range b.subs {
+			select {
+			case sub.ch <- msg:
+			default:
+				sub.lagged = true
+				delete(b.subs, sub)
+				close(sub.ch)
+			}
+		}
+	}
+}
+
+// PublishEvents 发布已提交的事件
+func (b *Broadcaster) PublishEvents(events []*model.StakingEvent) {
+	if b == nil {
+		return
+	}
+	msgs := make([]Message, 0, len(events))
+	for _, ev := range events {
+		msgs = append(msgs, Message{
+			Kind:              KindEvent,
+			ChainID:           ev.ChainID,
+			ContractAddresses: []string{ev.ContractAddress},
+			Event:             ev,
+		})
+	}
+	b.Publish(msgs...)
+}
+
+// PublishRetract 发布重组回滚
+func (b *Broadcaster) PublishRetract(chainID int64, contractAddresses []string, fromBlock int64) {
+	b.Publish(Message{
+		Kind:              KindRetract,
+		ChainID:           chainID,
+		ContractAddresses: contractAddresses,
+		FromBlock:         fromBlock,
+	})
+}
@@ -0,0 +1,143 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+)
+
+var (
+	// ErrLagged 订阅者消费过慢被断开，客户端应从最后收到的位置恢复
+	ErrLagged = errors.New("subscriber too slow, resume from the last received event")
+	// ErrClosed 订阅已关闭
+	ErrClosed = errors.New("subscription closed")
+)
+
+// Follower 单个推送连接的事件跟随：先订阅广播，再从数据库补发恢复位置之后的事件，
+// 保证补发与实时推送之间没有空档。投递语义为至少一次
+type Follower struct {
+	b      *Broadcaster
+	repo   repository.StakingQueryRepository
+	sub    *Subscription
+	filter repository.EventFilter
+
+	// last 已补发到的位置，replayedSeq 之前的实时消息与补发结果可能重复
+	last        *repository.EventPosition
+	replayedSeq uint64
+}
+
+// Follow 订阅 filter.ChainID 上满足条件的事件，调用方负责 Close
+func Follow(b *Broadcaster, repo repository.StakingQueryRepository, filter repository.EventFilter) *Follower {
+	return &Follower{
+		b:      b,
+		repo:   repo,
+		sub:    b.Subscribe(),
+		filter: filter,
+	}
+}
+
+// Replay 从 from 之后补发数据库中的事件，未调用时只推送实时事件
+func (f *Follower) Replay(ctx context.Context, from repository.EventPosition, emit func(*model.StakingEvent) error) error {
+	filter := f.filter
+	filter.Limit = repository.MaxPageSize
+	pos := from
+	for {
+		events, err := f.repo.ListEventsAfterPosition(ctx, filter, pos)
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			if err := emit(e); err != nil {
+				return err
+			}
+			pos = repository.EventPosition{BlockNumber: e.BlockNumber, LogIndex: e.LogIndex}
+		}
+		if len(events) < filter.Limit {
+			break
+		}
+	}
+
+	f.last = &pos
+	f.replayedSeq = f.b.Seq()
+	return nil
+}
+
+// C 实时消息，关闭后通过 Err 获取原因
+func (f *Follower) C() <-chan Message {
+	return f.sub.C
+}
+
+// Err 订阅关闭的原因
+func (f *Follower) Err() error {
+	if f.sub.Lagged() {
+		return ErrLagged
+	}
+	return ErrClosed
+}
+
+// Accept 判断实时消息是否需要推送给该连接
+func (f *Follower) Accept(msg Message) bool {
+	if msg.ChainID != f.filter.ChainID {
+		return false
+	}
+
+	switch msg.Kind {
+	case KindRetract:
+		if f.filter.ContractAddress != "" && !slices.Contains(msg.ContractAddresses, f.filter.ContractAddress) {
+			return false
+		}
+		// 回滚区块之后的事件会在新分叉上重新发布，不能再按补发位置去重
+		if f.last != nil && f.last.BlockNumber > msg.FromBlock {
+			f.last = &repository.EventPosition{BlockNumber: msg.FromBlock, LogIndex: math.MaxInt32}
+		}
+		return true
+
+	case KindEvent:
+		e := msg.Event
+		if !matchEvent(f.filter, e) {
+			return false
+		}
+		if msg.Seq <= f.replayedSeq && f.last != nil && !after(e, *f.last) {
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+func (f *Follower) Close() {
+	f.sub.Close()
+}
+
+// after 事件是否位于 pos 之后
+func after(e *model.StakingEvent, pos repository.EventPosition) bool {
+	return e.BlockNumber > pos.BlockNumber || (e.BlockNumber == pos.BlockNumber && e.LogIndex > pos.LogIndex)
+}
+
+// matchEvent 实时事件是否满足订阅条件
+func matchEvent(filter repository.EventFilter, e *model.StakingEvent) bool {
+	if e.ConfirmationStatus != nil {
+		switch *e.ConfirmationStatus {
+		case repository.ConfirmationStatusOrphaned:
+			return false
+		case repository.ConfirmationStatusPending:
+			if !filter.IncludePending {
+				return false
+			}
+		}
+	}
+	if filter.ContractAddress != "" && e.ContractAddress != filter.ContractAddress {
+		return false
+	}
+	if filter.UserAddress != "" && e.UserAddress != filter.UserAddress {
+		return false
+	}
+	if filter.PoolID != nil && e.PoolID != *filter.PoolID {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,157 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	parsedABI    abi.ABI
+	parsedABIErr error
+	parseABIOnce sync.Once
+)
+
+// ParsedABI 解析后的质押合约 ABI
+func ParsedABI() (abi.ABI, error) {
+	parseABIOnce.Do(func() {
+		parsedABI, parsedABIErr = abi.JSON(strings.NewReader(StakingContractABI))
+	})
+	return parsedABI, parsedABIErr
+}
+
+// PoolInfo pool(pid) 的返回值
+type PoolInfo struct {
+	StTokenAddress      common.Address
+	PoolWeight          *big.Int
+	LastRewardBlock     *big.Int
+	AccZeroTokenPerST   *big.Int
+	StTokenAmount       *big.Int
+	MinDepositAmount    *big.Int
+	UnstakeLockedBlocks *big.Int
+}
+
+// StakingCaller 通过 eth_call 在指定区块读取质押合约的视图函数
+type StakingCaller struct {
+	abi     abi.ABI
+	caller  ethereum.ContractCaller
+	address common.Address
+}
+
+func NewStakingCaller(caller ethereum.ContractCaller, address common.Address) (*StakingCaller, error) {
+	parsed, err := ParsedABI()
+	if err != nil {
+		return nil, fmt.Errorf("parse staking abi: %w", err)
+	}
+	return &StakingCaller{abi: parsed, caller: caller, address: address}, nil
+}
+
+// PoolLength 质押池数量
+func (c *StakingCaller) PoolLength(ctx context.Context, block *big.Int) (*big.Int, error) {
+	return c.callUint(ctx, block, "poolLength")
+}
+
+// Pool 质押池状态
+func (c *StakingCaller) Pool(ctx context.Context, block *big.Int, pid int64) (*PoolInfo, error) {
+	raw, err := c.callRaw(ctx, block, "pool", big.NewInt(pid))
+	if err != nil {
+		return nil, err
+	}
+	info := new(PoolInfo)
+	if err := c.abi.UnpackIntoInterface(info, "pool", raw); err != nil {
+		return nil, fmt.Errorf("unpack pool: %w", err)
+	}
+	return info, nil
+}
+
+// StakingBalance 用户在质押池中的质押数量（已申请赎回的部分不计入）
+func (c *StakingCaller) StakingBalance(ctx context.Context, block *big.Int, pid int64, user common.Address) (*big.Int, error) {
+	return c.callUint(ctx, block, "stakingBalance", big.NewInt(pid), user)
+}
+
+// WithdrawAmount 用户已申请赎回的总数量，以及其中已解锁可提取的数量
+func (c *StakingCaller) WithdrawAmount(ctx context.Context, block *big.Int, pid int64, user common.Address) (requestAmount *big.Int, pendingWithdrawAmount *big.Int, err error) {
+	out, err := c.call(ctx, block, "withdrawAmount", big.NewInt(pid), user)
+	if err != nil {
+		return nil, nil, err
+	}
+	return abi.ConvertType(out[0], new(big.Int)).(*big.Int), abi.ConvertType(out[1], new(big.Int)).(*big.Int), nil
+}
+
+// UserInfo user(pid, address) 的返回值
+type UserInfo struct {
+	StAmount          *big.Int
+	FinishedZeroToken *big.Int
+	PendingZeroToken  *big.Int
+}
+
+// User 用户在质押池中的奖励状态
+func (c *StakingCaller) User(ctx context.Context, block *big.Int, pid int64, user common.Address) (*UserInfo, error) {
+	raw, err := c.callRaw(ctx, block, "user", big.NewInt(pid), user)
+	if err != nil {
+		return nil, err
+	}
+	info := new(UserInfo)
+	if err := c.abi.UnpackIntoInterface(info, "user", raw); err != nil {
+		return nil, fmt.Errorf("unpack user: %w", err)
+	}
+	return info, nil
+}
+
+// StartBlock 奖励开始区块
+func (c *StakingCaller) StartBlock(ctx context.Context, block *big.Int) (*big.Int, error) {
+	return c.callUint(ctx, block, "startBlock")
+}
+
+// EndBlock 奖励结束区块
+func (c *StakingCaller) EndBlock(ctx context.Context, block *big.Int) (*big.Int, error) {
+	return c.callUint(ctx, block, "endBlock")
+}
+
+// ZeroTokenPerBlock 每区块奖励
+func (c *StakingCaller) ZeroTokenPerBlock(ctx context.Context, block *big.Int) (*big.Int, error) {
+	return c.callUint(ctx, block, "ZeroTokenPerBlock")
+}
+
+// PendingZeroTokenByBlockNumber 按 block 时的状态推算用户到 blockNumber 可领取的奖励
+func (c *StakingCaller) PendingZeroTokenByBlockNumber(ctx context.Context, block *big.Int, pid int64, user common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return c.callUint(ctx, block, "pendingZeroTokenByBlockNumber", big.NewInt(pid), user, blockNumber)
+}
+
+func (c *StakingCaller) callUint(ctx context.Context, block *big.Int, method string, args ...interface{}) (*big.Int, error) {
+	out, err := c.call(ctx, block, method, args...)
+	if err != nil {
+		return nil, err
+	}
+	return abi.ConvertType(out[0], new(big.Int)).(*big.Int), nil
+}
+
+func (c *StakingCaller) call(ctx context.Context, block *big.Int, method string, args ...interface{}) ([]interface{}, error) {
+	raw, err := c.callRaw(ctx, block, method, args...)
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.abi.Unpack(method, raw)
+	if err != nil {
+		return nil, fmt.Errorf("unpack %s: %w", method, err)
+	}
+	return out, nil
+}
+
+func (c *StakingCaller) callRaw(ctx context.Context, block *big.Int, method string, args ...interface{}) ([]byte, error) {
+	input, err := c.abi.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pack %s: %w", method, err)
+	}
+	raw, err := c.caller.CallContract(ctx, ethereum.CallMsg{To: &c.address, Data: input}, block)
+	if err != nil {
+		return nil, fmt.Errorf("call %s: %w", method, err)
+	}
+	return raw, nil
+}
@@ -2,8 +2,11 @@ package event
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
 	"github.com/dijiacoder/staking-indexer/internal/logger"
 	"github.com/dijiacoder/staking-indexer/internal/metrics"
 	"github.com/dijiacoder/staking-indexer/internal/repository"
@@ -16,23 +19,37 @@ import (
 // Processor 事件处理器
 // 职责：编排 handler 的分发流程
 type Processor struct {
+	repo       repository.ScannerRepository
 	handlerMgr *handler.EventHandlerManager
 }
 
-// NewEventProcessor 创建新的事件处理器
-func NewEventProcessor(repo repository.ScannerRepository) *Processor {
+// NewEventProcessor 创建新的事件处理器，enabledHandlers 为空时启用全部处理器
+func NewEventProcessor(repo repository.ScannerRepository, enabledHandlers []string) *Processor {
 	return &Processor{
-		handlerMgr: handler.NewEventHandlerManager(repo),
+		repo:       repo,
+		handlerMgr: handler.NewEventHandlerManager(repo, enabledHandlers),
 	}
 }
 
-// ProcessEvents 批量处理事件：分发到对应 handler
-func (ep *Processor) ProcessEvents(ctx context.Context, chainID int64, contractAddress string, logs []types.Log) error {
+// ProcessEvents 批量处理事件：归档原始日志后分发到对应 handler。
+// blockTimes 为日志所在区块的时间，按区块号索引，没有的区块以 nil 交给 handler。
+// 无法解析的日志跳过并计入失败指标，其他处理错误直接返回，调用方不能推进游标
+func (ep *Processor) ProcessEvents(ctx context.Context, chainID int64, contractAddress string, logs []types.Log,
+	blockTimes map[int64]*time.Time, confirmationStatus string) error {
 	labels := map[string]string{
 		"chain_id":        fmt.Sprintf("%d", chainID),
 		"contract_address": contractAddress,
 	}
 
+	// 先归档全部原始日志（包括未知和忽略的事件），归档失败时整个区块重试
+	rawLogs := make([]*model.RawLog, 0, len(logs))
+	for _, log := range logs {
+		rawLogs = append(rawLogs, ToRawLog(chainID, contractAddress, log))
+	}
+	if err := ep.repo.SaveRawLogs(ctx, rawLogs); err != nil {
+		return fmt.Errorf("archive raw logs: %w", err)
+	}
+
 	stakingContract := contracts.NewStakingContract()
 
 	for _, log := range logs {
@@ -49,7 +66,7 @@ func (ep *Processor) ProcessEvents(ctx context.Context, chainID int64, contractA
 		}
 
 		// 分发到 handler，让 handler 自己解析和处理
-		if err := ep.handlerMgr.HandleEvent(ctx, chainID, contractAddress, log); err != nil {
+		if err := ep.handlerMgr.HandleEvent(ctx, chainID, contractAddress, log, blockTimes[int64(log.BlockNumber)], confirmationStatus); err != nil {
 			logger.Logger.Error("Failed to handle event",
 				zap.Error(err),
 				zap.String("tx_hash", log.TxHash.Hex()),
@@ -59,7 +76,12 @@ func (ep *Processor) ProcessEvents(ctx context.Context, chainID int64, contractA
 			if eventName != "" {
 				metrics.EventsFailedTotal.With(labels).Inc()
 			}
-			continue
+			// 无法解析的日志跳过，写入失败时返回错误，由调用方重试整个区块或批次
+			if errors.Is(err, handler.ErrDecodeLog) {
+				delete(labels, "event_type")
+				continue
+			}
+			return fmt.Errorf("handle log %s:%d: %w", log.TxHash.Hex(), log.Index, err)
 		}
 		delete(labels, "event_type") // 清理 label 用于下一次循环
 	}
@@ -0,0 +1,62 @@
+package event
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ToRawLog 把链上日志转换为归档记录
+func ToRawLog(chainID int64, contractAddress string, log types.Log) *model.RawLog {
+	topics := make([]string, 0, len(log.Topics))
+	for _, t := range log.Topics {
+		topics = append(topics, t.Hex())
+	}
+	var topic0 string
+	if len(topics) > 0 {
+		topic0 = topics[0]
+	}
+	return &model.RawLog{
+		ChainID:         chainID,
+		ContractAddress: contractAddress,
+		BlockNumber:     int64(log.BlockNumber),
+		BlockHash:       log.BlockHash.Hex(),
+		TxHash:          log.TxHash.Hex(),
+		TxIndex:         int32(log.TxIndex),
+		LogIndex:        int32(log.Index),
+		Topic0:          topic0,
+		Topics:          strings.Join(topics, ","),
+		Data:            hexutil.Encode(log.Data),
+	}
+}
+
+// FromRawLog 把归档记录还原为链上日志，交给事件处理器重新解析
+func FromRawLog(raw *model.RawLog) (types.Log, error) {
+	var topics []common.Hash
+	if raw.Topics != "" {
+		for _, t := range strings.Split(raw.Topics, ",") {
+			if len(t) != 2+2*common.HashLength {
+				return types.Log{}, fmt.Errorf("raw log %d: invalid topic %q", raw.ID, t)
+			}
+			topics = append(topics, common.HexToHash(t))
+		}
+	}
+	data, err := hexutil.Decode(raw.Data)
+	if err != nil {
+		return types.Log{}, fmt.Errorf("raw log %d: invalid data: %w", raw.ID, err)
+	}
+	return types.Log{
+		Address:     common.HexToAddress(raw.ContractAddress),
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: uint64(raw.BlockNumber),
+		BlockHash:   common.HexToHash(raw.BlockHash),
+		TxHash:      common.HexToHash(raw.TxHash),
+		TxIndex:     uint(raw.TxIndex),
+		Index:       uint(raw.LogIndex),
+	}, nil
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"fmt"
 	"math/big"
 
 	"github.com/dijiacoder/staking-indexer/internal/gen/model"
@@ -22,6 +23,10 @@ func NewAddPoolEventHandler() *AddPoolEventHandler {
 func (h *AddPoolEventHandler) HandleEvent(ctx *EventHandlerContext) error {
 	log := ctx.Log
 
+	if len(log.Topics) < 4 {
+		return fmt.Errorf("%w: insufficient topics for AddPool event", ErrDecodeLog)
+	}
+
 	poolID := new(big.Int).SetBytes(log.Topics[1].Bytes())
 	stTokenAddress := common.BytesToAddress(log.Topics[2].Bytes())
 	poolWeight := new(big.Int).SetBytes(log.Topics[3].Bytes())
@@ -55,7 +60,6 @@ func (h *AddPoolEventHandler) HandleEvent(ctx *EventHandlerContext) error {
 		PoolWeight:          poolWeight.Int64(),
 		LastRewardBlock:     bigInt64(lastRewardBlock),
 		AccZeroTokenPerSt:   0,
-		StTokenAmount:       0,
 		MinDepositAmount:    bigInt64(minDepositAmount),
 		UnstakeLockedBlocks: bigInt64(unstakeLockedBlocks),
 	}
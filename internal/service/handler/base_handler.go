@@ -1,5 +1,13 @@
 package handler
 
+import (
+	"math/big"
+
+	"github.com/dijiacoder/staking-indexer/internal/decimal"
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/ethereum/go-ethereum/common"
+)
+
 // BaseEventHandler 基础事件处理器，提供通用功能
 type BaseEventHandler struct {
 	eventName string
@@ -11,4 +19,23 @@ func (h *BaseEventHandler) GetEventName() string {
 
 func (h *BaseEventHandler) CanHandle(eventName string) bool {
 	return h.eventName == eventName
-}
\ No newline at end of file
+}
+
+// saveStakingEvent 写入 staking_events 并更新用户持仓
+func (h *BaseEventHandler) saveStakingEvent(ctx *EventHandlerContext, user common.Address, poolID *big.Int, amount *big.Int) error {
+	status := ctx.ConfirmationStatus
+	ev := &model.StakingEvent{
+		ChainID:            ctx.ChainID,
+		ContractAddress:    ctx.ContractAddress,
+		PoolID:             poolID.Int64(),
+		EventType:          h.eventName,
+		UserAddress:        user.Hex(),
+		Amount:             decimal.New(amount),
+		BlockNumber:        int64(ctx.Log.BlockNumber),
+		BlockTime:          ctx.BlockTime,
+		TxHash:             ctx.Log.TxHash.Hex(),
+		LogIndex:           int32(ctx.Log.Index),
+		ConfirmationStatus: &status,
+	}
+	return ctx.Repo.SaveEventsAndProcessPositions(ctx.Ctx, []*model.StakingEvent{ev})
+}
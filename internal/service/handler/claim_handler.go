@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/dijiacoder/staking-indexer/internal/decimal"
 	"github.com/dijiacoder/staking-indexer/internal/logger"
 	"github.com/ethereum/go-ethereum/common"
 	"go.uber.org/zap"
@@ -24,7 +25,7 @@ func (h *ClaimEventHandler) HandleEvent(ctx *EventHandlerContext) error {
 	log := ctx.Log
 
 	if len(log.Topics) < 3 {
-		return fmt.Errorf("insufficient topics for Claim event")
+		return fmt.Errorf("%w: insufficient topics for Claim event", ErrDecodeLog)
 	}
 
 	// 从 Log 中解析数据
@@ -36,19 +37,19 @@ func (h *ClaimEventHandler) HandleEvent(ctx *EventHandlerContext) error {
 		reward = new(big.Int).SetBytes(log.Data[0:32])
 	}
 
-	// TODO: 业务处理逻辑
-	// 例如：更新 staking_user_positions 奖励记录
-	// 例如：累加用户历史奖励总量
-
-	var rewardFloat float64
-	if reward != nil {
-		rewardFloat, _ = new(big.Float).SetInt(reward).Float64()
+	if err := h.saveStakingEvent(ctx, userAddress, poolID, reward); err != nil {
+		logger.Logger.Error("save Claim to staking_events failed",
+			zap.Error(err),
+			zap.String("user", userAddress.Hex()),
+			zap.Int64("pool_id", poolID.Int64()),
+		)
+		return err
 	}
 
 	logger.Logger.Info("Claim event processed",
 		zap.String("user", userAddress.Hex()),
 		zap.Int64("pool_id", poolID.Int64()),
-		zap.Float64("reward", rewardFloat),
+		zap.Stringer("reward", decimal.New(reward)),
 	)
 
 	return nil
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/dijiacoder/staking-indexer/internal/decimal"
 	"github.com/dijiacoder/staking-indexer/internal/logger"
 	"github.com/ethereum/go-ethereum/common"
 	"go.uber.org/zap"
@@ -24,7 +25,7 @@ func (h *DepositEventHandler) HandleEvent(ctx *EventHandlerContext) error {
 	log := ctx.Log
 
 	if len(log.Topics) < 3 {
-		return fmt.Errorf("insufficient topics for Deposit event")
+		return fmt.Errorf("%w: insufficient topics for Deposit event", ErrDecodeLog)
 	}
 
 	// 从 Log 中解析数据
@@ -36,19 +37,19 @@ func (h *DepositEventHandler) HandleEvent(ctx *EventHandlerContext) error {
 		amount = new(big.Int).SetBytes(log.Data[0:32])
 	}
 
-	// TODO: 业务处理逻辑
-	// 例如：更新 staking_pools 总质押量
-	// 例如：更新 staking_user_positions 用户持仓
-
-	var amountFloat float64
-	if amount != nil {
-		amountFloat, _ = new(big.Float).SetInt(amount).Float64()
+	if err := h.saveStakingEvent(ctx, userAddress, poolID, amount); err != nil {
+		logger.Logger.Error("save Deposit to staking_events failed",
+			zap.Error(err),
+			zap.String("user", userAddress.Hex()),
+			zap.Int64("pool_id", poolID.Int64()),
+		)
+		return err
 	}
 
 	logger.Logger.Info("Deposit event processed",
 		zap.String("user", userAddress.Hex()),
 		zap.Int64("pool_id", poolID.Int64()),
-		zap.Float64("amount", amountFloat),
+		zap.Stringer("amount", decimal.New(amount)),
 	)
 
 	return nil
@@ -2,18 +2,27 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/dijiacoder/staking-indexer/internal/repository"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// ErrDecodeLog 日志无法按事件定义解析，重试也不会成功，调用方跳过该日志
+var ErrDecodeLog = errors.New("decode log")
+
 // EventHandlerContext 处理器上下文
 type EventHandlerContext struct {
 	Log             types.Log
 	ChainID         int64
 	ContractAddress string
-	Repo            repository.ScannerRepository
-	Ctx             context.Context
+	// ConfirmationStatus 事件所在区块的确认状态（pending / confirmed）
+	ConfirmationStatus string
+	// BlockTime 事件所在区块的时间（UTC），区块头没有记录时间时为 nil
+	BlockTime *time.Time
+	Repo      repository.ScannerRepository
+	Ctx       context.Context
 }
 
 // EventHandler 事件处理器接口
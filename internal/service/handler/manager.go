@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/dijiacoder/staking-indexer/internal/logger"
 	"github.com/dijiacoder/staking-indexer/internal/repository"
@@ -16,19 +17,30 @@ type EventHandlerManager struct {
 	handlers        map[string]EventHandler
 	stakingContract *contracts.StakingContract
 	repo            repository.ScannerRepository
+	enabled         map[string]bool
 }
 
 // NewEventHandlerManager 创建新的事件处理器管理器
-func NewEventHandlerManager(repo repository.ScannerRepository) *EventHandlerManager {
+// enabledHandlers 为空时注册全部处理器，否则只注册列出的事件
+func NewEventHandlerManager(repo repository.ScannerRepository, enabledHandlers []string) *EventHandlerManager {
 	manager := &EventHandlerManager{
 		handlers:        make(map[string]EventHandler),
 		stakingContract: contracts.NewStakingContract(),
 		repo:            repo,
 	}
+	if len(enabledHandlers) > 0 {
+		manager.enabled = make(map[string]bool, len(enabledHandlers))
+		for _, name := range enabledHandlers {
+			manager.enabled[name] = true
+		}
+	}
 
 	// 注册所有处理器
 	manager.RegisterHandler(NewSetZeroTokenEventHandler())
 	manager.RegisterHandler(NewAddPoolEventHandler())
+	manager.RegisterHandler(NewSetPoolWeightEventHandler())
+	manager.RegisterHandler(NewUpdatePoolInfoEventHandler())
+	manager.RegisterHandler(NewUpdatePoolEventHandler())
 	manager.RegisterHandler(NewDepositEventHandler())
 	manager.RegisterHandler(NewRequestUnstakeEventHandler())
 	manager.RegisterHandler(NewClaimEventHandler())
@@ -40,6 +52,9 @@ func NewEventHandlerManager(repo repository.ScannerRepository) *EventHandlerMana
 // RegisterHandler 注册事件处理器
 func (m *EventHandlerManager) RegisterHandler(handler EventHandler) {
 	eventName := handler.GetEventName()
+	if m.enabled != nil && !m.enabled[eventName] {
+		return
+	}
 	m.handlers[eventName] = handler
 }
 
@@ -49,11 +64,12 @@ func (m *EventHandlerManager) GetHandler(eventName string) (EventHandler, bool)
 	return handler, exists
 }
 
-// HandleEvent 根据原始日志分发到对应处理器
-func (m *EventHandlerManager) HandleEvent(ctx context.Context, chainID int64, contractAddress string, log types.Log) error {
+// HandleEvent 根据原始日志分发到对应处理器，blockTime 为日志所在区块的时间，未知时为 nil
+func (m *EventHandlerManager) HandleEvent(ctx context.Context, chainID int64, contractAddress string, log types.Log,
+	blockTime *time.Time, confirmationStatus string) error {
 	if len(log.Topics) == 0 {
 		logger.Logger.Error("log has no topics")
-		return fmt.Errorf("log has no topics")
+		return fmt.Errorf("%w: log has no topics", ErrDecodeLog)
 	}
 
 	eventHash := log.Topics[0]
@@ -80,11 +96,13 @@ func (m *EventHandlerManager) HandleEvent(ctx context.Context, chainID int64, co
 	}
 
 	eventCtx := &EventHandlerContext{
-		Log:             log,
-		ChainID:         chainID,
-		ContractAddress: contractAddress,
-		Repo:            m.repo,
-		Ctx:             ctx,
+		Log:                log,
+		ChainID:            chainID,
+		ContractAddress:    contractAddress,
+		ConfirmationStatus: confirmationStatus,
+		BlockTime:          blockTime,
+		Repo:               m.repo,
+		Ctx:                ctx,
 	}
 	return handler.HandleEvent(eventCtx)
 }
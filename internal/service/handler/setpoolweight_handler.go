@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"go.uber.org/zap"
+)
+
+// SetPoolWeightEventHandler 质押池权重变更处理器
+type SetPoolWeightEventHandler struct {
+	BaseEventHandler
+}
+
+func NewSetPoolWeightEventHandler() *SetPoolWeightEventHandler {
+	return &SetPoolWeightEventHandler{
+		BaseEventHandler: BaseEventHandler{eventName: "SetPoolWeight"},
+	}
+}
+
+func (h *SetPoolWeightEventHandler) HandleEvent(ctx *EventHandlerContext) error {
+	log := ctx.Log
+
+	if len(log.Topics) < 3 {
+		return fmt.Errorf("%w: insufficient topics for SetPoolWeight event", ErrDecodeLog)
+	}
+
+	poolID := new(big.Int).SetBytes(log.Topics[1].Bytes())
+	poolWeight := new(big.Int).SetBytes(log.Topics[2].Bytes())
+
+	if err := ctx.Repo.UpdatePool(ctx.Ctx, ctx.ChainID, ctx.ContractAddress, poolID.Int64(), map[string]interface{}{
+		"pool_weight": poolWeight.Int64(),
+	}); err != nil {
+		logger.Logger.Error("save SetPoolWeight to staking_pools failed",
+			zap.Error(err),
+			zap.Int64("poolID", poolID.Int64()),
+		)
+		return err
+	}
+
+	logger.Logger.Info("SetPoolWeight event processed and saved",
+		zap.Int64("poolID", poolID.Int64()),
+		zap.Int64("poolWeight", poolWeight.Int64()),
+	)
+
+	return nil
+}
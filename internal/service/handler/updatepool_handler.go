@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"fmt"
 	"math/big"
 
 	"github.com/dijiacoder/staking-indexer/internal/logger"
@@ -20,6 +21,10 @@ func NewUpdatePoolEventHandler() *UpdatePoolEventHandler {
 func (h *UpdatePoolEventHandler) HandleEvent(ctx *EventHandlerContext) error {
 	log := ctx.Log
 
+	if len(log.Topics) < 3 {
+		return fmt.Errorf("%w: insufficient topics for UpdatePool event", ErrDecodeLog)
+	}
+
 	poolID := new(big.Int).SetBytes(log.Topics[1].Bytes())
 	lastRewardBlock := new(big.Int).SetBytes(log.Topics[2].Bytes())
 
@@ -29,6 +34,16 @@ func (h *UpdatePoolEventHandler) HandleEvent(ctx *EventHandlerContext) error {
 		totalZeroToken = new(big.Int).SetBytes(log.Data[0:32])
 	}
 
+	if err := ctx.Repo.UpdatePool(ctx.Ctx, ctx.ChainID, ctx.ContractAddress, poolID.Int64(), map[string]interface{}{
+		"last_reward_block": lastRewardBlock.Int64(),
+	}); err != nil {
+		logger.Logger.Error("save UpdatePool to staking_pools failed",
+			zap.Error(err),
+			zap.Int64("poolID", poolID.Int64()),
+		)
+		return err
+	}
+
 	logger.Logger.Info("UpdatePool event processed and saved",
 		zap.Int64("poolID", poolID.Int64()),
 		zap.Int64("lastRewardBlock", lastRewardBlock.Int64()),
@@ -37,10 +52,3 @@ func (h *UpdatePoolEventHandler) HandleEvent(ctx *EventHandlerContext) error {
 
 	return nil
 }
-
-//func bigInt64(n *big.Int) int64 {
-//	if n == nil {
-//		return 0
-//	}
-//	return n.Int64()
-//}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"go.uber.org/zap"
+)
+
+// UpdatePoolInfoEventHandler 质押池最小质押数量与解质押锁定区块变更处理器
+type UpdatePoolInfoEventHandler struct {
+	BaseEventHandler
+}
+
+func NewUpdatePoolInfoEventHandler() *UpdatePoolInfoEventHandler {
+	return &UpdatePoolInfoEventHandler{
+		BaseEventHandler: BaseEventHandler{eventName: "UpdatePoolInfo"},
+	}
+}
+
+func (h *UpdatePoolInfoEventHandler) HandleEvent(ctx *EventHandlerContext) error {
+	log := ctx.Log
+
+	if len(log.Topics) < 4 {
+		return fmt.Errorf("%w: insufficient topics for UpdatePoolInfo event", ErrDecodeLog)
+	}
+
+	poolID := new(big.Int).SetBytes(log.Topics[1].Bytes())
+	minDepositAmount := new(big.Int).SetBytes(log.Topics[2].Bytes())
+	unstakeLockedBlocks := new(big.Int).SetBytes(log.Topics[3].Bytes())
+
+	if err := ctx.Repo.UpdatePool(ctx.Ctx, ctx.ChainID, ctx.ContractAddress, poolID.Int64(), map[string]interface{}{
+		"min_deposit_amount":    minDepositAmount.Int64(),
+		"unstake_locked_blocks": unstakeLockedBlocks.Int64(),
+	}); err != nil {
+		logger.Logger.Error("save UpdatePoolInfo to staking_pools failed",
+			zap.Error(err),
+			zap.Int64("poolID", poolID.Int64()),
+		)
+		return err
+	}
+
+	logger.Logger.Info("UpdatePoolInfo event processed and saved",
+		zap.Int64("poolID", poolID.Int64()),
+		zap.Int64("minDepositAmount", minDepositAmount.Int64()),
+		zap.Int64("unstakeLockedBlocks", unstakeLockedBlocks.Int64()),
+	)
+
+	return nil
+}
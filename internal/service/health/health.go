@@ -0,0 +1,290 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/config"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/scanner"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultMaxLagBlocks     = 50
+	defaultMaxScanIntervals = 10
+	defaultTimeout          = 3 * time.Second
+)
+
+// 检查结果
+const (
+	StatusOK       = "ok"
+	StatusFail     = "fail"
+	StatusReady    = "ready"
+	StatusNotReady = "not_ready"
+)
+
+// StatusProvider 提供各链 worker 的运行状态，由 scanner.Supervisor 实现
+type StatusProvider interface {
+	Status() []scanner.ChainStatus
+}
+
+// Check 单项检查结果
+type Check struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ChainHealth 单条链的存活检查结果
+type ChainHealth struct {
+	ChainID     int64  `json:"chain_id"`
+	Name        string `json:"name"`
+	Worker      string `json:"worker"`
+	RPC         Check  `json:"rpc"`
+	LatestBlock uint64 `json:"latest_block,omitempty"`
+}
+
+// HealthReport /healthz 响应
+type HealthReport struct {
+	Status   string        `json:"status"`
+	Database Check         `json:"database"`
+	Chains   []ChainHealth `json:"chains"`
+}
+
+// ContractReadiness 单个合约的就绪检查结果
+type ContractReadiness struct {
+	ChainID          int64      `json:"chain_id"`
+	ContractAddress  string     `json:"contract_address"`
+	Name             string     `json:"name"`
+	Ready            bool       `json:"ready"`
+	ScanStatus       string     `json:"scan_status"`
+	LastScannedBlock int64      `json:"last_scanned_block"`
+	TargetBlock      int64      `json:"target_block"`
+	Lag              int64      `json:"lag"`
+	LastScanAt       *time.Time `json:"last_scan_at,omitempty"`
+	Reasons          []string   `json:"reasons,omitempty"`
+}
+
+// ReadyReport /readyz 响应
+type ReadyReport struct {
+	Status    string              `json:"status"`
+	Contracts []ContractReadiness `json:"contracts"`
+}
+
+// Checker 提供存活与就绪检查
+type Checker struct {
+	db               *gorm.DB
+	repo             repository.ScannerRepository
+	status           StatusProvider
+	chains           []config.Chain
+	maxLagBlocks     int64
+	maxScanIntervals int
+	timeout          time.Duration
+
+	mu      sync.Mutex
+	clients map[int64]*ethclient.Client
+}
+
+func NewChecker(db *gorm.DB, repo repository.ScannerRepository, status StatusProvider, chains []config.Chain, cfg config.Health) *Checker {
+	if cfg.MaxLagBlocks <= 0 {
+		cfg.MaxLagBlocks = defaultMaxLagBlocks
+	}
+	if cfg.MaxScanIntervals <= 0 {
+		cfg.MaxScanIntervals = defaultMaxScanIntervals
+	}
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &Checker{
+		db:               db,
+		repo:             repo,
+		status:           status,
+		chains:           chains,
+		maxLagBlocks:     cfg.MaxLagBlocks,
+		maxScanIntervals: cfg.MaxScanIntervals,
+		timeout:          timeout,
+		clients:          make(map[int64]*ethclient.Client, len(chains)),
+	}
+}
+
+// Health 进程存活检查：数据库可连接且每条链的 RPC 可访问
+func (c *Checker) Health(ctx context.Context) HealthReport {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	report := HealthReport{Status: StatusOK, Database: Check{Status: StatusOK}}
+	if err := c.pingDB(ctx); err != nil {
+		report.Status = StatusFail
+		report.Database = Check{Status: StatusFail, Error: err.Error()}
+	}
+
+	workers := c.workerStates()
+	report.Chains = make([]ChainHealth, len(c.chains))
+	var wg sync.WaitGroup
+	for i, chain := range c.chains {
+		report.Chains[i] = ChainHealth{
+			ChainID: chain.ChainID,
+			Name:    chain.Name,
+			Worker:  workers[chain.ChainID].State,
+			RPC:     Check{Status: StatusOK},
+		}
+		wg.Add(1)
+		go func(ch *ChainHealth, chain config.Chain) {
+			defer wg.Done()
+			latest, err := c.latestBlock(ctx, chain)
+			if err != nil {
+				ch.RPC = Check{Status: StatusFail, Error: err.Error()}
+				return
+			}
+			ch.LatestBlock = latest
+		}(&report.Chains[i], chain)
+	}
+	wg.Wait()
+
+	for _, ch := range report.Chains {
+		if ch.RPC.Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+// Ready 就绪检查：每个合约游标为正常状态，同步延迟不超过 max_lag_blocks，
+// 且所在链最近一次成功扫描在 max_scan_intervals 个扫描间隔内
+func (c *Checker) Ready(ctx context.Context) ReadyReport {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	workers := c.workerStates()
+	report := ReadyReport{Status: StatusReady}
+	now := time.Now()
+	for _, chain := range c.chains {
+		worker := workers[chain.ChainID]
+		// 单轮扫描耗时最长为 scan_timeout，作为余量加在扫描间隔之外
+		staleAfter := time.Duration(c.maxScanIntervals*chain.ScanInterval+chain.ScanTimeout) * time.Second
+
+		for _, contract := range chain.Contracts {
+			r := ContractReadiness{
+				ChainID:         chain.ChainID,
+				ContractAddress: contract.Address,
+				Name:            contract.Name,
+				TargetBlock:     worker.TargetBlock,
+				LastScanAt:      worker.LastScanAt,
+			}
+
+			if worker.State != scanner.ChainStateRunning {
+				r.Reasons = append(r.Reasons, fmt.Sprintf("chain worker is %s", worker.State))
+			}
+			if worker.LastScanAt == nil {
+				r.Reasons = append(r.Reasons, "no successful scan yet")
+			} else if age := now.Sub(*worker.LastScanAt); age > staleAfter {
+				r.Reasons = append(r.Reasons, fmt.Sprintf("last successful scan %s ago", age.Truncate(time.Second)))
+			}
+
+			cursor, err := c.repo.GetCursor(ctx, chain.ChainID, contract.Address)
+			if err != nil {
+				r.ScanStatus = "unknown"
+				r.Reasons = append(r.Reasons, fmt.Sprintf("get cursor: %v", err))
+			} else {
+				r.LastScannedBlock = cursor.LastScannedBlock
+				r.ScanStatus = repository.ScanStatusName(cursor.ScanStatus)
+				if r.ScanStatus != "normal" {
+					r.Reasons = append(r.Reasons, "cursor is "+r.ScanStatus)
+				}
+				if worker.LastScanAt != nil {
+					r.Lag = max(worker.TargetBlock-cursor.LastScannedBlock, 0)
+					if r.Lag > c.maxLagBlocks {
+						r.Reasons = append(r.Reasons, fmt.Sprintf("sync lag %d exceeds %d blocks", r.Lag, c.maxLagBlocks))
+					}
+				}
+			}
+
+			r.Ready = len(r.Reasons) == 0
+			if !r.Ready {
+				report.Status = StatusNotReady
+			}
+			report.Contracts = append(report.Contracts, r)
+		}
+	}
+	return report
+}
+
+// ServeHealth 处理 /healthz，检查失败时返回 503
+func (c *Checker) ServeHealth(w http.ResponseWriter, r *http.Request) {
+	report := c.Health(r.Context())
+	code := http.StatusOK
+	if report.Status != StatusOK {
+		code = http.StatusServiceUnavailable
+	}
+	writeJSON(w, code, report)
+}
+
+// ServeReady 处理 /readyz，未就绪时返回 503
+func (c *Checker) ServeReady(w http.ResponseWriter, r *http.Request) {
+	report := c.Ready(r.Context())
+	code := http.StatusOK
+	if report.Status != StatusReady {
+		code = http.StatusServiceUnavailable
+	}
+	writeJSON(w, code, report)
+}
+
+func (c *Checker) pingDB(ctx context.Context) error {
+	sqlDB, err := c.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// latestBlock 通过健康检查专用的 RPC 客户端查询链头，客户端按链缓存
+func (c *Checker) latestBlock(ctx context.Context, chain config.Chain) (uint64, error) {
+	c.mu.Lock()
+	client, ok := c.clients[chain.ChainID]
+	if !ok {
+		var err error
+		client, err = ethclient.DialContext(ctx, chain.RPCURL)
+		if err != nil {
+			c.mu.Unlock()
+			return 0, fmt.Errorf("connect to rpc: %w", err)
+		}
+		c.clients[chain.ChainID] = client
+	}
+	c.mu.Unlock()
+
+	return client.BlockNumber(ctx)
+}
+
+func (c *Checker) workerStates() map[int64]scanner.ChainStatus {
+	states := make(map[int64]scanner.ChainStatus, len(c.chains))
+	for _, st := range c.status.Status() {
+		states[st.ChainID] = st
+	}
+	return states
+}
+
+// Close 关闭健康检查使用的 RPC 客户端
+func (c *Checker) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, client := range c.clients {
+		client.Close()
+		delete(c.clients, id)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Logger.Warn("Failed to write health response", zap.Error(err))
+	}
+}
@@ -0,0 +1,59 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	pruneInterval  = time.Hour
+	pruneBatchSize = 5000
+)
+
+// Pruner 定期删除超过保留时间的发件箱记录，落后超过保留时间的消费者会丢失这部分消息
+type Pruner struct {
+	repo      repository.OutboxRepository
+	retention time.Duration
+}
+
+func NewPruner(repo repository.OutboxRepository, retention time.Duration) *Pruner {
+	return &Pruner{repo: repo, retention: retention}
+}
+
+// Run 阻塞直到 ctx 取消
+func (p *Pruner) Run(ctx context.Context) error {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		p.prune(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Pruner) prune(ctx context.Context) {
+	before := time.Now().Add(-p.retention)
+	var total int64
+	for ctx.Err() == nil {
+		deleted, err := p.repo.DeleteOutboxBefore(ctx, before, pruneBatchSize)
+		if err != nil {
+			logger.Logger.Error("Failed to prune outbox", zap.Error(err))
+			return
+		}
+		total += deleted
+		if deleted < pruneBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		logger.Logger.Info("Pruned outbox entries", zap.Int64("deleted", total), zap.Time("before", before))
+	}
+}
@@ -0,0 +1,197 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/metrics"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/broadcast"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultBatchSize    = 500
+	defaultGapTimeout   = time.Minute
+)
+
+// Handler 按顺序处理一批发件箱消息，Message.Seq 为发件箱 id。
+// 返回 error 时整批消息稍后重新投递，处理需要幂等
+type Handler func(ctx context.Context, msgs []broadcast.Message) error
+
+// Relay 按 id 顺序读取发件箱交给 Handler，处理成功后保存消费进度，进度按链分别记录。
+// 扫描器、管理接口与运维命令可能在不同事务中写入同一条链的发件箱，id 较小的事务可能较晚提交，
+// 遇到 id 空洞时先停在空洞之前，空洞被填上或超过 gapTimeout（事务已回滚）后再继续
+type Relay struct {
+	repo         repository.OutboxRepository
+	consumer     string
+	chainIDs     []int64
+	handler      Handler
+	pollInterval time.Duration
+	batchSize    int
+	gapTimeout   time.Duration
+	// gaps 各链当前等待的空洞起始 id 与首次发现的时间，只在 Run 所在的 goroutine 中访问
+	gaps map[int64]outboxGap
+}
+
+type outboxGap struct {
+	id    int64
+	since time.Time
+}
+
+func NewRelay(repo repository.OutboxRepository, consumer string, chainIDs []int64, handler Handler,
+	pollInterval time.Duration, batchSize int, gapTimeout time.Duration) *Relay {
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	if gapTimeout <= 0 {
+		gapTimeout = defaultGapTimeout
+	}
+	return &Relay{
+		repo:         repo,
+		consumer:     consumer,
+		chainIDs:     chainIDs,
+		handler:      handler,
+		pollInterval: pollInterval,
+		batchSize:    batchSize,
+		gapTimeout:   gapTimeout,
+		gaps:         make(map[int64]outboxGap),
+	}
+}
+
+// Run 阻塞直到 ctx 取消
+func (r *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, chainID := range r.chainIDs {
+			if err := r.drain(ctx, chainID); err != nil && ctx.Err() == nil {
+				metrics.OutboxRelayErrorsTotal.WithLabelValues(r.consumer).Inc()
+				logger.Logger.Error("Outbox relay error",
+					zap.String("consumer", r.consumer),
+					zap.Int64("chain_id", chainID),
+					zap.Error(err),
+				)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain 处理链上积压的全部消息
+func (r *Relay) drain(ctx context.Context, chainID int64) error {
+	lastID, err := r.repo.GetCheckpoint(ctx, r.consumer, chainID)
+	if err != nil {
+		return err
+	}
+
+	for ctx.Err() == nil {
+		entries, err := r.repo.ListOutboxAfter(ctx, chainID, lastID, r.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			if entries, err = r.contiguous(ctx, chainID, lastID, entries); err != nil {
+				return err
+			}
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+
+		msgs := make([]broadcast.Message, 0, len(entries))
+		for _, entry := range entries {
+			msg, err := Decode(entry)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		if err := r.handler(ctx, msgs); err != nil {
+			return err
+		}
+
+		lastID = entries[len(entries)-1].ID
+		if err := r.repo.SaveCheckpoint(ctx, r.consumer, chainID, lastID); err != nil {
+			return err
+		}
+		metrics.OutboxRelayedTotal.WithLabelValues(r.consumer, strconv.FormatInt(chainID, 10)).Add(float64(len(entries)))
+	}
+	return ctx.Err()
+}
+
+// contiguous 截掉第一个未过期的 id 空洞之后的记录。id 在全部链之间分配，
+// 空洞可能属于其他链，只会推迟投递，不会跳过本链较晚提交的消息
+func (r *Relay) contiguous(ctx context.Context, chainID int64, lastID int64, entries []*model.EventOutbox) ([]*model.EventOutbox, error) {
+	ids, err := r.repo.ListOutboxIDs(ctx, lastID, entries[len(entries)-1].ID)
+	if err != nil {
+		return nil, err
+	}
+	expected := lastID + 1
+	for _, id := range ids {
+		if id > expected && !r.gapExpired(chainID, expected) {
+			n := sort.Search(len(entries), func(i int) bool { return entries[i].ID >= expected })
+			return entries[:n], nil
+		}
+		expected = id + 1
+	}
+	return entries, nil
+}
+
+// gapExpired 空洞持续超过 gapTimeout 时视为事务已回滚
+func (r *Relay) gapExpired(chainID int64, id int64) bool {
+	gap, ok := r.gaps[chainID]
+	if !ok || gap.id != id {
+		r.gaps[chainID] = outboxGap{id: id, since: time.Now()}
+		return false
+	}
+	if time.Since(gap.since) < r.gapTimeout {
+		return false
+	}
+	logger.Logger.Warn("Skipping outbox id gap",
+		zap.String("consumer", r.consumer),
+		zap.Int64("chain_id", chainID),
+		zap.Int64("id", id),
+	)
+	return true
+}
+
+// Decode 将发件箱记录转换为广播消息，Seq 为发件箱 id
+func Decode(entry *model.EventOutbox) (broadcast.Message, error) {
+	msg := broadcast.Message{
+		Seq:               uint64(entry.ID),
+		ChainID:           entry.ChainID,
+		ContractAddresses: []string{entry.ContractAddress},
+	}
+	switch entry.Kind {
+	case repository.OutboxKindEvent:
+		var ev model.StakingEvent
+		if err := json.Unmarshal([]byte(entry.Payload), &ev); err != nil {
+			return msg, fmt.Errorf("decode outbox entry %d: %w", entry.ID, err)
+		}
+		msg.Kind = broadcast.KindEvent
+		msg.Event = &ev
+	case repository.OutboxKindRetract:
+		msg.Kind = broadcast.KindRetract
+		msg.FromBlock = entry.BlockNumber
+	default:
+		return msg, fmt.Errorf("outbox entry %d has unknown kind %q", entry.ID, entry.Kind)
+	}
+	return msg, nil
+}
@@ -0,0 +1,337 @@
+package rebuild
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/contracts"
+	"github.com/dijiacoder/staking-indexer/internal/service/event"
+	"github.com/dijiacoder/staking-indexer/internal/service/handler"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm"
+)
+
+// ErrCursorMoved 重建期间游标发生了变化，结果不可信，需要重新执行
+var ErrCursorMoved = errors.New("cursor moved during rebuild")
+
+// Result 重建结果，Events 为重放的 staking_events 数量，RawLogs 为只来自归档的日志数量
+type Result struct {
+	Events             int   `json:"events"`
+	RawLogs            int   `json:"raw_logs"`
+	LastEventBlock     int64 `json:"last_event_block"`
+	LastScannedBlock   int64 `json:"last_scanned_block"`
+	LastConfirmedBlock int64 `json:"last_confirmed_block"`
+}
+
+// Rebuilder 清空合约的派生数据后，按 (block_number, log_index) 顺序把 staking_events
+// 与 raw_logs 中的其他日志重新交给事件处理器，不访问 RPC。合约需要在重建期间保持暂停。
+// 质押池随其他派生数据一起清空，由归档中的 AddPool、SetPoolWeight 等日志重新生成
+type Rebuilder struct {
+	db        *gorm.DB
+	repo      repository.ScannerRepository
+	queryRepo repository.StakingQueryRepository
+	handlers  *handler.EventHandlerManager
+	contract  *contracts.StakingContract
+}
+
+func NewRebuilder(db *gorm.DB) *Rebuilder {
+	// 已写入 staking_events 的事件都计入过持仓，重放时启用全部处理器
+	return &Rebuilder{
+		db:        db,
+		repo:      repository.NewScannerRepository(db),
+		queryRepo: repository.NewStakingQueryRepository(db),
+		handlers:  handler.NewEventHandlerManager(repository.NewReplayRepository(db), nil),
+		contract:  contracts.NewStakingContract(),
+	}
+}
+
+// Rebuild 重建合约的用户持仓、质押池与持仓快照，progress 每重放一页日志调用一次，可以为 nil。
+// 清空与重放在同一个事务内完成并锁定游标，读取方不会看到重建到一半的数据，失败时保留原数据
+func (b *Rebuilder) Rebuild(ctx context.Context, chainID int64, contractAddress string, progress func(events int, block int64)) (*Result, error) {
+	var result *Result
+	err := b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		result, err = NewRebuilder(tx).rebuild(ctx, chainID, contractAddress, progress)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b *Rebuilder) rebuild(ctx context.Context, chainID int64, contractAddress string, progress func(events int, block int64)) (*Result, error) {
+	// 1. 记录重建前的游标
+	before, err := b.repo.GetCursor(ctx, chainID, contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("get cursor: %w", err)
+	}
+	if repository.ScanStatusName(before.ScanStatus) != "paused" {
+		return nil, repository.ErrCursorNotPaused
+	}
+
+	filter := repository.EventFilter{
+		ChainID:         chainID,
+		ContractAddress: contractAddress,
+		IncludePending:  true,
+		Limit:           repository.MaxPageSize,
+	}
+	// 游标之后不应有有效事件，否则重建结果与游标不一致
+	first := filter
+	first.Limit = 1
+	beyond, err := b.queryRepo.ListEventsAfterPosition(ctx, first, repository.EventPosition{BlockNumber: before.LastScannedBlock + 1, LogIndex: -1})
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+	if len(beyond) > 0 {
+		return nil, fmt.Errorf("event %s:%d at block %d is above last scanned block %d",
+			beyond[0].TxHash, beyond[0].LogIndex, beyond[0].BlockNumber, before.LastScannedBlock)
+	}
+
+	// 质押池只能由归档中的 AddPool 重新生成，归档不完整时清空会丢失质押池
+	if err := b.checkPoolsArchived(ctx, chainID, contractAddress, before.LastScannedBlock); err != nil {
+		return nil, err
+	}
+
+	// 2. 清空派生数据
+	if err := b.repo.ResetProjections(ctx, chainID, contractAddress); err != nil {
+		return nil, fmt.Errorf("reset projections: %w", err)
+	}
+
+	// 3. 按链上顺序重放有效事件（pending 与 confirmed），与扫描时计入持仓的口径一致。
+	// 归档中有对应日志时使用原始日志，其他事件（如 AddPool）只能来自归档
+	result := &Result{
+		LastScannedBlock:   before.LastScannedBlock,
+		LastConfirmedBlock: before.LastConfirmedBlock,
+	}
+	events := newPager(func(after repository.EventPosition) ([]*model.StakingEvent, error) {
+		return b.queryRepo.ListEventsAfterPosition(ctx, filter, after)
+	}, func(ev *model.StakingEvent) repository.EventPosition {
+		return repository.EventPosition{BlockNumber: ev.BlockNumber, LogIndex: ev.LogIndex}
+	})
+	raws := newPager(func(after repository.EventPosition) ([]*model.RawLog, error) {
+		return b.repo.ListRawLogsAfterPosition(ctx, repository.RawLogFilter{
+			ChainID:         chainID,
+			ContractAddress: contractAddress,
+			ToBlock:         before.LastScannedBlock,
+			Limit:           repository.MaxPageSize,
+		}, after)
+	}, func(l *model.RawLog) repository.EventPosition {
+		return repository.EventPosition{BlockNumber: l.BlockNumber, LogIndex: l.LogIndex}
+	})
+	for {
+		ev, hasEvent, err := events.peek()
+		if err != nil {
+			return nil, fmt.Errorf("list events: %w", err)
+		}
+		raw, hasRaw, err := raws.peek()
+		if err != nil {
+			return nil, fmt.Errorf("list raw logs: %w", err)
+		}
+		if !hasEvent && !hasRaw {
+			break
+		}
+
+		var log types.Log
+		var status string
+		// 重建模式不写入 staking_events，归档中其余事件不需要区块时间
+		var blockTime *time.Time
+		switch {
+		case hasRaw && (!hasEvent || positionLess(raws.pos(raw), events.pos(ev))):
+			raws.next()
+			if b.indexed(raw) {
+				// 已作废或未写入 staking_events 的事件不计入持仓，需要时使用 redecode 补齐
+				continue
+			}
+			if log, err = event.FromRawLog(raw); err != nil {
+				return nil, err
+			}
+			status = repository.ConfirmationStatusConfirmed
+			if raw.BlockNumber > before.LastConfirmedBlock {
+				status = repository.ConfirmationStatusPending
+			}
+			result.RawLogs++
+		default:
+			events.next()
+			if hasRaw && raws.pos(raw) == events.pos(ev) {
+				raws.next()
+				log, err = event.FromRawLog(raw)
+			} else {
+				log, err = b.eventLog(ev)
+			}
+			if err != nil {
+				return nil, err
+			}
+			status = repository.ConfirmationStatusConfirmed
+			if ev.ConfirmationStatus != nil {
+				status = *ev.ConfirmationStatus
+			}
+			blockTime = ev.BlockTime
+			result.Events++
+		}
+
+		if err := b.handlers.HandleEvent(ctx, chainID, contractAddress, log, blockTime, status); err != nil {
+			return nil, fmt.Errorf("replay log %s:%d: %w", log.TxHash.Hex(), log.Index, err)
+		}
+		result.LastEventBlock = int64(log.BlockNumber)
+		if n := result.Events + result.RawLogs; progress != nil && n%repository.MaxPageSize == 0 {
+			progress(n, result.LastEventBlock)
+		}
+	}
+
+	// 4. 核对游标：重建期间保持暂停且没有被推进或回退
+	final, err := b.repo.GetCursor(ctx, chainID, contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("get cursor: %w", err)
+	}
+	if repository.ScanStatusName(final.ScanStatus) != "paused" ||
+		final.LastScannedBlock != before.LastScannedBlock ||
+		final.LastConfirmedBlock != before.LastConfirmedBlock {
+		return nil, fmt.Errorf("%w: scanned %d -> %d, confirmed %d -> %d, status %s",
+			ErrCursorMoved, before.LastScannedBlock, final.LastScannedBlock,
+			before.LastConfirmedBlock, final.LastConfirmedBlock, repository.ScanStatusName(final.ScanStatus))
+	}
+	return result, nil
+}
+
+// eventLog 按合约事件的布局把 staking_events 记录还原为日志：
+// topics 为事件签名、用户地址、Pool ID，data 的第一个字为数量
+func (b *Rebuilder) eventLog(ev *model.StakingEvent) (types.Log, error) {
+	sig, ok := b.contract.GetEventSignature(ev.EventType)
+	if !ok {
+		return types.Log{}, fmt.Errorf("unknown event type %q", ev.EventType)
+	}
+	if ev.Amount.Sign() < 0 {
+		return types.Log{}, fmt.Errorf("invalid event %s:%d", ev.TxHash, ev.LogIndex)
+	}
+	amount := ev.Amount.BigInt()
+
+	topics := []common.Hash{
+		sig,
+		common.BytesToHash(common.HexToAddress(ev.UserAddress).Bytes()),
+		common.BigToHash(big.NewInt(ev.PoolID)),
+	}
+	if ev.EventType == "Withdraw" {
+		// 第三个 indexed 参数为区块号，处理器不使用
+		topics = append(topics, common.BigToHash(big.NewInt(ev.BlockNumber)))
+	}
+
+	return types.Log{
+		Address:     common.HexToAddress(ev.ContractAddress),
+		Topics:      topics,
+		Data:        common.LeftPadBytes(amount.Bytes(), 32),
+		BlockNumber: uint64(ev.BlockNumber),
+		TxHash:      common.HexToHash(ev.TxHash),
+		Index:       uint(ev.LogIndex),
+	}, nil
+}
+
+// checkPoolsArchived 核对已有的质押池在 toBlock 之前都有归档的 AddPool 日志
+func (b *Rebuilder) checkPoolsArchived(ctx context.Context, chainID int64, contractAddress string, toBlock int64) error {
+	pools, err := b.repo.ListPools(ctx, chainID, contractAddress)
+	if err != nil {
+		return fmt.Errorf("list pools: %w", err)
+	}
+	if len(pools) == 0 {
+		return nil
+	}
+	sig, _ := b.contract.GetEventSignature("AddPool")
+	filter := repository.RawLogFilter{
+		ChainID:         chainID,
+		ContractAddress: contractAddress,
+		ToBlock:         toBlock,
+		Topic0:          []string{sig.Hex()},
+		Limit:           repository.MaxPageSize,
+	}
+	added := make(map[int64]bool)
+	after := repository.EventPosition{BlockNumber: -1, LogIndex: -1}
+	for {
+		raws, err := b.repo.ListRawLogsAfterPosition(ctx, filter, after)
+		if err != nil {
+			return fmt.Errorf("list raw logs: %w", err)
+		}
+		for _, raw := range raws {
+			log, err := event.FromRawLog(raw)
+			if err != nil {
+				return err
+			}
+			if len(log.Topics) > 1 {
+				added[new(big.Int).SetBytes(log.Topics[1].Bytes()).Int64()] = true
+			}
+		}
+		if len(raws) < repository.MaxPageSize {
+			break
+		}
+		last := raws[len(raws)-1]
+		after = repository.EventPosition{BlockNumber: last.BlockNumber, LogIndex: last.LogIndex}
+	}
+	for _, pool := range pools {
+		if !added[pool.PoolID] {
+			return fmt.Errorf("pool %d has no archived AddPool log, backfill raw_logs from start_block first", pool.PoolID)
+		}
+	}
+	return nil
+}
+
+// indexed 日志是否属于写入 staking_events 的事件类型
+func (b *Rebuilder) indexed(raw *model.RawLog) bool {
+	name, ok := b.contract.GetEventName(common.HexToHash(raw.Topic0))
+	if !ok {
+		return false
+	}
+	switch name {
+	case "Deposit", "RequestUnstake", "Claim", "Withdraw":
+		return true
+	}
+	return false
+}
+
+func positionLess(a, b repository.EventPosition) bool {
+	return a.BlockNumber < b.BlockNumber || (a.BlockNumber == b.BlockNumber && a.LogIndex < b.LogIndex)
+}
+
+// pager 按 (block_number, log_index) 分页读取，逐条返回
+type pager[T any] struct {
+	fetch func(after repository.EventPosition) ([]T, error)
+	pos   func(T) repository.EventPosition
+	buf   []T
+	after repository.EventPosition
+	done  bool
+}
+
+func newPager[T any](fetch func(after repository.EventPosition) ([]T, error), pos func(T) repository.EventPosition) *pager[T] {
+	return &pager[T]{
+		fetch: fetch,
+		pos:   pos,
+		after: repository.EventPosition{BlockNumber: -1, LogIndex: -1},
+	}
+}
+
+func (p *pager[T]) peek() (T, bool, error) {
+	var zero T
+	if len(p.buf) == 0 && !p.done {
+		items, err := p.fetch(p.after)
+		if err != nil {
+			return zero, false, err
+		}
+		p.buf = items
+		p.done = len(items) < repository.MaxPageSize
+		if len(items) > 0 {
+			p.after = p.pos(items[len(items)-1])
+		}
+	}
+	if len(p.buf) == 0 {
+		return zero, false, nil
+	}
+	return p.buf[0], true, nil
+}
+
+func (p *pager[T]) next() {
+	p.buf = p.buf[1:]
+}
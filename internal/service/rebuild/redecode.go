@@ -0,0 +1,107 @@
+package rebuild
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/contracts"
+	"github.com/dijiacoder/staking-indexer/internal/service/event"
+	"github.com/dijiacoder/staking-indexer/internal/service/handler"
+)
+
+// Redecode 把 [from, to] 内的归档日志重新交给事件处理器，新增或修复处理器后用于补齐历史事件，不访问 RPC。
+// 合约需要先暂停，区间需要已确认，事件写入幂等，已存在且未变化的事件不会重复计入持仓。
+// enabledHandlers 为空时启用全部处理器，progress 每处理一页日志调用一次，可以为 nil。返回处理的日志数量
+func Redecode(ctx context.Context, repo repository.ScannerRepository, chainID int64, contractAddress string,
+	from, to int64, enabledHandlers []string, progress func(logs int, block int64)) (int, error) {
+	cursor, err := repo.GetCursor(ctx, chainID, contractAddress)
+	if err != nil {
+		return 0, fmt.Errorf("get cursor: %w", err)
+	}
+	if repository.ScanStatusName(cursor.ScanStatus) != "paused" {
+		return 0, repository.ErrCursorNotPaused
+	}
+	if to > cursor.LastConfirmedBlock {
+		return 0, fmt.Errorf("to %d is above last confirmed block %d", to, cursor.LastConfirmedBlock)
+	}
+
+	// 只读取启用事件的日志
+	stakingContract := contracts.NewStakingContract()
+	var topics []string
+	for _, name := range enabledHandlers {
+		sig, ok := stakingContract.GetEventSignature(name)
+		if !ok {
+			return 0, fmt.Errorf("unknown event %q", name)
+		}
+		topics = append(topics, sig.Hex())
+	}
+
+	handlers := handler.NewEventHandlerManager(repo, enabledHandlers)
+	filter := repository.RawLogFilter{
+		ChainID:         chainID,
+		ContractAddress: contractAddress,
+		ToBlock:         to,
+		Topic0:          topics,
+		Limit:           repository.MaxPageSize,
+	}
+	after := repository.EventPosition{BlockNumber: from - 1, LogIndex: -1}
+	count := 0
+	for {
+		raws, err := repo.ListRawLogsAfterPosition(ctx, filter, after)
+		if err != nil {
+			return count, fmt.Errorf("list raw logs: %w", err)
+		}
+		blockTimes, err := rawLogBlockTimes(ctx, repo, chainID, raws)
+		if err != nil {
+			return count, fmt.Errorf("get blocks: %w", err)
+		}
+		for _, raw := range raws {
+			log, err := event.FromRawLog(raw)
+			if err != nil {
+				return count, err
+			}
+			if err := handlers.HandleEvent(ctx, chainID, contractAddress, log, blockTimes[raw.BlockNumber], repository.ConfirmationStatusConfirmed); err != nil {
+				return count, fmt.Errorf("decode log %s:%d: %w", raw.TxHash, raw.LogIndex, err)
+			}
+			count++
+		}
+		if len(raws) > 0 {
+			last := raws[len(raws)-1]
+			after = repository.EventPosition{BlockNumber: last.BlockNumber, LogIndex: last.LogIndex}
+			if progress != nil {
+				progress(count, last.BlockNumber)
+			}
+		}
+		if len(raws) < repository.MaxPageSize {
+			break
+		}
+	}
+
+	// 补齐的事件可能落在已生成的快照之前
+	if err := repo.DiscardSnapshots(ctx, chainID, contractAddress, from-1); err != nil {
+		return count, fmt.Errorf("discard snapshots: %w", err)
+	}
+	return count, nil
+}
+
+// rawLogBlockTimes 从 chain_blocks 读取一页归档日志所在区块的时间，按区块号索引
+func rawLogBlockTimes(ctx context.Context, repo repository.ScannerRepository, chainID int64, raws []*model.RawLog) (map[int64]*time.Time, error) {
+	numbers := make([]int64, 0, len(raws))
+	for _, raw := range raws {
+		if len(numbers) == 0 || numbers[len(numbers)-1] != raw.BlockNumber {
+			numbers = append(numbers, raw.BlockNumber)
+		}
+	}
+	blocks, err := repo.GetBlocksByNumbers(ctx, chainID, numbers)
+	if err != nil {
+		return nil, err
+	}
+	times := make(map[int64]*time.Time, len(blocks))
+	for _, b := range blocks {
+		times[b.BlockNumber] = b.BlockTime
+	}
+	return times, nil
+}
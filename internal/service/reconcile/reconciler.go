@@ -0,0 +1,375 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/config"
+	"github.com/dijiacoder/staking-indexer/internal/decimal"
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/metrics"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/contracts"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultInterval  = time.Hour
+	defaultTolerance = 1e-9
+)
+
+// Options 单次对账的参数
+type Options struct {
+	// SampleSize 抽查的用户持仓数，0 表示全部
+	SampleSize int
+	// AutoRepair 按链上值修复用户质押数量和质押池参数
+	AutoRepair bool
+	// Tolerance 数量比对的相对误差
+	Tolerance float64
+}
+
+// Report 单个合约的对账结果
+type Report struct {
+	ChainID         int64                   `json:"chain_id"`
+	ContractAddress string                  `json:"contract_address"`
+	BlockNumber     int64                   `json:"block_number"`
+	Pools           int                     `json:"pools"`
+	Positions       int                     `json:"positions"`
+	Drifts          []*model.ReconcileDrift `json:"drifts"`
+	Repaired        int                     `json:"repaired"`
+}
+
+// Reconciler 在游标的最近确认区块通过 eth_call 读取合约视图函数，与 staking_pools、staking_user_positions 比对。
+// 差异写入 reconcile_drifts 和监控指标，开启自动修复时按链上值修正
+type Reconciler struct {
+	repo        repository.ReconcileRepository
+	scannerRepo repository.ScannerRepository
+	chains      []config.Chain
+	interval    time.Duration
+	opts        Options
+	clients     map[int64]*ethclient.Client
+}
+
+func NewReconciler(repo repository.ReconcileRepository, scannerRepo repository.ScannerRepository, chains []config.Chain, cfg config.Reconcile) *Reconciler {
+	interval := time.Duration(cfg.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	return &Reconciler{
+		repo:        repo,
+		scannerRepo: scannerRepo,
+		chains:      chains,
+		interval:    interval,
+		opts: Options{
+			SampleSize: cfg.SampleSize,
+			AutoRepair: cfg.AutoRepair,
+			Tolerance:  cfg.Tolerance,
+		},
+		clients: make(map[int64]*ethclient.Client, len(chains)),
+	}
+}
+
+// Run 按间隔对账全部合约，阻塞直到 ctx 取消
+func (r *Reconciler) Run(ctx context.Context) error {
+	defer r.Close()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		for _, chain := range r.chains {
+			for _, contract := range chain.Contracts {
+				if ctx.Err() != nil {
+					break
+				}
+				report, err := r.ReconcileContract(ctx, chain, contract.Address, r.opts)
+				if err != nil {
+					logger.Logger.Error("Reconcile failed",
+						zap.Int64("chain_id", chain.ChainID),
+						zap.String("contract", contract.Address),
+						zap.Error(err))
+					continue
+				}
+				logger.Logger.Info("Reconcile finished",
+					zap.Int64("chain_id", chain.ChainID),
+					zap.String("contract", contract.Address),
+					zap.Int64("block", report.BlockNumber),
+					zap.Int("positions", report.Positions),
+					zap.Int("drifts", len(report.Drifts)),
+					zap.Int("repaired", report.Repaired))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Close 关闭 RPC 客户端
+func (r *Reconciler) Close() {
+	for id, client := range r.clients {
+		client.Close()
+		delete(r.clients, id)
+	}
+}
+
+// ReconcileContract 对账单个合约，opts 覆盖配置中的对账参数
+func (r *Reconciler) ReconcileContract(ctx context.Context, chain config.Chain, contractAddress string, opts Options) (*Report, error) {
+	client, err := r.client(ctx, chain)
+	if err != nil {
+		return nil, err
+	}
+	return Reconcile(ctx, r.repo, r.scannerRepo, client, chain.ChainID, contractAddress, opts)
+}
+
+func (r *Reconciler) client(ctx context.Context, chain config.Chain) (*ethclient.Client, error) {
+	if client, ok := r.clients[chain.ChainID]; ok {
+		return client, nil
+	}
+	client, err := ethclient.DialContext(ctx, chain.RPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("connect to rpc: %w", err)
+	}
+	r.clients[chain.ChainID] = client
+	return client, nil
+}
+
+// Reconcile 在合约游标的 last_confirmed_block 比对质押池和用户持仓：
+// poolLength 对应质押池数量，pool(pid) 对应质押池参数和已确认事件汇总的质押总量，
+// stakingBalance + withdrawAmount.requestAmount 对应用户质押数量，requestAmount 对应赎回中的数量
+func Reconcile(ctx context.Context, repo repository.ReconcileRepository, scannerRepo repository.ScannerRepository,
+	caller ethereum.ContractCaller, chainID int64, contractAddress string, opts Options) (*Report, error) {
+	if opts.Tolerance <= 0 {
+		opts.Tolerance = defaultTolerance
+	}
+	cursor, err := scannerRepo.GetCursor(ctx, chainID, contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("get cursor: %w", err)
+	}
+	if cursor.LastConfirmedBlock <= 0 {
+		return nil, fmt.Errorf("contract %s has no confirmed blocks", contractAddress)
+	}
+	state, err := repo.LoadState(ctx, chainID, contractAddress, cursor.LastConfirmedBlock)
+	if err != nil {
+		return nil, fmt.Errorf("load indexed state: %w", err)
+	}
+	staking, err := contracts.NewStakingCaller(caller, common.HexToAddress(contractAddress))
+	if err != nil {
+		return nil, err
+	}
+
+	c := &checker{
+		repo:     repo,
+		staking:  staking,
+		block:    big.NewInt(state.BlockNumber),
+		opts:     opts,
+		report:   &Report{ChainID: chainID, ContractAddress: contractAddress, BlockNumber: state.BlockNumber},
+		chainID:  chainID,
+		contract: contractAddress,
+	}
+	if err := c.checkPools(ctx, state); err != nil {
+		return nil, err
+	}
+	if err := c.checkPositions(ctx, state); err != nil {
+		return nil, err
+	}
+
+	if err := repo.SaveDrifts(ctx, c.report.Drifts); err != nil {
+		return nil, fmt.Errorf("save drifts: %w", err)
+	}
+	chainLabel := strconv.FormatInt(chainID, 10)
+	for _, drift := range c.report.Drifts {
+		metrics.ReconcileDriftsTotal.WithLabelValues(chainLabel, contractAddress, drift.Field).Inc()
+		if drift.Repaired == 1 {
+			metrics.ReconcileRepairsTotal.WithLabelValues(chainLabel, contractAddress, drift.Field).Inc()
+		}
+	}
+	metrics.ReconcileDrifts.WithLabelValues(chainLabel, contractAddress).Set(float64(len(c.report.Drifts)))
+	return c.report, nil
+}
+
+type checker struct {
+	repo     repository.ReconcileRepository
+	staking  *contracts.StakingCaller
+	block    *big.Int
+	opts     Options
+	report   *Report
+	chainID  int64
+	contract string
+}
+
+func (c *checker) checkPools(ctx context.Context, state *repository.ReconcileState) error {
+	length, err := c.staking.PoolLength(ctx, c.block)
+	if err != nil {
+		return err
+	}
+	c.report.Pools = int(length.Int64())
+	if length.Cmp(big.NewInt(int64(len(state.Pools)))) != 0 {
+		c.drift(-1, "", repository.DriftPoolCount, strconv.Itoa(len(state.Pools)), length.String())
+	}
+
+	indexed := make(map[int64]*model.StakingPool, len(state.Pools))
+	for _, pool := range state.Pools {
+		indexed[pool.PoolID] = pool
+	}
+	for pid := int64(0); pid < length.Int64(); pid++ {
+		info, err := c.staking.Pool(ctx, c.block, pid)
+		if err != nil {
+			return err
+		}
+		pool, ok := indexed[pid]
+		if !ok {
+			pool = &model.StakingPool{}
+		}
+
+		// 质押池参数由 AddPool 等事件写入，可以按链上值修复
+		var drifts []*model.ReconcileDrift
+		if !strings.EqualFold(pool.StTokenAddress, info.StTokenAddress.Hex()) {
+			drifts = append(drifts, c.drift(pid, "", repository.DriftStTokenAddress, pool.StTokenAddress, info.StTokenAddress.Hex()))
+		}
+		for _, f := range []struct {
+			name    string
+			indexed int64
+			chain   *big.Int
+		}{
+			{repository.DriftPoolWeight, pool.PoolWeight, info.PoolWeight},
+			{repository.DriftMinDepositAmount, pool.MinDepositAmount, info.MinDepositAmount},
+			{repository.DriftUnstakeLockedBlocks, pool.UnstakeLockedBlocks, info.UnstakeLockedBlocks},
+		} {
+			if f.chain.Cmp(big.NewInt(f.indexed)) != 0 {
+				drifts = append(drifts, c.drift(pid, "", f.name, strconv.FormatInt(f.indexed, 10), f.chain.String()))
+			}
+		}
+		if len(drifts) > 0 && c.opts.AutoRepair {
+			if err := c.repairPool(ctx, pid, info, drifts); err != nil {
+				return err
+			}
+		}
+
+		// 质押总量由事件汇总，差异说明事件缺失，需要重扫
+		staked := state.PoolStaked[pid]
+		if !c.equal(staked, info.StTokenAmount) {
+			c.drift(pid, "", repository.DriftStTokenAmount, staked.String(), info.StTokenAmount.String())
+		}
+	}
+	return nil
+}
+
+func (c *checker) repairPool(ctx context.Context, pid int64, info *contracts.PoolInfo, drifts []*model.ReconcileDrift) error {
+	for _, v := range []*big.Int{info.PoolWeight, info.LastRewardBlock, info.MinDepositAmount, info.UnstakeLockedBlocks} {
+		if !v.IsInt64() {
+			logger.Logger.Warn("Pool value out of range, skip repair",
+				zap.Int64("chain_id", c.chainID), zap.String("contract", c.contract), zap.Int64("pool_id", pid))
+			return nil
+		}
+	}
+	if err := c.repo.RepairPool(ctx, &model.StakingPool{
+		ChainID:             c.chainID,
+		ContractAddress:     c.contract,
+		PoolID:              pid,
+		StTokenAddress:      info.StTokenAddress.Hex(),
+		PoolWeight:          info.PoolWeight.Int64(),
+		LastRewardBlock:     info.LastRewardBlock.Int64(),
+		MinDepositAmount:    info.MinDepositAmount.Int64(),
+		UnstakeLockedBlocks: info.UnstakeLockedBlocks.Int64(),
+	}); err != nil {
+		return fmt.Errorf("repair pool %d: %w", pid, err)
+	}
+	for _, drift := range drifts {
+		c.repaired(drift)
+	}
+	return nil
+}
+
+func (c *checker) checkPositions(ctx context.Context, state *repository.ReconcileState) error {
+	positions := state.Positions
+	if c.opts.SampleSize > 0 && len(positions) > c.opts.SampleSize {
+		positions = append([]*repository.IndexedPosition(nil), positions...)
+		rand.Shuffle(len(positions), func(i, j int) {
+			positions[i], positions[j] = positions[j], positions[i]
+		})
+		positions = positions[:c.opts.SampleSize]
+	}
+	c.report.Positions = len(positions)
+
+	for _, pos := range positions {
+		user := common.HexToAddress(pos.UserAddress)
+		balance, err := c.staking.StakingBalance(ctx, c.block, pos.PoolID, user)
+		if err != nil {
+			return err
+		}
+		requested, _, err := c.staking.WithdrawAmount(ctx, c.block, pos.PoolID, user)
+		if err != nil {
+			return err
+		}
+
+		// 链上申请赎回时即扣减 stAmount，索引的质押数量在提取时才扣减
+		staked := new(big.Int).Add(balance, requested)
+		if !c.equal(pos.StakedAmount, staked) {
+			drift := c.drift(pos.PoolID, pos.UserAddress, repository.DriftStakedAmount, pos.StakedAmount.String(), staked.String())
+			if c.opts.AutoRepair {
+				if err := c.repo.RepairPosition(ctx, c.chainID, c.contract, pos.PoolID, pos.UserAddress,
+					c.report.BlockNumber, decimal.New(staked)); err != nil {
+					return fmt.Errorf("repair position %d/%s: %w", pos.PoolID, pos.UserAddress, err)
+				}
+				c.repaired(drift)
+			}
+		}
+		if !c.equal(pos.PendingUnstake, requested) {
+			c.drift(pos.PoolID, pos.UserAddress, repository.DriftPendingUnstake, pos.PendingUnstake.String(), requested.String())
+		}
+	}
+	return nil
+}
+
+func (c *checker) drift(poolID int64, userAddress string, field string, indexedValue string, chainValue string) *model.ReconcileDrift {
+	drift := &model.ReconcileDrift{
+		ChainID:         c.chainID,
+		ContractAddress: c.contract,
+		BlockNumber:     c.report.BlockNumber,
+		PoolID:          poolID,
+		UserAddress:     userAddress,
+		Field:           field,
+		IndexedValue:    indexedValue,
+		ChainValue:      chainValue,
+	}
+	c.report.Drifts = append(c.report.Drifts, drift)
+	logger.Logger.Warn("Reconcile drift",
+		zap.Int64("chain_id", c.chainID),
+		zap.String("contract", c.contract),
+		zap.Int64("block", c.report.BlockNumber),
+		zap.Int64("pool_id", poolID),
+		zap.String("user", userAddress),
+		zap.String("field", field),
+		zap.String("indexed", indexedValue),
+		zap.String("chain", chainValue))
+	return drift
+}
+
+func (c *checker) repaired(drift *model.ReconcileDrift) {
+	drift.Repaired = 1
+	c.report.Repaired++
+}
+
+// equal 比较索引的 DECIMAL 数量与链上值，差值按相对误差容忍，tolerance 为 0 时要求完全一致
+func (c *checker) equal(indexed decimal.Int, chain *big.Int) bool {
+	value := decimal.New(chain)
+	if indexed.Cmp(value) == 0 {
+		return true
+	}
+	diff := math.Abs(indexed.Sub(value).Float64())
+	return diff <= c.opts.Tolerance*math.Max(math.Abs(indexed.Float64()), math.Abs(value.Float64()))
+}
@@ -0,0 +1,249 @@
+package rescan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/config"
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/event"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPollInterval = 5 * time.Second
+	defaultChunkSize    = 100
+	// 运行中的任务超过该时长没有进度，视为执行者已退出
+	staleJobTimeout = 5 * time.Minute
+)
+
+// ErrBlockHashMismatch 日志所在区块与已索引的区块头不一致，应暂停合约并回退游标重新扫描
+var ErrBlockHashMismatch = errors.New("log block hash does not match indexed header")
+
+// Runner 执行管理接口创建的区间重扫任务。
+// 重扫只处理已确认的闭区间，事件写入幂等，已存在且未变化的事件不会重复计入持仓
+type Runner struct {
+	adminRepo    repository.AdminRepository
+	repo         repository.ScannerRepository
+	chains       map[int64]config.Chain
+	pollInterval time.Duration
+	clients      map[int64]*ethclient.Client
+}
+
+func NewRunner(adminRepo repository.AdminRepository, repo repository.ScannerRepository, chains []config.Chain, pollInterval time.Duration) *Runner {
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+	byID := make(map[int64]config.Chain, len(chains))
+	for _, chain := range chains {
+		byID[chain.ChainID] = chain
+	}
+	return &Runner{
+		adminRepo:    adminRepo,
+		repo:         repo,
+		chains:       byID,
+		pollInterval: pollInterval,
+		clients:      make(map[int64]*ethclient.Client, len(chains)),
+	}
+}
+
+// Run 逐个领取并执行任务，阻塞直到 ctx 取消
+func (r *Runner) Run(ctx context.Context) error {
+	defer func() {
+		for _, client := range r.clients {
+			client.Close()
+		}
+	}()
+
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			job, err := r.adminRepo.ClaimJob(ctx, time.Now().Add(-staleJobTimeout))
+			if err != nil {
+				logger.Logger.Error("Failed to claim admin job", zap.Error(err))
+				break
+			}
+			if job == nil {
+				break
+			}
+			r.execute(ctx, job)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Runner) execute(ctx context.Context, job *model.AdminJob) {
+	logger.Logger.Info("Admin job started",
+		zap.Int64("job_id", job.ID),
+		zap.String("kind", job.Kind),
+		zap.Int64("chain_id", job.ChainID),
+		zap.String("contract", job.ContractAddress),
+		zap.Int64("from_block", job.FromBlock),
+		zap.Int64("to_block", job.ToBlock),
+	)
+
+	var err error
+	switch job.Kind {
+	case repository.AdminJobRescan:
+		err = r.rescan(ctx, job)
+	default:
+		err = fmt.Errorf("unknown job kind %q", job.Kind)
+	}
+	if ctx.Err() != nil {
+		// 进程退出，任务保持运行中，超时后由其他副本或重启后的进程继续
+		return
+	}
+
+	if finishErr := r.adminRepo.FinishJob(ctx, job.ID, err); finishErr != nil {
+		logger.Logger.Error("Failed to finish admin job", zap.Int64("job_id", job.ID), zap.Error(finishErr))
+	}
+	if err != nil {
+		logger.Logger.Error("Admin job failed", zap.Int64("job_id", job.ID), zap.Error(err))
+		return
+	}
+	logger.Logger.Info("Admin job finished", zap.Int64("job_id", job.ID))
+}
+
+// rescan 从上次的进度继续，按批次重新拉取区间内的日志交给事件处理器
+func (r *Runner) rescan(ctx context.Context, job *model.AdminJob) error {
+	chain, ok := r.chains[job.ChainID]
+	if !ok {
+		return fmt.Errorf("chain %d is not configured", job.ChainID)
+	}
+	contract, ok := FindContract(chain, job.ContractAddress)
+	if !ok {
+		return fmt.Errorf("contract %s is not configured on chain %d", job.ContractAddress, job.ChainID)
+	}
+
+	cursor, err := r.repo.GetCursor(ctx, job.ChainID, job.ContractAddress)
+	if err != nil {
+		return fmt.Errorf("get cursor: %w", err)
+	}
+	if job.ToBlock > cursor.LastConfirmedBlock {
+		return fmt.Errorf("to_block %d is above last confirmed block %d", job.ToBlock, cursor.LastConfirmedBlock)
+	}
+
+	client, err := r.client(ctx, chain)
+	if err != nil {
+		return err
+	}
+	from := max(job.FromBlock, job.CurrentBlock+1)
+	if err := ScanRange(ctx, r.repo, client, chain, contract, from, job.ToBlock, func(block int64) error {
+		return r.adminRepo.UpdateJobProgress(ctx, job.ID, block)
+	}); err != nil {
+		return err
+	}
+
+	// 补录的事件可能落在已生成的快照之前，丢弃之后的快照由扫描器重新生成
+	if err := r.repo.DiscardSnapshots(ctx, job.ChainID, job.ContractAddress, job.FromBlock-1); err != nil {
+		return fmt.Errorf("discard snapshots: %w", err)
+	}
+	return nil
+}
+
+// ScanRange 按 batch_size 分批重新拉取 [from, to] 内合约的日志，以 confirmed 状态交给事件处理器。
+// 区间需要已确认，日志所在区块与已索引的区块头不一致时返回 ErrBlockHashMismatch。
+// 合约需要保持暂停，避免与扫描器同时写入持仓，每批开始前检查，未暂停时返回 repository.ErrCursorNotPaused。
+// progress 在每批处理完成后以该批的结束区块调用，可以为 nil
+func ScanRange(ctx context.Context, repo repository.ScannerRepository, client *ethclient.Client, chain config.Chain, contract config.Contract,
+	from, to int64, progress func(block int64) error) error {
+	processor := event.NewEventProcessor(repo, contract.Handlers)
+	address := common.HexToAddress(contract.Address)
+
+	chunkSize := int64(chain.BatchSize)
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+	for start := from; start <= to; start += chunkSize {
+		end := min(start+chunkSize-1, to)
+
+		cursor, err := repo.GetCursor(ctx, chain.ChainID, contract.Address)
+		if err != nil {
+			return fmt.Errorf("get cursor: %w", err)
+		}
+		if repository.ScanStatusName(cursor.ScanStatus) != "paused" {
+			return repository.ErrCursorNotPaused
+		}
+
+		logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: big.NewInt(start),
+			ToBlock:   big.NewInt(end),
+			Addresses: []common.Address{address},
+		})
+		if err != nil {
+			return fmt.Errorf("filter logs %d-%d: %w", start, end, err)
+		}
+
+		// 与已索引的区块头核对，避免把其他分叉上的日志写入
+		blocks, err := repo.GetBlocksInRange(ctx, chain.ChainID, start, end)
+		if err != nil {
+			return fmt.Errorf("get blocks %d-%d: %w", start, end, err)
+		}
+		hashes := make(map[int64]string, len(blocks))
+		times := make(map[int64]*time.Time, len(blocks))
+		for _, b := range blocks {
+			hashes[b.BlockNumber] = b.BlockHash
+			times[b.BlockNumber] = b.BlockTime
+		}
+		valid := logs[:0]
+		for _, log := range logs {
+			if log.Removed {
+				continue
+			}
+			if hash, ok := hashes[int64(log.BlockNumber)]; ok && !strings.EqualFold(hash, log.BlockHash.Hex()) {
+				return fmt.Errorf("%w: block %d", ErrBlockHashMismatch, log.BlockNumber)
+			}
+			valid = append(valid, log)
+		}
+
+		if len(valid) > 0 {
+			if err := processor.ProcessEvents(ctx, chain.ChainID, contract.Address, valid, times, repository.ConfirmationStatusConfirmed); err != nil {
+				return fmt.Errorf("process events %d-%d: %w", start, end, err)
+			}
+		}
+		if progress != nil {
+			if err := progress(end); err != nil {
+				return fmt.Errorf("update progress: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Runner) client(ctx context.Context, chain config.Chain) (*ethclient.Client, error) {
+	if client, ok := r.clients[chain.ChainID]; ok {
+		return client, nil
+	}
+	client, err := ethclient.DialContext(ctx, chain.RPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("connect to rpc: %w", err)
+	}
+	r.clients[chain.ChainID] = client
+	return client, nil
+}
+
+// FindContract 按地址（不区分大小写）查找链上配置的合约
+func FindContract(chain config.Chain, address string) (config.Contract, bool) {
+	for _, c := range chain.Contracts {
+		if strings.EqualFold(c.Address, address) {
+			return c, true
+		}
+	}
+	return config.Contract{}, false
+}
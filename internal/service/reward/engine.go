@@ -0,0 +1,288 @@
+package reward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/contracts"
+	"github.com/dijiacoder/staking-indexer/internal/service/event"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrParamsNotSynced 合约的奖励参数尚未从链上读取
+var ErrParamsNotSynced = errors.New("reward params not synced")
+
+// 奖励参数事件，读取参数之前的修改已经体现在参数中
+var paramEvents = map[string]bool{"SetStartBlock": true, "SetEndBlock": true, "SetZeroTokenPerBlock": true}
+
+// UserReward 用户在一个质押池中的奖励
+type UserReward struct {
+	PoolID            int64
+	StAmount          *big.Int
+	FinishedZeroToken *big.Int
+	// PendingZeroToken 已结算未领取的奖励，对应合约 user.pendingZeroToken
+	PendingZeroToken *big.Int
+	// Pending 到查询区块可领取的全部奖励，对应合约 pendingZeroTokenByBlockNumber
+	Pending *big.Int
+}
+
+// UserRewards 用户在合约中的奖励
+type UserRewards struct {
+	ChainID         int64
+	ContractAddress string
+	UserAddress     string
+	// BlockNumber 推算奖励的区块
+	BlockNumber int64
+	// StateBlock 重放日志的截止区块，不超过 last_confirmed_block
+	StateBlock int64
+	Rewards    []*UserReward
+}
+
+// Engine 从 raw_logs 重放合约的奖励记账，计算用户待领取的 ZeroToken，不访问 RPC。
+// 每个合约缓存重放到最近确认区块的账本，查询时只重放新增的日志；发现归档日志被补录或回滚时重新重放
+type Engine struct {
+	repo    repository.RewardRepository
+	mu      sync.Mutex
+	ledgers map[contractKey]*cachedLedger
+}
+
+type contractKey struct {
+	chainID int64
+	address string
+}
+
+type cachedLedger struct {
+	ledger      *Ledger
+	paramsBlock int64
+	applied     int64
+	last        *model.RawLog
+}
+
+func NewEngine(repo repository.RewardRepository) *Engine {
+	return &Engine{
+		repo:    repo,
+		ledgers: make(map[contractKey]*cachedLedger),
+	}
+}
+
+// PendingRewards 用户在 block 可领取的奖励。账本重放到 min(block, last_confirmed_block)，
+// 之后按合约 pendingZeroTokenByBlockNumber 的方式推算。block 为 nil 时使用最近确认区块，poolID 为 nil 时返回全部质押池
+func (e *Engine) PendingRewards(ctx context.Context, chainID int64, contractAddress string, user common.Address,
+	poolID *int64, block *int64) (*UserRewards, error) {
+	cursor, err := e.repo.GetCursor(ctx, chainID, contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("get cursor: %w", err)
+	}
+	target := cursor.LastConfirmedBlock
+	if block != nil {
+		target = *block
+	}
+	stateBlock := min(target, cursor.LastConfirmedBlock)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var ledger *Ledger
+	if stateBlock == cursor.LastConfirmedBlock {
+		cached, err := e.refresh(ctx, chainID, contractAddress, stateBlock)
+		if err != nil {
+			return nil, err
+		}
+		ledger = cached.ledger
+	} else {
+		// 历史区块不缓存
+		replayed, err := e.replay(ctx, chainID, contractAddress, nil, stateBlock)
+		if err != nil {
+			return nil, err
+		}
+		ledger = replayed.ledger
+	}
+
+	pools := ledger.UserPools(user)
+	if poolID != nil {
+		pools = []int64{*poolID}
+	}
+	result := &UserRewards{
+		ChainID:         chainID,
+		ContractAddress: contractAddress,
+		UserAddress:     user.Hex(),
+		BlockNumber:     target,
+		StateBlock:      stateBlock,
+		Rewards:         make([]*UserReward, 0, len(pools)),
+	}
+	for _, pid := range pools {
+		pending, err := ledger.PendingZeroToken(pid, user, big.NewInt(target))
+		if err != nil {
+			return nil, fmt.Errorf("pool %d: %w", pid, err)
+		}
+		u := ledger.User(pid, user)
+		result.Rewards = append(result.Rewards, &UserReward{
+			PoolID:            pid,
+			StAmount:          u.StAmount,
+			FinishedZeroToken: u.FinishedZeroToken,
+			PendingZeroToken:  u.PendingZeroToken,
+			Pending:           pending,
+		})
+	}
+	return result, nil
+}
+
+// refresh 把缓存的账本推进到 toBlock，缓存失效时重新重放
+func (e *Engine) refresh(ctx context.Context, chainID int64, contractAddress string, toBlock int64) (*cachedLedger, error) {
+	key := contractKey{chainID: chainID, address: contractAddress}
+	cached := e.ledgers[key]
+	if cached != nil {
+		valid, err := e.valid(ctx, chainID, contractAddress, cached)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			cached = nil
+		}
+	}
+	cached, err := e.replay(ctx, chainID, contractAddress, cached, toBlock)
+	if err != nil {
+		delete(e.ledgers, key)
+		return nil, err
+	}
+	e.ledgers[key] = cached
+	return cached, nil
+}
+
+// valid 已重放的日志没有被删除，之前也没有补录新的日志
+func (e *Engine) valid(ctx context.Context, chainID int64, contractAddress string, cached *cachedLedger) (bool, error) {
+	if cached.last == nil {
+		return true, nil
+	}
+	exists, err := e.repo.RawLogExists(ctx, cached.last.ID)
+	if err != nil || !exists {
+		return false, err
+	}
+	count, err := e.repo.CountRawLogs(ctx, chainID, contractAddress, repository.EventPosition{
+		BlockNumber: cached.last.BlockNumber,
+		LogIndex:    cached.last.LogIndex,
+	})
+	if err != nil {
+		return false, err
+	}
+	return count == cached.applied, nil
+}
+
+// replay 从 cached 之后按顺序重放到 toBlock 的归档日志，cached 为 nil 时从头开始
+func (e *Engine) replay(ctx context.Context, chainID int64, contractAddress string, cached *cachedLedger, toBlock int64) (*cachedLedger, error) {
+	if cached == nil {
+		params, err := e.repo.GetRewardParams(ctx, chainID, contractAddress)
+		if err != nil {
+			return nil, fmt.Errorf("get reward params: %w", err)
+		}
+		if params == nil {
+			return nil, fmt.Errorf("%w: chain %d contract %s", ErrParamsNotSynced, chainID, contractAddress)
+		}
+		perBlock, ok := new(big.Int).SetString(params.ZeroTokenPerBlock, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid zero_token_per_block %q", params.ZeroTokenPerBlock)
+		}
+		cached = &cachedLedger{
+			ledger: NewLedger(Params{
+				StartBlock:        big.NewInt(params.StartBlock),
+				EndBlock:          big.NewInt(params.EndBlock),
+				ZeroTokenPerBlock: perBlock,
+			}),
+			paramsBlock: params.BlockNumber,
+		}
+	}
+
+	// RawLogFilter.ToBlock 为 0 表示不限制
+	if toBlock <= 0 {
+		return cached, nil
+	}
+	filter := repository.RawLogFilter{
+		ChainID:         chainID,
+		ContractAddress: contractAddress,
+		ToBlock:         toBlock,
+		Limit:           repository.MaxPageSize,
+	}
+	stakingContract := contracts.NewStakingContract()
+	after := repository.EventPosition{BlockNumber: -1, LogIndex: -1}
+	if cached.last != nil {
+		after = repository.EventPosition{BlockNumber: cached.last.BlockNumber, LogIndex: cached.last.LogIndex}
+	}
+	for {
+		raws, err := e.repo.ListRawLogsAfterPosition(ctx, filter, after)
+		if err != nil {
+			return nil, fmt.Errorf("list raw logs: %w", err)
+		}
+		for _, raw := range raws {
+			log, err := event.FromRawLog(raw)
+			if err != nil {
+				return nil, err
+			}
+			var name string
+			if len(log.Topics) > 0 {
+				name, _ = stakingContract.GetEventName(log.Topics[0])
+			}
+			if !paramEvents[name] || raw.BlockNumber > cached.paramsBlock {
+				if err := cached.ledger.Apply(log); err != nil {
+					return nil, fmt.Errorf("apply log %s:%d: %w", raw.TxHash, raw.LogIndex, err)
+				}
+			}
+			cached.applied++
+			cached.last = raw
+		}
+		if len(raws) < repository.MaxPageSize {
+			return cached, nil
+		}
+		last := raws[len(raws)-1]
+		after = repository.EventPosition{BlockNumber: last.BlockNumber, LogIndex: last.LogIndex}
+	}
+}
+
+// SyncParams 在 block 通过 eth_call 读取 startBlock、endBlock 与 ZeroTokenPerBlock 写入 staking_reward_params，
+// 已同步的合约不再读取。block 之后的参数修改从归档日志重放，因此 block 应不晚于合约第一次修改参数
+func SyncParams(ctx context.Context, repo repository.RewardRepository, caller ethereum.ContractCaller,
+	chainID int64, contractAddress string, block int64) (*model.StakingRewardParam, error) {
+	params, err := repo.GetRewardParams(ctx, chainID, contractAddress)
+	if err != nil || params != nil {
+		return params, err
+	}
+
+	staking, err := contracts.NewStakingCaller(caller, common.HexToAddress(contractAddress))
+	if err != nil {
+		return nil, err
+	}
+	at := big.NewInt(block)
+	startBlock, err := staking.StartBlock(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+	endBlock, err := staking.EndBlock(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+	perBlock, err := staking.ZeroTokenPerBlock(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+	if !startBlock.IsInt64() || !endBlock.IsInt64() {
+		return nil, fmt.Errorf("reward block range %s-%s out of range", startBlock, endBlock)
+	}
+
+	params = &model.StakingRewardParam{
+		ChainID:           chainID,
+		ContractAddress:   contractAddress,
+		BlockNumber:       block,
+		StartBlock:        startBlock.Int64(),
+		EndBlock:          endBlock.Int64(),
+		ZeroTokenPerBlock: perBlock.String(),
+	}
+	if err := repo.SaveRewardParams(ctx, params); err != nil {
+		return nil, fmt.Errorf("save reward params: %w", err)
+	}
+	return repo.GetRewardParams(ctx, chainID, contractAddress)
+}
@@ -0,0 +1,491 @@
+package reward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/dijiacoder/staking-indexer/internal/service/contracts"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeChain 按合约源码逐行实现的质押合约，交易产生与链上一致的日志，
+// 通过 ethereum.ContractCaller 提供 pendingZeroTokenByBlockNumber 等视图函数，只支持最新状态
+type fakeChain struct {
+	abi     abi.ABI
+	address common.Address
+	block   uint64
+	state   *fakeState
+	logs    []types.Log
+	// 当前区块已产生的日志数，作为 log index
+	blockLogs uint
+}
+
+type fakeState struct {
+	startBlock        *big.Int
+	endBlock          *big.Int
+	zeroTokenPerBlock *big.Int
+	totalPoolWeight   *big.Int
+	pools             []*fakePool
+	users             map[userKey]*fakeUser
+}
+
+type fakePool struct {
+	stTokenAddress      common.Address
+	poolWeight          *big.Int
+	lastRewardBlock     *big.Int
+	accZeroTokenPerST   *big.Int
+	stTokenAmount       *big.Int
+	minDepositAmount    *big.Int
+	unstakeLockedBlocks *big.Int
+}
+
+type fakeUser struct {
+	stAmount          *big.Int
+	finishedZeroToken *big.Int
+	pendingZeroToken  *big.Int
+	requests          []fakeRequest
+}
+
+type fakeRequest struct {
+	amount       *big.Int
+	unlockBlocks *big.Int
+}
+
+var errRevert = errors.New("execution reverted")
+
+func newFakeChain(block uint64, startBlock, endBlock, perBlock int64) *fakeChain {
+	parsed, err := contracts.ParsedABI()
+	if err != nil {
+		panic(err)
+	}
+	return &fakeChain{
+		abi:     parsed,
+		address: common.HexToAddress("0x5a4e0000000000000000000000000000000000fe"),
+		block:   block,
+		state: &fakeState{
+			startBlock:        big.NewInt(startBlock),
+			endBlock:          big.NewInt(endBlock),
+			zeroTokenPerBlock: big.NewInt(perBlock),
+			totalPoolWeight:   new(big.Int),
+			users:             make(map[userKey]*fakeUser),
+		},
+	}
+}
+
+// mine 推进到下一个区块
+func (c *fakeChain) mine(n uint64) {
+	c.block += n
+	c.blockLogs = 0
+}
+
+// tx 执行一笔交易，revert 时状态和日志回滚
+func (c *fakeChain) tx(fn func(s *fakeState, emit func(name string, args ...interface{})) error) error {
+	state := c.state.clone()
+	var logs []types.Log
+	emit := func(name string, args ...interface{}) {
+		logs = append(logs, c.newLog(name, uint(len(logs))+c.blockLogs, args...))
+	}
+	if err := fn(state, emit); err != nil {
+		return err
+	}
+	c.state = state
+	c.logs = append(c.logs, logs...)
+	c.blockLogs += uint(len(logs))
+	return nil
+}
+
+func (c *fakeChain) newLog(name string, index uint, args ...interface{}) types.Log {
+	ev := c.abi.Events[name]
+	topics := []common.Hash{ev.ID}
+	var data []interface{}
+	for i, input := range ev.Inputs {
+		if !input.Indexed {
+			data = append(data, args[i])
+			continue
+		}
+		switch v := args[i].(type) {
+		case *big.Int:
+			topics = append(topics, common.BigToHash(v))
+		case common.Address:
+			topics = append(topics, common.BytesToHash(v.Bytes()))
+		default:
+			panic(fmt.Sprintf("unsupported topic %T", v))
+		}
+	}
+	packed, err := ev.Inputs.NonIndexed().Pack(data...)
+	if err != nil {
+		panic(err)
+	}
+	return types.Log{
+		Address:     c.address,
+		Topics:      topics,
+		Data:        packed,
+		BlockNumber: c.block,
+		BlockHash:   common.BigToHash(new(big.Int).SetUint64(c.block)),
+		TxHash:      common.BigToHash(big.NewInt(int64(len(c.logs) + 1))),
+		Index:       index,
+	}
+}
+
+func (c *fakeChain) number() *big.Int {
+	return new(big.Int).SetUint64(c.block)
+}
+
+func (c *fakeChain) addPool(stToken common.Address, weight, minDeposit, lockedBlocks int64, withUpdate bool) error {
+	return c.tx(func(s *fakeState, emit func(string, ...interface{})) error {
+		if weight <= 0 {
+			return errRevert
+		}
+		if withUpdate {
+			if err := s.massUpdatePools(c.number(), emit); err != nil {
+				return err
+			}
+		}
+		lastRewardBlock := c.number()
+		if lastRewardBlock.Cmp(s.startBlock) <= 0 {
+			lastRewardBlock = new(big.Int).Set(s.startBlock)
+		}
+		s.totalPoolWeight.Add(s.totalPoolWeight, big.NewInt(weight))
+		s.pools = append(s.pools, &fakePool{
+			stTokenAddress:      stToken,
+			poolWeight:          big.NewInt(weight),
+			lastRewardBlock:     lastRewardBlock,
+			accZeroTokenPerST:   new(big.Int),
+			stTokenAmount:       new(big.Int),
+			minDepositAmount:    big.NewInt(minDeposit),
+			unstakeLockedBlocks: big.NewInt(lockedBlocks),
+		})
+		emit("AddPool", big.NewInt(int64(len(s.pools)-1)), stToken, big.NewInt(weight), lastRewardBlock,
+			big.NewInt(minDeposit), big.NewInt(lockedBlocks))
+		return nil
+	})
+}
+
+func (c *fakeChain) setPoolWeight(pid int64, weight int64, withUpdate bool) error {
+	return c.tx(func(s *fakeState, emit func(string, ...interface{})) error {
+		if pid >= int64(len(s.pools)) || weight <= 0 {
+			return errRevert
+		}
+		if withUpdate {
+			if err := s.massUpdatePools(c.number(), emit); err != nil {
+				return err
+			}
+		}
+		pool := s.pools[pid]
+		s.totalPoolWeight.Sub(s.totalPoolWeight, pool.poolWeight)
+		s.totalPoolWeight.Add(s.totalPoolWeight, big.NewInt(weight))
+		pool.poolWeight = big.NewInt(weight)
+		emit("SetPoolWeight", big.NewInt(pid), big.NewInt(weight), new(big.Int).Set(s.totalPoolWeight))
+		return nil
+	})
+}
+
+func (c *fakeChain) setZeroTokenPerBlock(v int64) error {
+	return c.tx(func(s *fakeState, emit func(string, ...interface{})) error {
+		if v <= 0 {
+			return errRevert
+		}
+		s.zeroTokenPerBlock = big.NewInt(v)
+		emit("SetZeroTokenPerBlock", big.NewInt(v))
+		return nil
+	})
+}
+
+func (c *fakeChain) setStartBlock(v int64) error {
+	return c.tx(func(s *fakeState, emit func(string, ...interface{})) error {
+		if big.NewInt(v).Cmp(s.endBlock) > 0 {
+			return errRevert
+		}
+		s.startBlock = big.NewInt(v)
+		emit("SetStartBlock", big.NewInt(v))
+		return nil
+	})
+}
+
+func (c *fakeChain) setEndBlock(v int64) error {
+	return c.tx(func(s *fakeState, emit func(string, ...interface{})) error {
+		if s.startBlock.Cmp(big.NewInt(v)) > 0 {
+			return errRevert
+		}
+		s.endBlock = big.NewInt(v)
+		emit("SetEndBlock", big.NewInt(v))
+		return nil
+	})
+}
+
+func (c *fakeChain) updatePool(pid int64) error {
+	return c.tx(func(s *fakeState, emit func(string, ...interface{})) error {
+		if pid >= int64(len(s.pools)) {
+			return errRevert
+		}
+		return s.updatePool(pid, c.number(), emit)
+	})
+}
+
+func (c *fakeChain) massUpdatePools() error {
+	return c.tx(func(s *fakeState, emit func(string, ...interface{})) error {
+		return s.massUpdatePools(c.number(), emit)
+	})
+}
+
+func (c *fakeChain) deposit(user common.Address, pid int64, amount *big.Int) error {
+	return c.tx(func(s *fakeState, emit func(string, ...interface{})) error {
+		if pid >= int64(len(s.pools)) || amount.Cmp(s.pools[pid].minDepositAmount) < 0 {
+			return errRevert
+		}
+		if err := s.updatePool(pid, c.number(), emit); err != nil {
+			return err
+		}
+		pool, u := s.pools[pid], s.user(pid, user)
+		if u.stAmount.Sign() > 0 {
+			accST := mulDiv(u.stAmount, pool.accZeroTokenPerST, accPrecision)
+			if accST.Cmp(u.finishedZeroToken) < 0 {
+				return errRevert
+			}
+			pending := accST.Sub(accST, u.finishedZeroToken)
+			if pending.Sign() > 0 {
+				u.pendingZeroToken = new(big.Int).Add(u.pendingZeroToken, pending)
+			}
+		}
+		if amount.Sign() > 0 {
+			u.stAmount = new(big.Int).Add(u.stAmount, amount)
+		}
+		pool.stTokenAmount = new(big.Int).Add(pool.stTokenAmount, amount)
+		u.finishedZeroToken = mulDiv(u.stAmount, pool.accZeroTokenPerST, accPrecision)
+		emit("Deposit", user, big.NewInt(pid), amount)
+		return nil
+	})
+}
+
+func (c *fakeChain) unstake(user common.Address, pid int64, amount *big.Int) error {
+	return c.tx(func(s *fakeState, emit func(string, ...interface{})) error {
+		if pid >= int64(len(s.pools)) {
+			return errRevert
+		}
+		pool, u := s.pools[pid], s.user(pid, user)
+		if u.stAmount.Cmp(amount) < 0 {
+			return errRevert
+		}
+		if err := s.updatePool(pid, c.number(), emit); err != nil {
+			return err
+		}
+		pending := mulDiv(u.stAmount, pool.accZeroTokenPerST, accPrecision)
+		pending.Sub(pending, u.finishedZeroToken)
+		if pending.Sign() > 0 {
+			u.pendingZeroToken = new(big.Int).Add(u.pendingZeroToken, pending)
+		}
+		if amount.Sign() > 0 {
+			u.stAmount = new(big.Int).Sub(u.stAmount, amount)
+			u.requests = append(u.requests, fakeRequest{
+				amount:       new(big.Int).Set(amount),
+				unlockBlocks: new(big.Int).Add(c.number(), pool.unstakeLockedBlocks),
+			})
+		}
+		pool.stTokenAmount = new(big.Int).Sub(pool.stTokenAmount, amount)
+		u.finishedZeroToken = mulDiv(u.stAmount, pool.accZeroTokenPerST, accPrecision)
+		emit("RequestUnstake", user, big.NewInt(pid), amount)
+		return nil
+	})
+}
+
+func (c *fakeChain) withdraw(user common.Address, pid int64) error {
+	return c.tx(func(s *fakeState, emit func(string, ...interface{})) error {
+		if pid >= int64(len(s.pools)) {
+			return errRevert
+		}
+		u := s.user(pid, user)
+		amount := new(big.Int)
+		remaining := u.requests[:0:0]
+		for _, r := range u.requests {
+			if r.unlockBlocks.Cmp(c.number()) > 0 {
+				remaining = append(remaining, r)
+				continue
+			}
+			amount.Add(amount, r.amount)
+		}
+		u.requests = remaining
+		emit("Withdraw", user, big.NewInt(pid), amount, c.number())
+		return nil
+	})
+}
+
+func (c *fakeChain) claim(user common.Address, pid int64) error {
+	return c.tx(func(s *fakeState, emit func(string, ...interface{})) error {
+		if pid >= int64(len(s.pools)) {
+			return errRevert
+		}
+		if err := s.updatePool(pid, c.number(), emit); err != nil {
+			return err
+		}
+		pool, u := s.pools[pid], s.user(pid, user)
+		pending := mulDiv(u.stAmount, pool.accZeroTokenPerST, accPrecision)
+		pending.Sub(pending, u.finishedZeroToken)
+		pending.Add(pending, u.pendingZeroToken)
+		if pending.Sign() > 0 {
+			u.pendingZeroToken = new(big.Int)
+		}
+		u.finishedZeroToken = mulDiv(u.stAmount, pool.accZeroTokenPerST, accPrecision)
+		emit("Claim", user, big.NewInt(pid), pending)
+		return nil
+	})
+}
+
+func (s *fakeState) getMultiplier(from, to *big.Int) (*big.Int, error) {
+	if from.Cmp(to) > 0 {
+		return nil, errRevert
+	}
+	if from.Cmp(s.startBlock) < 0 {
+		from = s.startBlock
+	}
+	if to.Cmp(s.endBlock) > 0 {
+		to = s.endBlock
+	}
+	if from.Cmp(to) > 0 {
+		return nil, errRevert
+	}
+	n := new(big.Int).Sub(to, from)
+	return n.Mul(n, s.zeroTokenPerBlock), nil
+}
+
+func (s *fakeState) updatePool(pid int64, block *big.Int, emit func(string, ...interface{})) error {
+	pool := s.pools[pid]
+	if block.Cmp(pool.lastRewardBlock) <= 0 {
+		return nil
+	}
+	multiplier, err := s.getMultiplier(pool.lastRewardBlock, block)
+	if err != nil {
+		return err
+	}
+	if s.totalPoolWeight.Sign() == 0 {
+		return errRevert
+	}
+	totalZeroToken := mulDiv(multiplier, pool.poolWeight, s.totalPoolWeight)
+	if pool.stTokenAmount.Sign() > 0 {
+		perST := mulDiv(totalZeroToken, accPrecision, pool.stTokenAmount)
+		pool.accZeroTokenPerST = new(big.Int).Add(pool.accZeroTokenPerST, perST)
+	}
+	pool.lastRewardBlock = new(big.Int).Set(block)
+	emit("UpdatePool", big.NewInt(pid), new(big.Int).Set(block), totalZeroToken)
+	return nil
+}
+
+func (s *fakeState) massUpdatePools(block *big.Int, emit func(string, ...interface{})) error {
+	for pid := range s.pools {
+		if err := s.updatePool(int64(pid), block, emit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeState) pendingZeroTokenByBlockNumber(pid int64, user common.Address, block *big.Int) (*big.Int, error) {
+	if pid >= int64(len(s.pools)) {
+		return nil, errRevert
+	}
+	pool := s.pools[pid]
+	u := s.user(pid, user)
+	acc := new(big.Int).Set(pool.accZeroTokenPerST)
+	if block.Cmp(pool.lastRewardBlock) > 0 && pool.stTokenAmount.Sign() != 0 {
+		multiplier, err := s.getMultiplier(pool.lastRewardBlock, block)
+		if err != nil {
+			return nil, err
+		}
+		forPool := mulDiv(multiplier, pool.poolWeight, s.totalPoolWeight)
+		acc.Add(acc, mulDiv(forPool, accPrecision, pool.stTokenAmount))
+	}
+	pending := mulDiv(u.stAmount, acc, accPrecision)
+	pending.Sub(pending, u.finishedZeroToken)
+	return pending.Add(pending, u.pendingZeroToken), nil
+}
+
+func (s *fakeState) user(pid int64, address common.Address) *fakeUser {
+	key := userKey{poolID: pid, user: address}
+	u, ok := s.users[key]
+	if !ok {
+		u = &fakeUser{stAmount: new(big.Int), finishedZeroToken: new(big.Int), pendingZeroToken: new(big.Int)}
+		s.users[key] = u
+	}
+	return u
+}
+
+func (s *fakeState) clone() *fakeState {
+	out := &fakeState{
+		startBlock:        new(big.Int).Set(s.startBlock),
+		endBlock:          new(big.Int).Set(s.endBlock),
+		zeroTokenPerBlock: new(big.Int).Set(s.zeroTokenPerBlock),
+		totalPoolWeight:   new(big.Int).Set(s.totalPoolWeight),
+		users:             make(map[userKey]*fakeUser, len(s.users)),
+	}
+	for _, p := range s.pools {
+		cp := *p
+		out.pools = append(out.pools, &cp)
+	}
+	for k, u := range s.users {
+		cu := *u
+		cu.requests = append([]fakeRequest(nil), u.requests...)
+		out.users[k] = &cu
+	}
+	return out
+}
+
+// CallContract 实现 ethereum.ContractCaller，视图函数在最新状态上执行
+func (c *fakeChain) CallContract(ctx context.Context, msg ethereum.CallMsg, block *big.Int) ([]byte, error) {
+	if block != nil && block.Uint64() != c.block {
+		return nil, fmt.Errorf("fake chain only serves the latest block %d", c.block)
+	}
+	method, err := c.abi.MethodById(msg.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(msg.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	s := c.state
+	switch method.Name {
+	case "pendingZeroTokenByBlockNumber":
+		pending, err := s.pendingZeroTokenByBlockNumber(args[0].(*big.Int).Int64(), args[1].(common.Address), args[2].(*big.Int))
+		if err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(pending)
+	case "pendingZeroToken":
+		pending, err := s.pendingZeroTokenByBlockNumber(args[0].(*big.Int).Int64(), args[1].(common.Address), c.number())
+		if err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(pending)
+	case "pool":
+		pid := args[0].(*big.Int).Int64()
+		if pid >= int64(len(s.pools)) {
+			return nil, errRevert
+		}
+		p := s.pools[pid]
+		return method.Outputs.Pack(p.stTokenAddress, p.poolWeight, p.lastRewardBlock, p.accZeroTokenPerST,
+			p.stTokenAmount, p.minDepositAmount, p.unstakeLockedBlocks)
+	case "user":
+		u := s.user(args[0].(*big.Int).Int64(), args[1].(common.Address))
+		return method.Outputs.Pack(u.stAmount, u.finishedZeroToken, u.pendingZeroToken)
+	case "poolLength":
+		return method.Outputs.Pack(big.NewInt(int64(len(s.pools))))
+	case "totalPoolWeight":
+		return method.Outputs.Pack(s.totalPoolWeight)
+	case "startBlock":
+		return method.Outputs.Pack(s.startBlock)
+	case "endBlock":
+		return method.Outputs.Pack(s.endBlock)
+	case "ZeroTokenPerBlock":
+		return method.Outputs.Pack(s.zeroTokenPerBlock)
+	}
+	return nil, fmt.Errorf("fake chain does not implement %s", method.Name)
+}
+
+func mulDiv(a, b, d *big.Int) *big.Int {
+	n := new(big.Int).Mul(a, b)
+	return n.Quo(n, d)
+}
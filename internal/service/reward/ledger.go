@@ -0,0 +1,378 @@
+package reward
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/dijiacoder/staking-indexer/internal/service/contracts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// ErrInvalidBlockRange 与合约 getMultiplier 的 require 对应，区间在 [startBlock, endBlock] 截断后为空
+	ErrInvalidBlockRange = errors.New("invalid block range")
+	// ErrUnknownPool 日志或查询引用了尚未添加的质押池
+	ErrUnknownPool = errors.New("unknown pool")
+)
+
+// 1 ether，accZeroTokenPerST 的精度
+var accPrecision = big.NewInt(1e18)
+
+// Params 奖励参数，由 initialize 设置，之后通过 SetStartBlock / SetEndBlock / SetZeroTokenPerBlock 修改
+type Params struct {
+	StartBlock        *big.Int
+	EndBlock          *big.Int
+	ZeroTokenPerBlock *big.Int
+}
+
+// Pool 质押池的奖励状态，对应合约 pool(pid)
+type Pool struct {
+	PoolWeight        *big.Int
+	LastRewardBlock   *big.Int
+	AccZeroTokenPerST *big.Int
+	StTokenAmount     *big.Int
+}
+
+// User 用户在质押池中的奖励状态，对应合约 user(pid, address)
+type User struct {
+	StAmount          *big.Int
+	FinishedZeroToken *big.Int
+	PendingZeroToken  *big.Int
+}
+
+type userKey struct {
+	poolID int64
+	user   common.Address
+}
+
+// Ledger 按日志顺序重放合约的奖励记账：updatePool 按区块累加 accZeroTokenPerST，
+// Deposit / RequestUnstake / Claim 结算用户奖励。整数运算与合约一致，结果可以与 pendingZeroTokenByBlockNumber 逐位比对
+type Ledger struct {
+	params          Params
+	totalPoolWeight *big.Int
+	pools           []*Pool
+	users           map[userKey]*User
+	contract        *contracts.StakingContract
+}
+
+func NewLedger(params Params) *Ledger {
+	return &Ledger{
+		params: Params{
+			StartBlock:        cloneInt(params.StartBlock),
+			EndBlock:          cloneInt(params.EndBlock),
+			ZeroTokenPerBlock: cloneInt(params.ZeroTokenPerBlock),
+		},
+		totalPoolWeight: new(big.Int),
+		users:           make(map[userKey]*User),
+		contract:        contracts.NewStakingContract(),
+	}
+}
+
+// Apply 把一条合约日志计入奖励状态，与奖励无关的事件忽略。日志需按 (block_number, log_index) 顺序传入
+func (l *Ledger) Apply(log types.Log) error {
+	if len(log.Topics) == 0 {
+		return nil
+	}
+	name, ok := l.contract.GetEventName(log.Topics[0])
+	if !ok {
+		return nil
+	}
+	block := new(big.Int).SetUint64(log.BlockNumber)
+
+	switch name {
+	case "AddPool":
+		if len(log.Topics) < 4 || len(log.Data) < 32 {
+			return fmt.Errorf("malformed AddPool log")
+		}
+		poolID := topicInt(log.Topics[1])
+		if poolID.Cmp(big.NewInt(int64(len(l.pools)))) != 0 {
+			return fmt.Errorf("AddPool %s out of order, %d pools known", poolID, len(l.pools))
+		}
+		weight := topicInt(log.Topics[3])
+		l.totalPoolWeight.Add(l.totalPoolWeight, weight)
+		l.pools = append(l.pools, &Pool{
+			PoolWeight:        weight,
+			LastRewardBlock:   wordInt(log.Data, 0),
+			AccZeroTokenPerST: new(big.Int),
+			StTokenAmount:     new(big.Int),
+		})
+
+	case "SetPoolWeight":
+		if len(log.Topics) < 3 || len(log.Data) < 32 {
+			return fmt.Errorf("malformed SetPoolWeight log")
+		}
+		pool, err := l.pool(topicInt(log.Topics[1]))
+		if err != nil {
+			return err
+		}
+		pool.PoolWeight = topicInt(log.Topics[2])
+		l.totalPoolWeight = wordInt(log.Data, 0)
+
+	case "UpdatePool":
+		if len(log.Topics) < 2 {
+			return fmt.Errorf("malformed UpdatePool log")
+		}
+		pool, err := l.pool(topicInt(log.Topics[1]))
+		if err != nil {
+			return err
+		}
+		return l.updatePool(pool, block)
+
+	case "SetStartBlock":
+		if len(log.Topics) < 2 {
+			return fmt.Errorf("malformed SetStartBlock log")
+		}
+		l.params.StartBlock = topicInt(log.Topics[1])
+
+	case "SetEndBlock":
+		if len(log.Topics) < 2 {
+			return fmt.Errorf("malformed SetEndBlock log")
+		}
+		l.params.EndBlock = topicInt(log.Topics[1])
+
+	case "SetZeroTokenPerBlock":
+		if len(log.Topics) < 2 {
+			return fmt.Errorf("malformed SetZeroTokenPerBlock log")
+		}
+		l.params.ZeroTokenPerBlock = topicInt(log.Topics[1])
+
+	case "Deposit", "RequestUnstake", "Claim":
+		if len(log.Topics) < 3 || len(log.Data) < 32 {
+			return fmt.Errorf("malformed %s log", name)
+		}
+		poolID := topicInt(log.Topics[2])
+		pool, err := l.pool(poolID)
+		if err != nil {
+			return err
+		}
+		// 合约在结算前调用 updatePool，同一区块已更新时不重复累加
+		if err := l.updatePool(pool, block); err != nil {
+			return err
+		}
+		user := l.user(poolID.Int64(), common.BytesToAddress(log.Topics[1].Bytes()))
+		amount := wordInt(log.Data, 0)
+		switch name {
+		case "Deposit":
+			return deposit(pool, user, amount)
+		case "RequestUnstake":
+			return unstake(pool, user, amount)
+		default:
+			claim(pool, user)
+		}
+	}
+	return nil
+}
+
+// Params 当前的奖励参数
+func (l *Ledger) Params() Params {
+	return Params{
+		StartBlock:        cloneInt(l.params.StartBlock),
+		EndBlock:          cloneInt(l.params.EndBlock),
+		ZeroTokenPerBlock: cloneInt(l.params.ZeroTokenPerBlock),
+	}
+}
+
+// TotalPoolWeight 全部质押池的权重之和
+func (l *Ledger) TotalPoolWeight() *big.Int {
+	return cloneInt(l.totalPoolWeight)
+}
+
+// PoolLength 已添加的质押池数量
+func (l *Ledger) PoolLength() int {
+	return len(l.pools)
+}
+
+// Pool 质押池的当前状态
+func (l *Ledger) Pool(poolID int64) (*Pool, error) {
+	pool, err := l.pool(big.NewInt(poolID))
+	if err != nil {
+		return nil, err
+	}
+	return &Pool{
+		PoolWeight:        cloneInt(pool.PoolWeight),
+		LastRewardBlock:   cloneInt(pool.LastRewardBlock),
+		AccZeroTokenPerST: cloneInt(pool.AccZeroTokenPerST),
+		StTokenAmount:     cloneInt(pool.StTokenAmount),
+	}, nil
+}
+
+// User 用户的当前状态，没有记录时返回零值
+func (l *Ledger) User(poolID int64, user common.Address) *User {
+	u, ok := l.users[userKey{poolID: poolID, user: user}]
+	if !ok {
+		return &User{StAmount: new(big.Int), FinishedZeroToken: new(big.Int), PendingZeroToken: new(big.Int)}
+	}
+	return &User{
+		StAmount:          cloneInt(u.StAmount),
+		FinishedZeroToken: cloneInt(u.FinishedZeroToken),
+		PendingZeroToken:  cloneInt(u.PendingZeroToken),
+	}
+}
+
+// UserPools 用户有过记录的质押池，按 Pool ID 升序
+func (l *Ledger) UserPools(user common.Address) []int64 {
+	var pools []int64
+	for key := range l.users {
+		if key.user == user {
+			pools = append(pools, key.poolID)
+		}
+	}
+	sort.Slice(pools, func(i, j int) bool { return pools[i] < pools[j] })
+	return pools
+}
+
+// Multiplier 与合约 getMultiplier 一致：区间截断到 [startBlock, endBlock] 后乘以每区块奖励
+func (l *Ledger) Multiplier(from, to *big.Int) (*big.Int, error) {
+	if from.Cmp(to) > 0 {
+		return nil, fmt.Errorf("%w: from %s > to %s", ErrInvalidBlockRange, from, to)
+	}
+	if from.Cmp(l.params.StartBlock) < 0 {
+		from = l.params.StartBlock
+	}
+	if to.Cmp(l.params.EndBlock) > 0 {
+		to = l.params.EndBlock
+	}
+	if from.Cmp(to) > 0 {
+		return nil, fmt.Errorf("%w: end block must be greater than start block", ErrInvalidBlockRange)
+	}
+	n := new(big.Int).Sub(to, from)
+	return n.Mul(n, l.params.ZeroTokenPerBlock), nil
+}
+
+// PendingZeroToken 与合约 pendingZeroTokenByBlockNumber 一致：在当前状态下推算用户到 block 时可领取的奖励
+func (l *Ledger) PendingZeroToken(poolID int64, user common.Address, block *big.Int) (*big.Int, error) {
+	pool, err := l.pool(big.NewInt(poolID))
+	if err != nil {
+		return nil, err
+	}
+	acc := cloneInt(pool.AccZeroTokenPerST)
+	if block.Cmp(pool.LastRewardBlock) > 0 && pool.StTokenAmount.Sign() != 0 {
+		multiplier, err := l.Multiplier(pool.LastRewardBlock, block)
+		if err != nil {
+			return nil, err
+		}
+		if l.totalPoolWeight.Sign() == 0 {
+			return nil, errors.New("total pool weight is zero")
+		}
+		forPool := multiplier.Mul(multiplier, pool.PoolWeight)
+		forPool.Quo(forPool, l.totalPoolWeight)
+		forPool.Mul(forPool, accPrecision)
+		acc.Add(acc, forPool.Quo(forPool, pool.StTokenAmount))
+	}
+
+	u := l.User(poolID, user)
+	pending := accumulated(u.StAmount, acc)
+	pending.Sub(pending, u.FinishedZeroToken)
+	pending.Add(pending, u.PendingZeroToken)
+	if pending.Sign() < 0 {
+		return nil, fmt.Errorf("pending reward underflow for %s in pool %d", user.Hex(), poolID)
+	}
+	return pending, nil
+}
+
+// updatePool 与合约 updatePool 一致，把 lastRewardBlock 到 block 的奖励按权重分配给质押池
+func (l *Ledger) updatePool(pool *Pool, block *big.Int) error {
+	if block.Cmp(pool.LastRewardBlock) <= 0 {
+		return nil
+	}
+	total, err := l.Multiplier(pool.LastRewardBlock, block)
+	if err != nil {
+		return err
+	}
+	if l.totalPoolWeight.Sign() == 0 {
+		return errors.New("total pool weight is zero")
+	}
+	total.Mul(total, pool.PoolWeight)
+	total.Quo(total, l.totalPoolWeight)
+	if pool.StTokenAmount.Sign() > 0 {
+		total.Mul(total, accPrecision)
+		total.Quo(total, pool.StTokenAmount)
+		pool.AccZeroTokenPerST = new(big.Int).Add(pool.AccZeroTokenPerST, total)
+	}
+	pool.LastRewardBlock = cloneInt(block)
+	return nil
+}
+
+func (l *Ledger) pool(poolID *big.Int) (*Pool, error) {
+	if !poolID.IsInt64() || poolID.Int64() >= int64(len(l.pools)) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPool, poolID)
+	}
+	return l.pools[poolID.Int64()], nil
+}
+
+func (l *Ledger) user(poolID int64, address common.Address) *User {
+	key := userKey{poolID: poolID, user: address}
+	u, ok := l.users[key]
+	if !ok {
+		u = &User{StAmount: new(big.Int), FinishedZeroToken: new(big.Int), PendingZeroToken: new(big.Int)}
+		l.users[key] = u
+	}
+	return u
+}
+
+// deposit 先把已产生的奖励计入 pendingZeroToken，再增加质押数量
+func deposit(pool *Pool, user *User, amount *big.Int) error {
+	if user.StAmount.Sign() > 0 {
+		pending := accumulated(user.StAmount, pool.AccZeroTokenPerST)
+		if pending.Cmp(user.FinishedZeroToken) < 0 {
+			return errors.New("accST sub finishedZeroToken underflow")
+		}
+		pending.Sub(pending, user.FinishedZeroToken)
+		user.PendingZeroToken = new(big.Int).Add(user.PendingZeroToken, pending)
+	}
+	user.StAmount = new(big.Int).Add(user.StAmount, amount)
+	pool.StTokenAmount = new(big.Int).Add(pool.StTokenAmount, amount)
+	user.FinishedZeroToken = accumulated(user.StAmount, pool.AccZeroTokenPerST)
+	return nil
+}
+
+// unstake 对应 RequestUnstake：结算奖励后扣减质押数量，赎回的部分不再产生奖励
+func unstake(pool *Pool, user *User, amount *big.Int) error {
+	if user.StAmount.Cmp(amount) < 0 {
+		return errors.New("not enough staking token balance")
+	}
+	pending := accumulated(user.StAmount, pool.AccZeroTokenPerST)
+	if pending.Cmp(user.FinishedZeroToken) < 0 {
+		return errors.New("accST sub finishedZeroToken underflow")
+	}
+	pending.Sub(pending, user.FinishedZeroToken)
+	user.PendingZeroToken = new(big.Int).Add(user.PendingZeroToken, pending)
+	user.StAmount = new(big.Int).Sub(user.StAmount, amount)
+	pool.StTokenAmount = new(big.Int).Sub(pool.StTokenAmount, amount)
+	user.FinishedZeroToken = accumulated(user.StAmount, pool.AccZeroTokenPerST)
+	return nil
+}
+
+// claim 领取全部奖励，pendingZeroToken 清零
+func claim(pool *Pool, user *User) {
+	user.PendingZeroToken = new(big.Int)
+	user.FinishedZeroToken = accumulated(user.StAmount, pool.AccZeroTokenPerST)
+}
+
+// accumulated stAmount * acc / 1 ether
+func accumulated(stAmount, acc *big.Int) *big.Int {
+	n := new(big.Int).Mul(stAmount, acc)
+	return n.Quo(n, accPrecision)
+}
+
+func topicInt(topic common.Hash) *big.Int {
+	return new(big.Int).SetBytes(topic.Bytes())
+}
+
+// wordInt data 中第 i 个 32 字节字
+func wordInt(data []byte, i int) *big.Int {
+	if len(data) < (i+1)*32 {
+		return new(big.Int)
+	}
+	return new(big.Int).SetBytes(data[i*32 : (i+1)*32])
+}
+
+func cloneInt(n *big.Int) *big.Int {
+	if n == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(n)
+}
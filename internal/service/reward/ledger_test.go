@@ -0,0 +1,263 @@
+package reward
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/dijiacoder/staking-indexer/internal/service/contracts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var testUsers = []common.Address{
+	common.HexToAddress("0x00000000000000000000000000000000000000a1"),
+	common.HexToAddress("0x00000000000000000000000000000000000000a2"),
+	common.HexToAddress("0x00000000000000000000000000000000000000a3"),
+	common.HexToAddress("0x00000000000000000000000000000000000000a4"),
+}
+
+// simulate 在模拟链上随机执行交易，每执行一批就把新日志交给 ledger 并与链上视图比对
+func simulate(t *testing.T, seed uint64, steps int) (*fakeChain, *Ledger) {
+	t.Helper()
+	rng := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+	chain := newFakeChain(100, 120, 400, 1e15+rng.Int64N(1e18))
+	ledger := NewLedger(Params{
+		StartBlock:        new(big.Int).Set(chain.state.startBlock),
+		EndBlock:          new(big.Int).Set(chain.state.endBlock),
+		ZeroTokenPerBlock: new(big.Int).Set(chain.state.zeroTokenPerBlock),
+	})
+	if err := chain.addPool(common.Address{}, 1+rng.Int64N(500), 0, 5, false); err != nil {
+		t.Fatalf("add ETH pool: %v", err)
+	}
+
+	applied := 0
+	for step := 0; step < steps; step++ {
+		if rng.IntN(3) == 0 {
+			chain.mine(1 + rng.Uint64N(6))
+		}
+		user := testUsers[rng.IntN(len(testUsers))]
+		pid := rng.Int64N(int64(len(chain.state.pools)) + 1)
+		amount := big.NewInt(1 + rng.Int64N(5e18))
+		// revert 与链上一致，只是不产生日志
+		switch op := rng.IntN(100); {
+		case op < 30:
+			_ = chain.deposit(user, pid, amount)
+		case op < 45:
+			u := chain.state.user(pid, user)
+			if u.stAmount.Sign() > 0 && rng.IntN(2) == 0 {
+				amount = new(big.Int).Set(u.stAmount)
+			}
+			_ = chain.unstake(user, pid, amount)
+		case op < 55:
+			_ = chain.claim(user, pid)
+		case op < 60:
+			_ = chain.withdraw(user, pid)
+		case op < 68:
+			_ = chain.updatePool(pid)
+		case op < 71:
+			_ = chain.massUpdatePools()
+		case op < 76:
+			_ = chain.addPool(common.BigToAddress(big.NewInt(int64(step+1))), 1+rng.Int64N(500),
+				rng.Int64N(1e17), rng.Int64N(20), rng.IntN(2) == 0)
+		case op < 82:
+			_ = chain.setPoolWeight(pid, 1+rng.Int64N(500), rng.IntN(2) == 0)
+		case op < 86:
+			_ = chain.setZeroTokenPerBlock(1e15 + rng.Int64N(1e18))
+		case op < 88:
+			_ = chain.setStartBlock(int64(chain.block) - 20 + rng.Int64N(60))
+		case op < 90:
+			_ = chain.setEndBlock(int64(chain.block) + rng.Int64N(300))
+		default:
+			chain.mine(1 + rng.Uint64N(30))
+		}
+
+		for _, log := range chain.logs[applied:] {
+			if err := ledger.Apply(log); err != nil {
+				t.Fatalf("seed %d step %d: apply %x: %v", seed, step, log.Topics[0], err)
+			}
+		}
+		applied = len(chain.logs)
+		compareState(t, chain, ledger)
+	}
+	return chain, ledger
+}
+
+// compareState 逐个质押池、用户比对账本与链上视图，并在多个区块上比对 pendingZeroTokenByBlockNumber
+func compareState(t *testing.T, chain *fakeChain, ledger *Ledger) {
+	t.Helper()
+	ctx := context.Background()
+	caller, err := contracts.NewStakingCaller(chain, chain.address)
+	if err != nil {
+		t.Fatalf("new staking caller: %v", err)
+	}
+	at := chain.number()
+
+	if got, want := ledger.PoolLength(), len(chain.state.pools); got != want {
+		t.Fatalf("block %d: pool length %d, chain %d", chain.block, got, want)
+	}
+	if got := ledger.TotalPoolWeight(); got.Cmp(chain.state.totalPoolWeight) != 0 {
+		t.Fatalf("block %d: total pool weight %s, chain %s", chain.block, got, chain.state.totalPoolWeight)
+	}
+	params := ledger.Params()
+	if params.StartBlock.Cmp(chain.state.startBlock) != 0 || params.EndBlock.Cmp(chain.state.endBlock) != 0 ||
+		params.ZeroTokenPerBlock.Cmp(chain.state.zeroTokenPerBlock) != 0 {
+		t.Fatalf("block %d: params %v, chain %s-%s/%s", chain.block, params,
+			chain.state.startBlock, chain.state.endBlock, chain.state.zeroTokenPerBlock)
+	}
+
+	targets := []*big.Int{
+		at,
+		new(big.Int).Add(at, big.NewInt(1)),
+		new(big.Int).Add(at, big.NewInt(37)),
+		new(big.Int).Add(chain.state.endBlock, big.NewInt(50)),
+		new(big.Int).Sub(at, big.NewInt(10)),
+	}
+	for pid := range chain.state.pools {
+		want, err := caller.Pool(ctx, at, int64(pid))
+		if err != nil {
+			t.Fatalf("pool %d: %v", pid, err)
+		}
+		got, err := ledger.Pool(int64(pid))
+		if err != nil {
+			t.Fatalf("ledger pool %d: %v", pid, err)
+		}
+		if got.PoolWeight.Cmp(want.PoolWeight) != 0 || got.LastRewardBlock.Cmp(want.LastRewardBlock) != 0 ||
+			got.AccZeroTokenPerST.Cmp(want.AccZeroTokenPerST) != 0 || got.StTokenAmount.Cmp(want.StTokenAmount) != 0 {
+			t.Fatalf("block %d pool %d: ledger %+v, chain %+v", chain.block, pid, got, want)
+		}
+
+		for _, user := range testUsers {
+			wantUser, err := caller.User(ctx, at, int64(pid), user)
+			if err != nil {
+				t.Fatalf("user %s pool %d: %v", user.Hex(), pid, err)
+			}
+			gotUser := ledger.User(int64(pid), user)
+			if gotUser.StAmount.Cmp(wantUser.StAmount) != 0 || gotUser.FinishedZeroToken.Cmp(wantUser.FinishedZeroToken) != 0 ||
+				gotUser.PendingZeroToken.Cmp(wantUser.PendingZeroToken) != 0 {
+				t.Fatalf("block %d pool %d user %s: ledger %+v, chain %+v", chain.block, pid, user.Hex(), gotUser, wantUser)
+			}
+
+			for _, target := range targets {
+				want, wantErr := caller.PendingZeroTokenByBlockNumber(ctx, at, int64(pid), user, target)
+				got, gotErr := ledger.PendingZeroToken(int64(pid), user, target)
+				if (wantErr != nil) != (gotErr != nil) {
+					t.Fatalf("block %d pool %d user %s target %s: ledger err %v, chain err %v",
+						chain.block, pid, user.Hex(), target, gotErr, wantErr)
+				}
+				if wantErr == nil && got.Cmp(want) != 0 {
+					t.Fatalf("block %d pool %d user %s target %s: ledger %s, chain %s",
+						chain.block, pid, user.Hex(), target, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestLedgerMatchesPendingZeroTokenByBlockNumber(t *testing.T) {
+	for seed := uint64(1); seed <= 10; seed++ {
+		chain, _ := simulate(t, seed, 200)
+		if len(chain.logs) == 0 {
+			t.Fatalf("seed %d: no logs emitted", seed)
+		}
+	}
+}
+
+func TestLedgerPendingAfterEndBlock(t *testing.T) {
+	chain := newFakeChain(100, 100, 150, 1e18)
+	ledger := NewLedger(Params{StartBlock: big.NewInt(100), EndBlock: big.NewInt(150), ZeroTokenPerBlock: big.NewInt(1e18)})
+	user := testUsers[0]
+	if err := chain.addPool(common.Address{}, 100, 0, 5, false); err != nil {
+		t.Fatal(err)
+	}
+	chain.mine(10)
+	if err := chain.deposit(user, 0, big.NewInt(3e18)); err != nil {
+		t.Fatal(err)
+	}
+	for _, log := range chain.logs {
+		if err := ledger.Apply(log); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 区块 110 到 150 共 40 个区块的奖励，之后不再增加
+	want, _ := new(big.Int).SetString("40000000000000000000", 10)
+	for _, block := range []int64{150, 151, 1000} {
+		got, err := ledger.PendingZeroToken(0, user, big.NewInt(block))
+		if err != nil {
+			t.Fatalf("block %d: %v", block, err)
+		}
+		// 3e18 质押下 accZeroTokenPerST 的整除误差
+		if diff := new(big.Int).Sub(want, got); diff.Sign() < 0 || diff.Cmp(big.NewInt(3)) > 0 {
+			t.Fatalf("block %d: pending %s, want about %s", block, got, want)
+		}
+	}
+
+	// 越过 endBlock 之后 updatePool 与合约一样 revert
+	chain.mine(50)
+	if err := chain.claim(user, 0); err != nil {
+		t.Fatalf("claim at %d: %v", chain.block, err)
+	}
+	if err := ledger.Apply(chain.logs[len(chain.logs)-1]); err != nil {
+		t.Fatal(err)
+	}
+	chain.mine(1)
+	if err := chain.claim(user, 0); !errors.Is(err, errRevert) {
+		t.Fatalf("claim after end block: %v", err)
+	}
+	claim := chain.newLog("Claim", 0, user, big.NewInt(0), new(big.Int))
+	if err := ledger.Apply(claim); !errors.Is(err, ErrInvalidBlockRange) {
+		t.Fatalf("apply claim after end block: %v", err)
+	}
+	compareState(t, chain, ledger)
+}
+
+func TestLedgerMultiplier(t *testing.T) {
+	ledger := NewLedger(Params{StartBlock: big.NewInt(100), EndBlock: big.NewInt(200), ZeroTokenPerBlock: big.NewInt(7)})
+	cases := []struct {
+		from, to int64
+		want     int64
+		err      bool
+	}{
+		{from: 100, to: 200, want: 700},
+		{from: 50, to: 150, want: 350},
+		{from: 150, to: 300, want: 350},
+		{from: 0, to: 1000, want: 700},
+		{from: 120, to: 120, want: 0},
+		{from: 130, to: 120, err: true},
+		{from: 250, to: 300, err: true},
+		{from: 10, to: 50, err: true},
+	}
+	for _, c := range cases {
+		got, err := ledger.Multiplier(big.NewInt(c.from), big.NewInt(c.to))
+		if c.err {
+			if !errors.Is(err, ErrInvalidBlockRange) {
+				t.Errorf("Multiplier(%d, %d) error = %v, want ErrInvalidBlockRange", c.from, c.to, err)
+			}
+			continue
+		}
+		if err != nil || got.Int64() != c.want {
+			t.Errorf("Multiplier(%d, %d) = %v, %v, want %d", c.from, c.to, got, err, c.want)
+		}
+	}
+}
+
+func TestLedgerIgnoresUnrelatedLogs(t *testing.T) {
+	ledger := NewLedger(Params{StartBlock: big.NewInt(0), EndBlock: big.NewInt(10), ZeroTokenPerBlock: big.NewInt(1)})
+	if err := ledger.Apply(types.Log{}); err != nil {
+		t.Fatalf("log without topics: %v", err)
+	}
+	if err := ledger.Apply(types.Log{Topics: []common.Hash{common.HexToHash("0x01")}}); err != nil {
+		t.Fatalf("unknown event: %v", err)
+	}
+	chain := newFakeChain(1, 0, 10, 1)
+	if err := chain.deposit(testUsers[0], 0, big.NewInt(1)); !errors.Is(err, errRevert) {
+		t.Fatalf("deposit to missing pool: %v", err)
+	}
+	log := chain.newLog("Deposit", 0, testUsers[0], big.NewInt(0), big.NewInt(1))
+	if err := ledger.Apply(log); !errors.Is(err, ErrUnknownPool) {
+		t.Fatalf("deposit to missing pool: %v", err)
+	}
+}
@@ -2,88 +2,163 @@ package scanner
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/dijiacoder/staking-indexer/internal/gen/model"
 	"github.com/dijiacoder/staking-indexer/internal/logger"
 	"github.com/dijiacoder/staking-indexer/internal/repository"
-	"github.com/dijiacoder/staking-indexer/internal/service/event"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/core/types"
 	"go.uber.org/zap"
 )
 
+// ErrLogBlockHashMismatch 日志所属区块与已校验的区块头不一致，区块在两次请求之间发生了重组
+var ErrLogBlockHashMismatch = errors.New("log block hash does not match header")
+
+// blockClient BlockProcessor 使用的 RPC 方法，*ethclient.Client 满足该接口
+type blockClient interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// blockHeader 区块头及其 logs bloom，bloom 用于校验获取到的日志确实来自该区块
+type blockHeader struct {
+	*model.ChainBlock
+	bloom types.Bloom
+}
+
 type BlockProcessor struct {
-	repo           repository.ScannerRepository
-	client         *ethclient.Client
-	eventProcessor *event.Processor
+	repo   repository.ScannerRepository
+	client blockClient
 }
 
-func NewBlockProcessor(repo repository.ScannerRepository, client *ethclient.Client) (*BlockProcessor, error) {
+func NewBlockProcessor(repo repository.ScannerRepository, client blockClient) (*BlockProcessor, error) {
 	return &BlockProcessor{
-		repo:           repo,
-		client:         client,
-		eventProcessor: event.NewEventProcessor(repo),
+		repo:   repo,
+		client: client,
 	}, nil
 }
 
-func (p *BlockProcessor) ProcessBlock(ctx context.Context, chainID int64, contractAddress string, blockNumber int64) error {
-	// 1. Get block header to save to chain_blocks
-	header, err := p.client.HeaderByNumber(ctx, big.NewInt(blockNumber))
-	if err != nil {
-		return err
+// ProcessBlock 处理单个区块，header 为已经过 reorg 校验的区块头，日志必须全部属于该区块。
+// contracts 为游标尚未到达该区块的合约，一次 FilterLogs 获取全部合约日志后按地址分发。
+// confirmed 为 false 时区块高于安全高度，事件以 pending 状态写入。返回分发的日志数量
+func (p *BlockProcessor) ProcessBlock(ctx context.Context, chainID int64, contracts []*contractScanner, header *blockHeader, confirmed bool) (int, error) {
+	blockNumber := header.BlockNumber
+	blockHash := common.HexToHash(header.BlockHash)
+
+	addresses := make([]common.Address, 0, len(contracts))
+	for _, c := range contracts {
+		addresses = append(addresses, c.hexAddress())
 	}
 
-	// 2. Fetch logs for this contract in this block
+	// 1. Fetch logs for these contracts by block hash, so they can't come from another fork
 	query := ethereum.FilterQuery{
-		FromBlock: big.NewInt(blockNumber),
-		ToBlock:   big.NewInt(blockNumber),
-		Addresses: []common.Address{common.HexToAddress(contractAddress)},
+		BlockHash: &blockHash,
+		Addresses: addresses,
 	}
 	logs, err := p.client.FilterLogs(ctx, query)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	if len(logs) != 0 {
-
-		logger.Logger.Info("Found logs in block",
-			zap.Int("count", len(logs)),
-			zap.Int64("block_number", blockNumber),
-		)
-
-		// 3. 分发事件到处理器
-		if err := p.eventProcessor.ProcessEvents(ctx, chainID, contractAddress, logs); err != nil {
-			logger.Logger.Error("process events error", zap.Error(err))
-			return err
+	// 2. Verify logs against the verified header: 部分节点或代理会忽略 BlockHash 条件，
+	// 日志的区块哈希、区块高度必须与区块头一致，且地址和 topics 都必须命中区块头的 logs bloom
+	logsByAddress := make(map[common.Address][]types.Log, len(contracts))
+	for _, log := range logs {
+		if !logMatchesHeader(&log, blockNumber, blockHash, header.bloom) {
+			logger.Logger.Warn("Log does not match block header",
+				zap.Int64("block_number", blockNumber),
+				zap.String("header_hash", header.BlockHash),
+				zap.Uint64("log_block_number", log.BlockNumber),
+				zap.String("log_block_hash", log.BlockHash.Hex()),
+				zap.String("tx_hash", log.TxHash.Hex()),
+			)
+			return 0, fmt.Errorf("%w: block %d", ErrLogBlockHashMismatch, blockNumber)
 		}
+		logsByAddress[log.Address] = append(logsByAddress[log.Address], log)
 	}
 
-	// 4. Save block header for reorg detection
+	// 3. Save block header for reorg detection
+	var isConfirmed int32
+	if confirmed {
+		isConfirmed = 1
+	}
 	blockModel := &model.ChainBlock{
 		ChainID:     chainID,
 		BlockNumber: blockNumber,
-		BlockHash:   header.Hash().Hex(),
-		ParentHash:  header.ParentHash.Hex(),
-		IsConfirmed: 1,
+		BlockHash:   header.BlockHash,
+		ParentHash:  header.ParentHash,
+		IsConfirmed: isConfirmed,
+		BlockTime:   header.BlockTime,
 	}
 	if err := p.repo.SaveBlock(ctx, blockModel); err != nil {
 		logger.Logger.Error("save block error", zap.Error(err))
-		return err
+		return 0, err
 	}
 
-	return nil
+	// 4. 按合约分发事件到处理器
+	status := repository.ConfirmationStatusConfirmed
+	if !confirmed {
+		status = repository.ConfirmationStatusPending
+	}
+	blockTimes := map[int64]*time.Time{blockNumber: header.BlockTime}
+	dispatched := 0
+	for _, c := range contracts {
+		contractLogs := logsByAddress[c.hexAddress()]
+		if len(contractLogs) == 0 {
+			continue
+		}
+
+		logger.Logger.Info("Found logs in block",
+			zap.Int("count", len(contractLogs)),
+			zap.Int64("block_number", blockNumber),
+			zap.String("contract", c.address),
+		)
+
+		if err := c.eventProcessor.ProcessEvents(ctx, chainID, c.address, contractLogs, blockTimes, status); err != nil {
+			logger.Logger.Error("process events error", zap.Error(err))
+			return 0, err
+		}
+		dispatched += len(contractLogs)
+	}
+
+	return dispatched, nil
 }
 
-func (p *BlockProcessor) GetHeader(ctx context.Context, blockNumber int64) (*model.ChainBlock, error) {
+// GetHeader 按高度获取区块头，区块哈希由区块头内容计算
+func (p *BlockProcessor) GetHeader(ctx context.Context, blockNumber int64) (*blockHeader, error) {
 	header, err := p.client.HeaderByNumber(ctx, big.NewInt(blockNumber))
 	if err != nil {
 		return nil, err
 	}
-	return &model.ChainBlock{
-		BlockNumber: blockNumber,
-		BlockHash:   header.Hash().Hex(),
-		ParentHash:  header.ParentHash.Hex(),
+	blockTime := time.Unix(int64(header.Time), 0).UTC()
+	return &blockHeader{
+		ChainBlock: &model.ChainBlock{
+			BlockNumber: blockNumber,
+			BlockHash:   header.Hash().Hex(),
+			ParentHash:  header.ParentHash.Hex(),
+			BlockTime:   &blockTime,
+		},
+		bloom: header.Bloom,
 	}, nil
 }
+
+// logMatchesHeader 日志是否属于该区块头，bloom 没有假阴性，未命中说明日志来自其他区块
+func logMatchesHeader(log *types.Log, blockNumber int64, blockHash common.Hash, bloom types.Bloom) bool {
+	if log.BlockHash != blockHash || log.BlockNumber != uint64(blockNumber) {
+		return false
+	}
+	if !bloom.Test(log.Address.Bytes()) {
+		return false
+	}
+	for _, topic := range log.Topics {
+		if !bloom.Test(topic.Bytes()) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+)
+
+// fakeBlockClient 返回固定区块头和日志，不校验查询条件，模拟忽略 BlockHash 的节点
+type fakeBlockClient struct {
+	header *types.Header
+	logs   []types.Log
+}
+
+func (c *fakeBlockClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return c.header, nil
+}
+
+func (c *fakeBlockClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return c.logs, nil
+}
+
+// fakeBlockRepo 只记录保存的区块，其余方法不应被调用
+type fakeBlockRepo struct {
+	repository.ScannerRepository
+	saved []*model.ChainBlock
+}
+
+func (r *fakeBlockRepo) SaveBlock(ctx context.Context, block *model.ChainBlock) error {
+	r.saved = append(r.saved, block)
+	return nil
+}
+
+var (
+	testContract = common.HexToAddress("0x00000000000000000000000000000000000000c1")
+	testTopic    = common.HexToHash("0x01")
+)
+
+// newTestHeader 区块头的 bloom 包含 testContract 的一条日志
+func newTestHeader(number int64) *types.Header {
+	receipt := &types.Receipt{Logs: []*types.Log{{Address: testContract, Topics: []common.Hash{testTopic}}}}
+	return &types.Header{
+		Number:     big.NewInt(number),
+		ParentHash: common.HexToHash("0xaa"),
+		Bloom:      types.CreateBloom(receipt),
+	}
+}
+
+func TestProcessBlockRejectsLogsFromAnotherBlock(t *testing.T) {
+	logger.Logger = zap.NewNop()
+	const number = 100
+	header := newTestHeader(number)
+	other := newTestHeader(number)
+	other.ParentHash = common.HexToHash("0xbb")
+
+	valid := types.Log{Address: testContract, Topics: []common.Hash{testTopic}, BlockNumber: number, BlockHash: header.Hash()}
+	cases := []struct {
+		name string
+		log  func() types.Log
+	}{
+		{"other block hash", func() types.Log { l := valid; l.BlockHash = other.Hash(); return l }},
+		{"other block number", func() types.Log { l := valid; l.BlockNumber = number + 1; return l }},
+		{"topic not in bloom", func() types.Log { l := valid; l.Topics = []common.Hash{common.HexToHash("0x02")}; return l }},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &fakeBlockRepo{}
+			client := &fakeBlockClient{header: header, logs: []types.Log{tc.log()}}
+			p, err := NewBlockProcessor(repo, client)
+			if err != nil {
+				t.Fatal(err)
+			}
+			h, err := p.GetHeader(context.Background(), number)
+			if err != nil {
+				t.Fatal(err)
+			}
+			contracts := []*contractScanner{{address: testContract.Hex()}}
+			n, err := p.ProcessBlock(context.Background(), 1, contracts, h, true)
+			if !errors.Is(err, ErrLogBlockHashMismatch) {
+				t.Fatalf("err = %v, want ErrLogBlockHashMismatch", err)
+			}
+			if n != 0 || len(repo.saved) != 0 {
+				t.Fatalf("dispatched %d logs and saved %d blocks, want none", n, len(repo.saved))
+			}
+		})
+	}
+}
+
+func TestProcessBlockSavesMatchingBlock(t *testing.T) {
+	logger.Logger = zap.NewNop()
+	const number = 100
+	repo := &fakeBlockRepo{}
+	p, err := NewBlockProcessor(repo, &fakeBlockClient{header: newTestHeader(number)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := p.GetHeader(context.Background(), number)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.ProcessBlock(context.Background(), 1, []*contractScanner{{address: testContract.Hex()}}, h, true); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.saved) != 1 || repo.saved[0].BlockHash != h.BlockHash {
+		t.Fatalf("saved = %+v, want block %s", repo.saved, h.BlockHash)
+	}
+}
@@ -0,0 +1,44 @@
+package scanner
+
+import (
+	"fmt"
+
+	"github.com/dijiacoder/staking-indexer/internal/config"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/event"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// contractScanner 单个合约的扫描状态，同一条链上的合约共享区块头和 reorg 检测
+type contractScanner struct {
+	address        string
+	name           string
+	startBlock     int64
+	eventProcessor *event.Processor
+	labels         map[string]string
+}
+
+func newContractScanner(repo repository.ScannerRepository, chainID int64, c config.Contract) *contractScanner {
+	return &contractScanner{
+		address:        c.Address,
+		name:           c.Name,
+		startBlock:     c.StartBlock,
+		eventProcessor: event.NewEventProcessor(repo, c.Handlers),
+		labels: map[string]string{
+			"chain_id":         fmt.Sprintf("%d", chainID),
+			"contract_address": c.Address,
+		},
+	}
+}
+
+func (c *contractScanner) hexAddress() common.Address {
+	return common.HexToAddress(c.address)
+}
+
+func contractAddresses(contracts []*contractScanner) []string {
+	addresses := make([]string, 0, len(contracts))
+	for _, c := range contracts {
+		addresses = append(addresses, c.address)
+	}
+	return addresses
+}
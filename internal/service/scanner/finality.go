@@ -0,0 +1,63 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.uber.org/zap"
+)
+
+// 确认策略
+const (
+	// FinalityConfirmations latest - confirmations 作为安全高度
+	FinalityConfirmations = "confirmations"
+	// FinalitySafe 使用节点的 safe 区块标签
+	FinalitySafe = "safe"
+	// FinalityFinalized 使用节点的 finalized 区块标签
+	FinalityFinalized = "finalized"
+)
+
+// validateFinality 校验确认策略，未配置时使用 confirmations
+func validateFinality(finality string) (string, error) {
+	switch finality {
+	case "":
+		return FinalityConfirmations, nil
+	case FinalityConfirmations, FinalitySafe, FinalityFinalized:
+		return finality, nil
+	default:
+		return "", fmt.Errorf("unknown finality strategy: %q", finality)
+	}
+}
+
+// getSafeBlock 按确认策略计算可视为已确认的最高区块
+func (s *ScannerService) getSafeBlock(ctx context.Context, latestBlock uint64) (int64, error) {
+	var tag rpc.BlockNumber
+	switch s.finality {
+	case FinalitySafe:
+		tag = rpc.SafeBlockNumber
+	case FinalityFinalized:
+		tag = rpc.FinalizedBlockNumber
+	default:
+		return int64(latestBlock) - s.confirmations, nil
+	}
+
+	chainIDStr := fmt.Sprintf("%d", s.chainID)
+	method := "HeaderByNumber_" + s.finality
+
+	rpcStart := time.Now()
+	header, err := s.client.HeaderByNumber(ctx, big.NewInt(tag.Int64()))
+	metrics.RPCRequestsTotal.WithLabelValues(chainIDStr, method).Inc()
+	metrics.RPCDuration.WithLabelValues(chainIDStr, method).Observe(time.Since(rpcStart).Seconds())
+	if err != nil {
+		logger.Logger.Error("get tagged header error", zap.String("finality", s.finality), zap.Error(err))
+		metrics.RPCErrorsTotal.WithLabelValues(chainIDStr, method).Inc()
+		return 0, err
+	}
+
+	return header.Number.Int64(), nil
+}
@@ -0,0 +1,31 @@
+package scanner
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/dijiacoder/staking-indexer/internal/decimal"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// updatePoolMetrics 每轮扫描结束后从 staking_pools 刷新质押池总量指标，回滚后的数值也会在下一轮更新
+func (s *ScannerService) updatePoolMetrics(ctx context.Context, contracts []*contractScanner) {
+	chainID := strconv.FormatInt(s.chainID, 10)
+	for _, c := range contracts {
+		pools, err := s.repo.ListPools(ctx, s.chainID, c.address)
+		if err != nil {
+			logger.Logger.Error("list pools error", zap.Error(err), zap.String("contract", c.address))
+			continue
+		}
+		for _, p := range pools {
+			poolID := strconv.FormatInt(p.PoolID, 10)
+			metrics.PoolStTokenAmount.WithLabelValues(chainID, c.address, poolID).Set(decimal.Deref(p.StTokenAmount).Float64())
+			metrics.PoolPendingUnstake.WithLabelValues(chainID, c.address, poolID).Set(decimal.Deref(p.PendingUnstake).Float64())
+			if p.StakerCount != nil {
+				metrics.PoolStakers.WithLabelValues(chainID, c.address, poolID).Set(float64(*p.StakerCount))
+			}
+		}
+	}
+}
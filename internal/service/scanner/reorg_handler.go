@@ -2,28 +2,51 @@ package scanner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
 	"github.com/dijiacoder/staking-indexer/internal/logger"
 	"github.com/dijiacoder/staking-indexer/internal/metrics"
 	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/broadcast"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"go.uber.org/zap"
 )
 
+// defaultMaxReorgDepth 未配置 max_reorg_depth 时允许回滚的最大区块数
+const defaultMaxReorgDepth int64 = 64
+
+// ErrReorgTooDeep 在最大回滚深度内找不到共同祖先，扫描器需要停止等待人工介入
+var ErrReorgTooDeep = errors.New("common ancestor not found within max reorg depth")
+
+// ReorgHandler 检测区块重组并回滚链上所有合约的派生数据。
+// 回滚在单个事务内完成，失败时不留下修改，不经过回滚中状态；
+// scan_status = 2 只用于回滚深度超限、需要人工介入的情况
 type ReorgHandler struct {
-	repo   repository.ScannerRepository
-	client *ethclient.Client
+	repo          repository.ScannerRepository
+	client        *ethclient.Client
+	broadcaster   *broadcast.Broadcaster
+	maxReorgDepth int64
 }
 
-func NewReorgHandler(repo repository.ScannerRepository, client *ethclient.Client) *ReorgHandler {
-	return &ReorgHandler{repo: repo, client: client}
+func NewReorgHandler(repo repository.ScannerRepository, client *ethclient.Client, broadcaster *broadcast.Broadcaster, maxReorgDepth int64) *ReorgHandler {
+	if maxReorgDepth <= 0 {
+		maxReorgDepth = defaultMaxReorgDepth
+	}
+	return &ReorgHandler{repo: repo, client: client, broadcaster: broadcaster, maxReorgDepth: maxReorgDepth}
 }
 
 // CheckAndHandleReorg checks if a reorg occurred and handles it if necessary.
+// Block headers are shared by all contracts of a chain, so a reorg rolls back
+// every contract in contractAddresses.
 // returns true if a reorg was handled, false otherwise.
-func (h *ReorgHandler) CheckAndHandleReorg(ctx context.Context, chainID int64, contractAddress string,
+// The rollback itself runs in a single repository transaction (HandleReorg), so
+// cursors stay at scan_status = 1 while it runs and a failed rollback leaves no
+// changes behind. Only if no common ancestor exists within maxReorgDepth are the
+// cursors marked as rolling back (scan_status = 2) and ErrReorgTooDeep returned.
+func (h *ReorgHandler) CheckAndHandleReorg(ctx context.Context, chainID int64, contractAddresses []string,
 	currentBlockNumber int64, currentParentHash string) (bool, error) {
 	// 1. Get previous block from DB
 	prevBlock, err := h.repo.GetBlockByNumber(ctx, chainID, currentBlockNumber-1)
@@ -41,9 +64,12 @@ func (h *ReorgHandler) CheckAndHandleReorg(ctx context.Context, chainID int64, c
 			zap.String("parent_hash", currentParentHash),
 		)
 
-		// 3. Find common ancestor by walking back
+		// 3. Find common ancestor by searching stored headers
 		commonAncestor, err := h.findCommonAncestor(ctx, chainID, currentBlockNumber-1)
 		if err != nil {
+			if errors.Is(err, ErrReorgTooDeep) {
+				h.haltScanner(ctx, chainID, contractAddresses, currentBlockNumber, err)
+			}
 			return false, fmt.Errorf("failed to find common ancestor: %w", err)
 		}
 
@@ -54,42 +80,132 @@ func (h *ReorgHandler) CheckAndHandleReorg(ctx context.Context, chainID int64, c
 		// 记录回滚区块数
 		rollbackBlocks := currentBlockNumber - 1 - commonAncestor
 		if rollbackBlocks > 0 {
-			labels := map[string]string{
-				"chain_id":        fmt.Sprintf("%d", chainID),
-				"contract_address": contractAddress,
+			for _, contractAddress := range contractAddresses {
+				metrics.ReorgRollbackBlocks.With(reorgLabels(chainID, contractAddress)).Add(float64(rollbackBlocks))
 			}
-			metrics.ReorgRollbackBlocks.With(labels).Add(float64(rollbackBlocks))
 		}
 
 		// 4. Execute rollback in repository (atomic transaction)
-		if err := h.repo.HandleReorg(ctx, chainID, contractAddress, commonAncestor); err != nil {
+		if err := h.repo.HandleReorg(ctx, chainID, contractAddresses, commonAncestor); err != nil {
 			return false, fmt.Errorf("failed to handle reorg rollback: %w", err)
 		}
 
+		// 通知订阅者作废共同祖先之后的事件
+		h.broadcaster.PublishRetract(chainID, contractAddresses, commonAncestor)
+
 		return true, nil
 	}
 
 	return false, nil
 }
 
+// haltScanner 回滚深度超限：标记游标为回滚中并触发告警指标
+func (h *ReorgHandler) haltScanner(ctx context.Context, chainID int64, contractAddresses []string,
+	currentBlockNumber int64, cause error) {
+	logger.Logger.Error("Reorg deeper than max reorg depth, halting scanner",
+		zap.Int64("block", currentBlockNumber),
+		zap.Int64("max_reorg_depth", h.maxReorgDepth),
+		zap.Error(cause),
+	)
+
+	for _, contractAddress := range contractAddresses {
+		metrics.ReorgDepthExceeded.With(reorgLabels(chainID, contractAddress)).Set(1)
+
+		if err := h.repo.UpdateScanStatus(ctx, chainID, contractAddress, repository.ScanStatusRollingBack); err != nil {
+			logger.Logger.Error("update scan status error", zap.Error(err),
+				zap.String("contract", contractAddress),
+			)
+		}
+	}
+}
+
+func reorgLabels(chainID int64, contractAddress string) map[string]string {
+	return map[string]string{
+		"chain_id":         fmt.Sprintf("%d", chainID),
+		"contract_address": contractAddress,
+	}
+}
+
+// findCommonAncestor 在 maxReorgDepth 范围内查找本地与链上一致的最高区块。
+// 先按 1,2,4,... 的步长指数回退找到一个一致的区块，再在一致与分叉之间二分，
+// 本地区块头一次性批量读取，每次探测只需要一次 RPC。
+// 刚开始扫描时最早的区块头之前没有记录，最早的区块也不一致时回滚到它之前，从头重新扫描。
 func (h *ReorgHandler) findCommonAncestor(ctx context.Context, chainID int64, startBlock int64) (int64, error) {
-	current := startBlock
-	for current > 0 {
-		dbBlock, err := h.repo.GetBlockByNumber(ctx, chainID, current)
+	lowest := startBlock - h.maxReorgDepth
+	if lowest < 0 {
+		lowest = 0
+	}
+
+	stored, err := h.repo.GetBlocksInRange(ctx, chainID, lowest, startBlock)
+	if err != nil {
+		return 0, err
+	}
+	blocks := make(map[int64]*model.ChainBlock, len(stored))
+	for _, b := range stored {
+		blocks[b.BlockNumber] = b
+	}
+	// 区块头从第一个扫描的区块开始连续写入，不会向更低的区块查找
+	floor := lowest
+	if len(stored) > 0 && stored[0].BlockNumber > floor {
+		floor = stored[0].BlockNumber
+	}
+
+	isCanonical := func(blockNumber int64) (bool, error) {
+		dbBlock, ok := blocks[blockNumber]
+		if !ok {
+			// 缺失的区块无法验证，不能当作共同祖先
+			return false, fmt.Errorf("%w: block %d missing from chain_blocks", ErrReorgTooDeep, blockNumber)
+		}
+		header, err := h.client.HeaderByNumber(ctx, big.NewInt(blockNumber))
 		if err != nil {
-			// If we can't find it in DB, we've gone back far enough or something is wrong
-			return current, nil
+			return false, err
 		}
+		return dbBlock.BlockHash == header.Hash().Hex(), nil
+	}
 
-		header, err := h.client.HeaderByNumber(ctx, big.NewInt(current))
+	// 1. Exponential search: bad is known to be orphaned, find a canonical good below it
+	bad := startBlock
+	good := int64(-1)
+	for step := int64(1); ; step *= 2 {
+		probe := bad - step
+		if probe < floor {
+			probe = floor
+		}
+		if probe >= bad {
+			break
+		}
+		ok, err := isCanonical(probe)
 		if err != nil {
 			return 0, err
 		}
+		if ok {
+			good = probe
+			break
+		}
+		bad = probe
+	}
+	if good < 0 {
+		if floor > lowest {
+			logger.Logger.Warn("Reorg reaches the first stored block, rolling back to before it",
+				zap.Int64("first_stored_block", floor),
+			)
+			return floor - 1, nil
+		}
+		return 0, fmt.Errorf("%w: no matching block in [%d, %d]", ErrReorgTooDeep, lowest, startBlock)
+	}
 
-		if dbBlock.BlockHash == header.Hash().Hex() {
-			return current, nil
+	// 2. Binary search for the highest canonical block in (good, bad)
+	for bad-good > 1 {
+		mid := good + (bad-good)/2
+		ok, err := isCanonical(mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			good = mid
+		} else {
+			bad = mid
 		}
-		current--
 	}
-	return 0, nil
+	return good, nil
 }
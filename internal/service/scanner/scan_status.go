@@ -0,0 +1,61 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/metrics"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"go.uber.org/zap"
+)
+
+// ErrCursorRollingBack 游标停留在回滚中（scan_status = 2），回滚深度超限后等待人工介入
+var ErrCursorRollingBack = errors.New("cursor is stuck in rolling back state")
+
+// IsHaltError 需要人工介入、不能自动重启的扫描错误
+func IsHaltError(err error) bool {
+	return errors.Is(err, ErrReorgTooDeep) || errors.Is(err, ErrCursorRollingBack)
+}
+
+// cursorStatus 返回游标扫描状态，未设置时视为正常
+func cursorStatus(cursor *model.ChainScanCursor) int32 {
+	if cursor.ScanStatus == nil {
+		return repository.ScanStatusNormal
+	}
+	return *cursor.ScanStatus
+}
+
+// checkRollingBack 启动时检查游标是否停留在回滚中。
+// recoverRollback 开启时恢复为正常状态，由下一轮扫描的 parent hash 校验重新触发回滚
+func (s *ScannerService) checkRollingBack(ctx context.Context) error {
+	for _, c := range s.contracts {
+		cursor, err := s.repo.GetCursor(ctx, s.chainID, c.address)
+		if err != nil {
+			return fmt.Errorf("get cursor for contract %s: %w", c.address, err)
+		}
+		if cursorStatus(cursor) != repository.ScanStatusRollingBack {
+			continue
+		}
+
+		if !s.recoverRollback {
+			logger.Logger.Error("Cursor is stuck in rolling back state, set scan_status = 1 after verifying data or enable recover_rollback",
+				zap.String("contract", c.address),
+				zap.Int64("last_scanned_block", cursor.LastScannedBlock),
+			)
+			return fmt.Errorf("%w: contract %s", ErrCursorRollingBack, c.address)
+		}
+
+		logger.Logger.Warn("Recovering cursor stuck in rolling back state",
+			zap.String("contract", c.address),
+			zap.Int64("last_scanned_block", cursor.LastScannedBlock),
+		)
+		if err := s.repo.UpdateScanStatus(ctx, s.chainID, c.address, repository.ScanStatusNormal); err != nil {
+			return fmt.Errorf("recover cursor for contract %s: %w", c.address, err)
+		}
+		metrics.ReorgDepthExceeded.With(c.labels).Set(0)
+	}
+	return nil
+}
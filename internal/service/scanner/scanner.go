@@ -2,13 +2,16 @@ package scanner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/dijiacoder/staking-indexer/internal/config"
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
 	"github.com/dijiacoder/staking-indexer/internal/logger"
 	"github.com/dijiacoder/staking-indexer/internal/metrics"
 	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/broadcast"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"go.uber.org/zap"
 )
@@ -18,35 +21,73 @@ type ScannerService struct {
 	client        *ethclient.Client
 	processor     *BlockProcessor
 	reorgHandler  *ReorgHandler
+	broadcaster   *broadcast.Broadcaster
 	chainID       int64
-	contractAddr  string
+	contracts     []*contractScanner
 	confirmations int64
-	batchSize     int
-	scanInterval  time.Duration
-	scanTimeout   time.Duration
+	finality      string
+	indexPending  bool
+	// recoverRollback 启动时自动恢复停留在回滚中的游标
+	recoverRollback bool
+	batchSize       int
+	// snapshotInterval 持仓快照间隔（区块数），0 表示不生成
+	snapshotInterval int64
+	scanInterval     time.Duration
+	scanTimeout      time.Duration
+
+	// targetBlock 最近一轮扫描的目标高度
+	targetBlock int64
+	// onScanned 每轮扫描成功后回调，上报目标高度，为 nil 时不上报
+	onScanned func(targetBlock int64)
 }
 
 func NewScannerService(
 	repo repository.ScannerRepository,
 	client *ethclient.Client,
-	cfg *config.Config,
+	chain config.Chain,
+	broadcaster *broadcast.Broadcaster,
 ) (*ScannerService, error) {
 	processor, err := NewBlockProcessor(repo, client)
 	if err != nil {
 		return nil, err
 	}
 
+	finality, err := validateFinality(chain.Finality)
+	if err != nil {
+		return nil, err
+	}
+
+	if finality == FinalityConfirmations && chain.Confirmations <= 0 {
+		// 确认数为 0 时链头区块直接作为已确认区块写入，发生重组需要回滚已确认数据
+		logger.Logger.Warn("Confirmations is 0, chain head blocks are treated as confirmed",
+			zap.Int64("chain_id", chain.ChainID),
+		)
+	}
+
+	if len(chain.Contracts) == 0 {
+		return nil, fmt.Errorf("no contracts configured for chain %d", chain.ChainID)
+	}
+	contracts := make([]*contractScanner, 0, len(chain.Contracts))
+	for _, c := range chain.Contracts {
+		contracts = append(contracts, newContractScanner(repo, chain.ChainID, c))
+	}
+
 	return &ScannerService{
-		repo:          repo,
-		client:        client,
-		processor:     processor,
-		reorgHandler:  NewReorgHandler(repo, client),
-		chainID:       cfg.Ethereum.ChainID,
-		contractAddr:  cfg.Ethereum.ContractAddr,
-		confirmations: cfg.Ethereum.Confirmations,
-		batchSize:     cfg.Scanner.BatchSize,
-		scanInterval:  time.Duration(cfg.Scanner.ScanInterval) * time.Second,
-		scanTimeout:   time.Duration(cfg.Scanner.ScanTimeout) * time.Second,
+		repo:             repo,
+		client:           client,
+		processor:        processor,
+		reorgHandler:     NewReorgHandler(repo, client, broadcaster, chain.MaxReorgDepth),
+		broadcaster:      broadcaster,
+		chainID:          chain.ChainID,
+		contracts:        contracts,
+		confirmations:    chain.Confirmations,
+		finality:         finality,
+		indexPending:     chain.IndexPending != nil && *chain.IndexPending,
+		recoverRollback:  chain.RecoverRollback != nil && *chain.RecoverRollback,
+		batchSize:        chain.BatchSize,
+		snapshotInterval: chain.SnapshotInterval,
+		scanInterval:     time.Duration(chain.ScanInterval) * time.Second,
+		scanTimeout:      time.Duration(chain.ScanTimeout) * time.Second,
 	}, nil
 }
 
@@ -54,39 +95,92 @@ func NewScannerService(
 func (s *ScannerService) Start(ctx context.Context) error {
 	logger.Logger.Info("Starting scanner",
 		zap.Int64("chain_id", s.chainID),
-		zap.String("contract", s.contractAddr),
+		zap.Strings("contracts", contractAddresses(s.contracts)),
+		zap.String("finality", s.finality),
 	)
 
+	if err := s.initCursors(ctx); err != nil {
+		return err
+	}
+	if err := s.checkRollingBack(ctx); err != nil {
+		return err
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
 			if err := s.scan(ctx); err != nil {
+				if IsHaltError(err) {
+					return err
+				}
 				logger.Logger.Error("Scan error", zap.Error(err))
+			} else if s.onScanned != nil {
+				s.onScanned(s.targetBlock)
 			}
 			time.Sleep(s.scanInterval)
 		}
 	}
 }
 
-func (s *ScannerService) scan(ctx context.Context) error {
+// initCursors 为每个合约创建游标，新合约从 start_block 开始扫描
+func (s *ScannerService) initCursors(ctx context.Context) error {
+	for _, c := range s.contracts {
+		startFrom := c.startBlock - 1
+		if startFrom < 0 {
+			startFrom = 0
+		}
+		if _, err := s.repo.EnsureCursor(ctx, &model.ChainScanCursor{
+			ChainID:            s.chainID,
+			ContractAddress:    c.address,
+			ContractName:       c.name,
+			LastScannedBlock:   startFrom,
+			LastConfirmedBlock: startFrom,
+		}); err != nil {
+			return fmt.Errorf("init cursor for contract %s: %w", c.address, err)
+		}
+	}
+	return nil
+}
+
+func (s *ScannerService) scan(ctx context.Context) (err error) {
 	scanCtx, cancel := context.WithTimeout(ctx, s.scanTimeout)
 	defer cancel()
 
-	labels := map[string]string{
-		"chain_id":        fmt.Sprintf("%d", s.chainID),
-		"contract_address": s.contractAddr,
-	}
-
 	chainIDStr := fmt.Sprintf("%d", s.chainID)
 	startTime := time.Now()
 
-	// 1. Get current cursor from DB
-	cursor, err := s.repo.GetCursor(scanCtx, s.chainID, s.contractAddr)
-	if err != nil {
-		logger.Logger.Error("get cursor error", zap.Error(err))
-		return err
+	// 1. Get current cursors from DB, scanning starts from the slowest running contract
+	lastScanned := make(map[*contractScanner]int64, len(s.contracts))
+	running := make([]*contractScanner, 0, len(s.contracts))
+	fromBlock := int64(-1)
+	for _, c := range s.contracts {
+		cursor, err := s.repo.GetCursor(scanCtx, s.chainID, c.address)
+		if err != nil {
+			logger.Logger.Error("get cursor error", zap.Error(err), zap.String("contract", c.address))
+			return err
+		}
+
+		// 每轮检查 scan_status，作为合约级别的开关
+		status := cursorStatus(cursor)
+		metrics.ScanStatus.With(c.labels).Set(float64(status))
+		switch status {
+		case repository.ScanStatusPaused:
+			logger.Logger.Debug("Contract scanner paused", zap.String("contract", c.address))
+			continue
+		case repository.ScanStatusRollingBack:
+			return fmt.Errorf("%w: contract %s", ErrCursorRollingBack, c.address)
+		}
+
+		running = append(running, c)
+		lastScanned[c] = cursor.LastScannedBlock
+		if fromBlock < 0 || cursor.LastScannedBlock+1 < fromBlock {
+			fromBlock = cursor.LastScannedBlock + 1
+		}
+	}
+	if len(running) == 0 {
+		return nil // All contracts paused
 	}
 
 	// 2. Get latest block number from the chain
@@ -101,35 +195,60 @@ func (s *ScannerService) scan(ctx context.Context) error {
 	}
 
 	// 3. Calculate the highest safe block we can process
-	safeBlock := int64(latestBlock) - s.confirmations
+	safeBlock, err := s.getSafeBlock(scanCtx, latestBlock)
+	if err != nil {
+		return err
+	}
+
+	// 开启 pending 模式时扫描到链头，高于 safeBlock 的区块以 pending 状态写入
+	targetBlock := safeBlock
+	if s.indexPending {
+		targetBlock = int64(latestBlock)
+	}
+	s.targetBlock = targetBlock
 
 	// 更新区块高度指标
-	metrics.ChainLatestBlock.With(labels).Set(float64(latestBlock))
-	metrics.SafeBlock.With(labels).Set(float64(safeBlock))
-	metrics.CurrentScannedBlock.With(labels).Set(float64(cursor.LastScannedBlock))
-	metrics.SyncLag.With(labels).Set(float64(safeBlock - cursor.LastScannedBlock))
+	for _, c := range running {
+		metrics.ChainLatestBlock.With(c.labels).Set(float64(latestBlock))
+		metrics.SafeBlock.With(c.labels).Set(float64(safeBlock))
+		metrics.CurrentScannedBlock.With(c.labels).Set(float64(lastScanned[c]))
+		metrics.SyncLag.With(c.labels).Set(float64(targetBlock - lastScanned[c]))
+	}
 
-	if safeBlock <= cursor.LastScannedBlock {
+	// 快照在确认之后生成，defer 按注册的相反顺序执行
+	defer s.updatePoolMetrics(ctx, running)
+	defer s.snapshotPositions(ctx, running)
+	// 本轮出错、处理了重组或日志与区块头不一致时，pending 数据可能来自已放弃的分叉，不做提升
+	restart := false
+	if s.indexPending {
+		defer func() {
+			if err == nil && !restart {
+				s.promoteConfirmed(ctx, running, safeBlock)
+			}
+		}()
+	}
+
+	if targetBlock < fromBlock {
 		return nil // Up to date
 	}
 
-	// 4. Batch process blocks up to safeBlock
-	endBlock := safeBlock
-	if endBlock > cursor.LastScannedBlock+int64(s.batchSize) {
-		endBlock = cursor.LastScannedBlock + int64(s.batchSize)
+	// 4. Batch process blocks up to targetBlock
+	endBlock := targetBlock
+	if endBlock > fromBlock-1+int64(s.batchSize) {
+		endBlock = fromBlock - 1 + int64(s.batchSize)
 	}
 
 	logger.Logger.Info("Scanning blocks",
-		zap.Int64("from", cursor.LastScannedBlock+1),
+		zap.Int64("from", fromBlock),
 		zap.Int64("to", endBlock),
 		zap.Uint64("latest", latestBlock),
 		zap.Int64("safe", safeBlock),
 	)
 
 	blocksProcessed := 0
-	for nextBlock := cursor.LastScannedBlock + 1; nextBlock <= endBlock; nextBlock++ {
+	for nextBlock := fromBlock; nextBlock <= endBlock; nextBlock++ {
 		logger.Logger.Debug("Scanning block", zap.Int64("block", nextBlock))
-		// A. Fetch current block header for reorg verification
+		// A. Fetch current block header for reorg verification, once for all contracts
 		headerStart := time.Now()
 		header, err := s.processor.GetHeader(scanCtx, nextBlock)
 		metrics.RPCRequestsTotal.WithLabelValues(chainIDStr, "GetHeader").Inc()
@@ -141,7 +260,7 @@ func (s *ScannerService) scan(ctx context.Context) error {
 		}
 
 		// B. Verify chain continuity (Reorg Detection)
-		reorged, err := s.reorgHandler.CheckAndHandleReorg(scanCtx, s.chainID, s.contractAddr, nextBlock, header.ParentHash)
+		reorged, err := s.reorgHandler.CheckAndHandleReorg(scanCtx, s.chainID, contractAddresses(s.contracts), nextBlock, header.ParentHash)
 		if err != nil {
 			return fmt.Errorf("reorg check failed at block %d: %w", nextBlock, err)
 		}
@@ -150,32 +269,105 @@ func (s *ScannerService) scan(ctx context.Context) error {
 			logger.Logger.Info("Reorg handled, restarting scan loop",
 				zap.Int64("block", nextBlock),
 			)
-			metrics.ReorgTotal.With(labels).Inc()
-			metrics.LastReorgBlock.With(labels).Set(float64(nextBlock))
+			for _, c := range running {
+				metrics.ReorgTotal.With(c.labels).Inc()
+				metrics.LastReorgBlock.With(c.labels).Set(float64(nextBlock))
+			}
+			restart = true
 			return nil // Exit scan to let next iteration start from new cursor
 		}
 
-		// C. Process events in the block
-		if err := s.processor.ProcessBlock(scanCtx, s.chainID, s.contractAddr, nextBlock); err != nil {
+		// C. Process events in the block for contracts whose cursor hasn't reached it
+		active := make([]*contractScanner, 0, len(running))
+		for _, c := range running {
+			if lastScanned[c] < nextBlock {
+				active = append(active, c)
+			}
+		}
+
+		confirmed := nextBlock <= safeBlock
+		dispatched, err := s.processor.ProcessBlock(scanCtx, s.chainID, active, header, confirmed)
+		if err != nil {
+			if errors.Is(err, ErrLogBlockHashMismatch) {
+				// 区块在获取区块头之后被重组，下一轮重新获取区块头并做 reorg 校验
+				logger.Logger.Warn("Block changed while fetching logs, restarting scan loop",
+					zap.Int64("block", nextBlock),
+				)
+				for _, c := range active {
+					metrics.LogBlockHashMismatchTotal.With(c.labels).Inc()
+				}
+				restart = true
+				return nil
+			}
 			return fmt.Errorf("failed to process block %d: %w", nextBlock, err)
 		}
 
-		// D. Update cursor in DB
-		if err := s.repo.UpdateCursor(ctx, s.chainID, s.contractAddr, nextBlock, nextBlock); err != nil {
-			return fmt.Errorf("failed to update cursor at block %d: %w", nextBlock, err)
+		// D. Update cursors in DB
+		lastConfirmed := nextBlock
+		if !confirmed {
+			lastConfirmed = safeBlock
+		}
+		for _, c := range active {
+			if err := s.repo.UpdateCursor(ctx, s.chainID, c.address, nextBlock, lastConfirmed); err != nil {
+				return fmt.Errorf("failed to update cursor of %s at block %d: %w", c.address, nextBlock, err)
+			}
+			lastScanned[c] = nextBlock
+
+			// 更新当前扫描区块指标
+			metrics.CurrentScannedBlock.With(c.labels).Set(float64(nextBlock))
+			metrics.SyncLag.With(c.labels).Set(float64(targetBlock - nextBlock))
 		}
 
-		// 更新当前扫描区块指标
-		metrics.CurrentScannedBlock.With(labels).Set(float64(nextBlock))
-		metrics.SyncLag.With(labels).Set(float64(safeBlock - nextBlock))
+		// E. 区块提交后通知订阅者
+		if dispatched > 0 && s.broadcaster != nil {
+			s.publishBlockEvents(ctx, active, nextBlock)
+		}
 		blocksProcessed++
 	}
 
 	// 计算每秒处理区块数
 	duration := time.Since(startTime).Seconds()
 	if duration > 0 && blocksProcessed > 0 {
-		metrics.BlocksPerSecond.With(labels).Set(float64(blocksProcessed) / duration)
+		for _, c := range running {
+			metrics.BlocksPerSecond.With(c.labels).Set(float64(blocksProcessed) / duration)
+		}
 	}
 
 	return nil
 }
+
+// promoteConfirmed 将深度已达到确认数的 pending 区块和事件提升为 confirmed
+func (s *ScannerService) promoteConfirmed(ctx context.Context, contracts []*contractScanner, safeBlock int64) {
+	for _, c := range contracts {
+		cursor, err := s.repo.GetCursor(ctx, s.chainID, c.address)
+		if err != nil {
+			logger.Logger.Error("get cursor error", zap.Error(err), zap.String("contract", c.address))
+			continue
+		}
+
+		confirmedBlock := safeBlock
+		if confirmedBlock > cursor.LastScannedBlock {
+			confirmedBlock = cursor.LastScannedBlock
+		}
+
+		promoted, err := s.repo.ConfirmBlocks(ctx, s.chainID, c.address, confirmedBlock)
+		if err != nil {
+			logger.Logger.Error("confirm blocks error", zap.Error(err),
+				zap.String("contract", c.address),
+				zap.Int64("confirmed_block", confirmedBlock),
+			)
+			continue
+		}
+		s.broadcaster.PublishEvents(promoted)
+	}
+}
+
+// publishBlockEvents 读取区块内已提交的事件并广播
+func (s *ScannerService) publishBlockEvents(ctx context.Context, contracts []*contractScanner, blockNumber int64) {
+	events, err := s.repo.GetEventsByBlock(ctx, s.chainID, contractAddresses(contracts), blockNumber)
+	if err != nil {
+		logger.Logger.Error("get block events error", zap.Error(err), zap.Int64("block", blockNumber))
+		return
+	}
+	s.broadcaster.PublishEvents(events)
+}
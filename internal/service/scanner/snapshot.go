@@ -0,0 +1,52 @@
+package scanner
+
+import (
+	"context"
+
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"go.uber.org/zap"
+)
+
+// maxSnapshotsPerScan 每轮每个合约最多生成的快照区间数，首次开启时分多轮补齐历史快照
+const maxSnapshotsPerScan = 10
+
+// snapshotPositions 每隔 snapshotInterval 个已确认区块生成一次持仓快照，加速历史持仓查询
+func (s *ScannerService) snapshotPositions(ctx context.Context, contracts []*contractScanner) {
+	if s.snapshotInterval <= 0 {
+		return
+	}
+	for _, c := range contracts {
+		cursor, err := s.repo.GetCursor(ctx, s.chainID, c.address)
+		if err != nil {
+			logger.Logger.Error("get cursor error", zap.Error(err), zap.String("contract", c.address))
+			continue
+		}
+
+		var lastSnapshot int64
+		if cursor.LastSnapshotBlock != nil {
+			lastSnapshot = *cursor.LastSnapshotBlock
+		}
+		// 尚未生成快照时从合约的 start_block 开始，避免为部署前的区间生成空快照
+		if lastSnapshot < c.startBlock-1 {
+			lastSnapshot = c.startBlock - 1
+		}
+		target := cursor.LastConfirmedBlock - cursor.LastConfirmedBlock%s.snapshotInterval
+		for i := 0; i < maxSnapshotsPerScan && lastSnapshot < target; i++ {
+			next := lastSnapshot - lastSnapshot%s.snapshotInterval + s.snapshotInterval
+			created, err := s.repo.SnapshotPositions(ctx, s.chainID, c.address, next)
+			if err != nil {
+				logger.Logger.Error("snapshot positions error", zap.Error(err),
+					zap.String("contract", c.address),
+					zap.Int64("block", next),
+				)
+				break
+			}
+			logger.Logger.Debug("Position snapshot created",
+				zap.String("contract", c.address),
+				zap.Int64("block", next),
+				zap.Int("positions", created),
+			)
+			lastSnapshot = next
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/config"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/metrics"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/broadcast"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
+)
+
+const (
+	// 链 worker 重启退避
+	minRestartBackoff = time.Second
+	maxRestartBackoff = time.Minute
+	// worker 稳定运行超过该时长后重置退避
+	stableRunDuration = 5 * time.Minute
+)
+
+// 链 worker 状态
+const (
+	ChainStateStarting = "starting"
+	ChainStateRunning  = "running"
+	ChainStateBackoff  = "backoff"
+	ChainStateHalted   = "halted"
+	ChainStateStopped  = "stopped"
+)
+
+// ChainStatus 单条链 worker 的运行状态
+type ChainStatus struct {
+	Name      string    `json:"name"`
+	ChainID   int64     `json:"chain_id"`
+	State     string    `json:"state"`
+	Restarts  int       `json:"restarts"`
+	LastError string    `json:"last_error,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	// TargetBlock 最近一轮成功扫描的目标高度（安全高度，开启 index_pending 时为链头）
+	TargetBlock int64 `json:"target_block"`
+	// LastScanAt 最近一轮成功扫描的时间
+	LastScanAt *time.Time `json:"last_scan_at,omitempty"`
+}
+
+// Supervisor 在同一进程内为每条链运行独立的扫描器，worker 异常退出后按退避重启
+type Supervisor struct {
+	repo        repository.ScannerRepository
+	chains      []config.Chain
+	broadcaster *broadcast.Broadcaster
+
+	mu     sync.RWMutex
+	status map[int64]*ChainStatus
+}
+
+// broadcaster 为 nil 时不推送事件
+func NewSupervisor(repo repository.ScannerRepository, chains []config.Chain, broadcaster *broadcast.Broadcaster) (*Supervisor, error) {
+	if len(chains) == 0 {
+		return nil, errors.New("no chains configured")
+	}
+
+	status := make(map[int64]*ChainStatus, len(chains))
+	for _, chain := range chains {
+		if _, exists := status[chain.ChainID]; exists {
+			return nil, fmt.Errorf("duplicate chain id %d", chain.ChainID)
+		}
+		status[chain.ChainID] = &ChainStatus{
+			Name:    chain.Name,
+			ChainID: chain.ChainID,
+			State:   ChainStateStarting,
+		}
+	}
+
+	return &Supervisor{
+		repo:        repo,
+		chains:      chains,
+		broadcaster: broadcaster,
+		status:      status,
+	}, nil
+}
+
+// Start 启动所有链 worker，阻塞直到 ctx 取消且全部 worker 退出
+func (s *Supervisor) Start(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for _, chain := range s.chains {
+		wg.Add(1)
+		go func(chain config.Chain) {
+			defer wg.Done()
+			s.superviseChain(ctx, chain)
+		}(chain)
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// Status 返回所有链 worker 的状态快照
+func (s *Supervisor) Status() []ChainStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]ChainStatus, 0, len(s.chains))
+	for _, chain := range s.chains {
+		result = append(result, *s.status[chain.ChainID])
+	}
+	return result
+}
+
+func (s *Supervisor) superviseChain(ctx context.Context, chain config.Chain) {
+	chainIDStr := fmt.Sprintf("%d", chain.ChainID)
+	backoff := minRestartBackoff
+
+	for {
+		s.setState(chain.ChainID, ChainStateRunning, nil)
+		metrics.ChainWorkerUp.WithLabelValues(chainIDStr).Set(1)
+		startedAt := time.Now()
+
+		err := s.runChain(ctx, chain)
+		metrics.ChainWorkerUp.WithLabelValues(chainIDStr).Set(0)
+
+		if ctx.Err() != nil {
+			s.setState(chain.ChainID, ChainStateStopped, nil)
+			return
+		}
+		if IsHaltError(err) {
+			// 需要人工介入，不再自动重启
+			logger.Logger.Error("Chain worker halted", zap.String("chain", chain.Name), zap.Error(err))
+			s.setState(chain.ChainID, ChainStateHalted, err)
+			return
+		}
+
+		if time.Since(startedAt) > stableRunDuration {
+			backoff = minRestartBackoff
+		}
+		logger.Logger.Error("Chain worker exited, restarting",
+			zap.String("chain", chain.Name),
+			zap.Int64("chain_id", chain.ChainID),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+		s.setState(chain.ChainID, ChainStateBackoff, err)
+		metrics.ChainWorkerRestartsTotal.WithLabelValues(chainIDStr).Inc()
+
+		select {
+		case <-ctx.Done():
+			s.setState(chain.ChainID, ChainStateStopped, nil)
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxRestartBackoff {
+			backoff = maxRestartBackoff
+		}
+		s.incRestarts(chain.ChainID)
+	}
+}
+
+// runChain 为一条链创建独立的 RPC 客户端和扫描器并运行，panic 转换为 error
+func (s *Supervisor) runChain(ctx context.Context, chain config.Chain) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("chain worker panic: %v", r)
+		}
+	}()
+
+	client, err := ethclient.DialContext(ctx, chain.RPCURL)
+	if err != nil {
+		return fmt.Errorf("connect to rpc: %w", err)
+	}
+	defer client.Close()
+
+	svc, err := NewScannerService(s.repo, client, chain, s.broadcaster)
+	if err != nil {
+		return fmt.Errorf("create scanner service: %w", err)
+	}
+	svc.onScanned = func(targetBlock int64) {
+		s.recordScan(chain.ChainID, targetBlock)
+	}
+
+	logger.Logger.Info("Chain worker started",
+		zap.String("chain", chain.Name),
+		zap.Int64("chain_id", chain.ChainID),
+		zap.Int("contracts", len(chain.Contracts)),
+	)
+	return svc.Start(ctx)
+}
+
+func (s *Supervisor) setState(chainID int64, state string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.status[chainID]
+	st.State = state
+	if err != nil {
+		st.LastError = err.Error()
+	}
+	if state == ChainStateRunning {
+		st.StartedAt = time.Now()
+	}
+}
+
+func (s *Supervisor) incRestarts(chainID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status[chainID].Restarts++
+}
+
+func (s *Supervisor) recordScan(chainID int64, targetBlock int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	st := s.status[chainID]
+	st.TargetBlock = targetBlock
+	st.LastScanAt = &now
+}
@@ -0,0 +1,185 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/config"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultMaxSizeMB = 100
+	// 轮转后文件名中的时间格式，按字典序即时间序
+	rotateTimeFormat = "20060102T150405.000000000"
+)
+
+// fileSink 以 NDJSON 追加写入文件，超过大小上限时轮转为 <path>.<时间>，只保留最近 maxBackups 个
+type fileSink struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	file *os.File
+	size int64
+	now  func() time.Time
+}
+
+func newFileSink(cfg config.Sink) (Sink, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("file sink requires path")
+	}
+	maxSizeMB := cfg.MaxSize
+	if maxSizeMB <= 0 {
+		maxSizeMB = defaultMaxSizeMB
+	}
+	s := &fileSink{
+		path:       cfg.Path,
+		maxSize:    int64(maxSizeMB) << 20,
+		maxBackups: cfg.MaxBackups,
+		now:        time.Now,
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *fileSink) Name() string { return "file:" + s.path }
+
+func (s *fileSink) Write(ctx context.Context, records []Record) error {
+	buf, err := encodeRecords(records)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return errors.New("file sink closed")
+	}
+	// 一批消息写在同一个文件里，超过上限的批次写完后再轮转
+	n, err := s.file.Write(buf)
+	s.size += int64(n)
+	if err != nil {
+		return err
+	}
+	if s.size >= s.maxSize {
+		// 消息已经写入，轮转失败不影响本批结果，继续写入当前文件，下一批写完后重试
+		if err := s.rotate(); err != nil {
+			logger.Logger.Warn("rotate file sink failed", zap.String("path", s.path), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (s *fileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+func (s *fileSink) open() error {
+	f, size, err := s.openFile()
+	if err != nil {
+		return err
+	}
+	s.file = f
+	s.size = size
+	return nil
+}
+
+// openFile 以追加方式打开 path，返回文件与当前大小
+func (s *fileSink) openFile() (*os.File, int64, error) {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return nil, 0, err
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, 0, err
+	}
+	return f, info.Size(), nil
+}
+
+// rotate 把当前文件改名为历史文件并打开新文件，新文件打开成功后才替换句柄；
+// 任一步失败时保留原句柄，文件名恢复为 path，之后的消息继续写入原文件
+func (s *fileSink) rotate() error {
+	backup := fmt.Sprintf("%s.%s", s.path, s.now().UTC().Format(rotateTimeFormat))
+	if err := os.Rename(s.path, backup); err != nil {
+		return err
+	}
+	f, size, err := s.openFile()
+	if err != nil {
+		if rerr := os.Rename(backup, s.path); rerr != nil {
+			return errors.Join(err, rerr)
+		}
+		return err
+	}
+	old := s.file
+	s.file, s.size = f, size
+	if err := old.Close(); err != nil {
+		logger.Logger.Warn("close rotated file failed", zap.String("path", backup), zap.Error(err))
+	}
+	return s.removeOldBackups()
+}
+
+// removeOldBackups 删除超出保留数量的历史文件，maxBackups 为 0 时全部保留
+func (s *fileSink) removeOldBackups() error {
+	if s.maxBackups <= 0 {
+		return nil
+	}
+	backups, err := filepath.Glob(s.path + ".*")
+	if err != nil {
+		return err
+	}
+	prefix := s.path + "."
+	filtered := backups[:0]
+	for _, b := range backups {
+		if _, err := time.Parse(rotateTimeFormat, strings.TrimPrefix(b, prefix)); err == nil {
+			filtered = append(filtered, b)
+		}
+	}
+	if len(filtered) <= s.maxBackups {
+		return nil
+	}
+	sort.Strings(filtered)
+	for _, b := range filtered[:len(filtered)-s.maxBackups] {
+		if err := os.Remove(b); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeRecords 每条消息编码为一行 JSON
+func encodeRecords(records []Record) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,190 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/decimal"
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"go.uber.org/zap"
+)
+
+// newTestFileSink 创建以字节为大小上限的 fileSink，每次轮转时间前进 1 秒，保证历史文件名不重复
+func newTestFileSink(t *testing.T, maxSize int64, maxBackups int) *fileSink {
+	t.Helper()
+	logger.Logger = zap.NewNop()
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &fileSink{
+		path:       filepath.Join(t.TempDir(), "out", "events.ndjson"),
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+		now: func() time.Time {
+			clock = clock.Add(time.Second)
+			return clock
+		},
+	}
+	if err := s.open(); err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func testRecord(id uint64) Record {
+	status := "confirmed"
+	r := NewEventRecord(&model.StakingEvent{
+		ChainID:            1,
+		ContractAddress:    "0x00000000000000000000000000000000000000c1",
+		EventType:          "Deposit",
+		UserAddress:        "0x00000000000000000000000000000000000000a1",
+		Amount:             decimal.NewFromInt64(1e18),
+		BlockNumber:        int64(id),
+		TxHash:             "0xabc",
+		ConfirmationStatus: &status,
+	})
+	r.ID = id
+	return r
+}
+
+// readRecords 按行解码 NDJSON 文件
+func readRecords(t *testing.T, path string) []Record {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open %s: %v", path, err)
+	}
+	defer f.Close()
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("decode line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, r)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scan %s: %v", path, err)
+	}
+	return records
+}
+
+// backupFiles 历史文件，按轮转时间排序
+func backupFiles(t *testing.T, s *fileSink) []string {
+	t.Helper()
+	backups, err := filepath.Glob(s.path + ".*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(backups)
+	return backups
+}
+
+func TestEncodeRecords(t *testing.T) {
+	buf, err := encodeRecords([]Record{testRecord(1), NewRetractionRecord(1, []string{"0xc1"}, 10)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(buf), "\n")
+	if len(lines) != 3 || lines[2] != "" {
+		t.Fatalf("want 2 newline-terminated lines, got %q", buf)
+	}
+	var event, retraction Record
+	if err := json.Unmarshal([]byte(lines[0]), &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.ID != 1 || event.Type != RecordEvent || event.Event == nil || event.Event.Amount != "1000000000000000000" {
+		t.Fatalf("unexpected event line %s", lines[0])
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &retraction); err != nil {
+		t.Fatal(err)
+	}
+	if retraction.Type != RecordRetracted || retraction.Retraction == nil || retraction.Retraction.FromBlock != 10 {
+		t.Fatalf("unexpected retraction line %s", lines[1])
+	}
+}
+
+func TestFileSinkRotatesAndPrunesBackups(t *testing.T) {
+	line, err := encodeRecords([]Record{testRecord(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 每两条消息轮转一次
+	s := newTestFileSink(t, int64(2*len(line)), 2)
+	ctx := context.Background()
+	for id := uint64(1); id <= 9; id++ {
+		if err := s.Write(ctx, []Record{testRecord(id)}); err != nil {
+			t.Fatalf("write %d: %v", id, err)
+		}
+	}
+
+	// 轮转 4 次，只保留最近 2 个历史文件：[5 6] [7 8]，当前文件为 [9]
+	backups := backupFiles(t, s)
+	if len(backups) != 2 {
+		t.Fatalf("want 2 backups, got %v", backups)
+	}
+	var ids []uint64
+	for _, path := range append(backups, s.path) {
+		for _, r := range readRecords(t, path) {
+			ids = append(ids, r.ID)
+		}
+	}
+	want := []uint64{5, 6, 7, 8, 9}
+	if len(ids) != len(want) {
+		t.Fatalf("ids = %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("ids = %v, want %v", ids, want)
+		}
+	}
+}
+
+func TestFileSinkKeepsWritingWhenRotateFails(t *testing.T) {
+	s := newTestFileSink(t, 1, 0)
+	ctx := context.Background()
+
+	// 历史文件名被目录占用，改名失败
+	now := time.Date(2024, 1, 1, 0, 0, 1, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	blocked := s.path + "." + now.Format(rotateTimeFormat)
+	if err := os.MkdirAll(filepath.Join(blocked, "x"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Write(ctx, []Record{testRecord(1)}); err != nil {
+		t.Fatalf("write after failed rotation should succeed: %v", err)
+	}
+	if err := s.Write(ctx, []Record{testRecord(2)}); err != nil {
+		t.Fatalf("write to old handle: %v", err)
+	}
+	records := readRecords(t, s.path)
+	if len(records) != 2 || records[0].ID != 1 || records[1].ID != 2 {
+		t.Fatalf("current file records = %+v, want ids 1 and 2 once each", records)
+	}
+
+	// 下一批写完后重新轮转
+	if err := os.RemoveAll(blocked); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Write(ctx, []Record{testRecord(3)}); err != nil {
+		t.Fatal(err)
+	}
+	backups := backupFiles(t, s)
+	if len(backups) != 1 {
+		t.Fatalf("want 1 backup, got %v", backups)
+	}
+	if rotated := readRecords(t, backups[0]); len(rotated) != 3 {
+		t.Fatalf("rotated records = %+v, want 3", rotated)
+	}
+	if info, err := os.Stat(s.path); err != nil || info.Size() != 0 {
+		t.Fatalf("new file after rotation: %v, %v", info, err)
+	}
+}
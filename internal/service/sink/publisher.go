@@ -0,0 +1,58 @@
+package sink
+
+import (
+	"context"
+
+	"github.com/dijiacoder/staking-indexer/internal/metrics"
+	"github.com/dijiacoder/staking-indexer/internal/service/broadcast"
+)
+
+// Publisher 把发件箱消息按顺序写入单个 sink，由 outbox.Relay 驱动。
+// 每个 sink 独立记录消费进度，单个 sink 写入失败不影响其他 sink
+type Publisher struct {
+	sink Sink
+}
+
+func NewPublisher(sink Sink) *Publisher {
+	return &Publisher{sink: sink}
+}
+
+// Consumer 发件箱消费者名称
+func (p *Publisher) Consumer() string {
+	return "sink:" + p.sink.Name()
+}
+
+// Handle 写入一批消息，失败时整批重试，下游按 Record.ID 去重
+func (p *Publisher) Handle(ctx context.Context, msgs []broadcast.Message) error {
+	records := make([]Record, 0, len(msgs))
+	for _, msg := range msgs {
+		records = appendRecord(records, msg)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	if err := p.sink.Write(ctx, records); err != nil {
+		metrics.SinkRecordsTotal.WithLabelValues(p.sink.Name(), "failed").Add(float64(len(records)))
+		return err
+	}
+	metrics.SinkRecordsTotal.WithLabelValues(p.sink.Name(), "success").Add(float64(len(records)))
+	return nil
+}
+
+func (p *Publisher) Close() error {
+	return p.sink.Close()
+}
+
+func appendRecord(records []Record, msg broadcast.Message) []Record {
+	var record Record
+	switch msg.Kind {
+	case broadcast.KindEvent:
+		record = NewEventRecord(msg.Event)
+	case broadcast.KindRetract:
+		record = NewRetractionRecord(msg.ChainID, msg.ContractAddresses, msg.FromBlock)
+	default:
+		return records
+	}
+	record.ID = msg.Seq
+	return append(records, record)
+}
@@ -0,0 +1,128 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/config"
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+)
+
+// Record 类型
+const (
+	RecordEvent     = "event"
+	RecordRetracted = "retracted"
+)
+
+// Record 输出到 sink 的一条消息，Type 为 event 时 Event 非空，为 retracted 时 Retraction 非空。
+// ID 为发件箱 id，同一条链内递增，重试时可能重复输出，下游按 ID 去重
+type Record struct {
+	ID         uint64      `json:"id"`
+	Type       string      `json:"type"`
+	Event      *Event      `json:"event,omitempty"`
+	Retraction *Retraction `json:"retraction,omitempty"`
+}
+
+// Event 解码后的质押事件
+type Event struct {
+	ChainID            int64      `json:"chain_id"`
+	ContractAddress    string     `json:"contract_address"`
+	PoolID             int64      `json:"pool_id"`
+	EventType          string     `json:"event_type"`
+	UserAddress        string     `json:"user_address"`
+	Amount             string     `json:"amount"`
+	BlockNumber        int64      `json:"block_number"`
+	BlockTime          *time.Time `json:"block_time"`
+	TxHash             string     `json:"tx_hash"`
+	LogIndex           int32      `json:"log_index"`
+	ConfirmationStatus string     `json:"confirmation_status"`
+}
+
+// Retraction 重组回滚，FromBlock 之后已输出的事件作废
+type Retraction struct {
+	ChainID           int64    `json:"chain_id"`
+	ContractAddresses []string `json:"contract_addresses"`
+	FromBlock         int64    `json:"from_block"`
+}
+
+// Sink 事件输出目标。Write 按顺序接收一批消息，返回 error 时该批消息记为失败
+type Sink interface {
+	Name() string
+	Write(ctx context.Context, records []Record) error
+	Close() error
+}
+
+// Factory 根据配置创建 sink
+type Factory func(cfg config.Sink) (Sink, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = map[string]Factory{
+		"stdout": newStdoutSink,
+		"file":   newFileSink,
+	}
+)
+
+// Register 注册 sink 类型，消息队列等实现通过此处接入
+func Register(typ string, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	factories[typ] = factory
+}
+
+// New 按 [[sinks]] 配置创建 sink
+func New(cfg config.Sink) (Sink, error) {
+	factoriesMu.RLock()
+	factory, ok := factories[cfg.Type]
+	factoriesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown sink type %q (available: %v)", cfg.Type, types())
+	}
+	return factory(cfg)
+}
+
+func types() []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+	list := make([]string, 0, len(factories))
+	for typ := range factories {
+		list = append(list, typ)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// NewEventRecord 由事件表记录生成消息
+func NewEventRecord(e *model.StakingEvent) Record {
+	event := &Event{
+		ChainID:         e.ChainID,
+		ContractAddress: e.ContractAddress,
+		PoolID:          e.PoolID,
+		EventType:       e.EventType,
+		UserAddress:     e.UserAddress,
+		Amount:          e.Amount.String(),
+		BlockNumber:     e.BlockNumber,
+		BlockTime:       e.BlockTime,
+		TxHash:          e.TxHash,
+		LogIndex:        e.LogIndex,
+	}
+	if e.ConfirmationStatus != nil {
+		event.ConfirmationStatus = *e.ConfirmationStatus
+	}
+	return Record{Type: RecordEvent, Event: event}
+}
+
+// NewRetractionRecord 生成重组回滚消息
+func NewRetractionRecord(chainID int64, contractAddresses []string, fromBlock int64) Record {
+	return Record{
+		Type: RecordRetracted,
+		Retraction: &Retraction{
+			ChainID:           chainID,
+			ContractAddresses: contractAddresses,
+			FromBlock:         fromBlock,
+		},
+	}
+}
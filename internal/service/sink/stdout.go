@@ -0,0 +1,35 @@
+package sink
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/dijiacoder/staking-indexer/internal/config"
+)
+
+// stdoutSink 以 NDJSON 写到标准输出，日志输出在标准错误，不会混在一起
+type stdoutSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func newStdoutSink(config.Sink) (Sink, error) {
+	return &stdoutSink{w: os.Stdout}, nil
+}
+
+func (s *stdoutSink) Name() string { return "stdout" }
+
+func (s *stdoutSink) Write(ctx context.Context, records []Record) error {
+	buf, err := encodeRecords(records)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(buf)
+	return err
+}
+
+func (s *stdoutSink) Close() error { return nil }
@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/broadcast"
+)
+
+// subscriptionRefreshInterval 订阅列表缓存刷新间隔
+const subscriptionRefreshInterval = 30 * time.Second
+
+// Dispatcher 由 outbox.Relay 驱动，将已确认事件和重组回滚按订阅条件写入投递队列。
+// 消息幂等键由事件位置或发件箱 id 生成，重试的批次不会重复投递
+type Dispatcher struct {
+	repo repository.WebhookRepository
+
+	subscriptions []*model.WebhookSubscription
+	refreshedAt   time.Time
+}
+
+func NewDispatcher(repo repository.WebhookRepository) *Dispatcher {
+	return &Dispatcher{
+		repo: repo,
+	}
+}
+
+// Consumer 发件箱消费者名称
+func (d *Dispatcher) Consumer() string {
+	return "webhook"
+}
+
+// Handle 按顺序处理一批发件箱消息
+func (d *Dispatcher) Handle(ctx context.Context, msgs []broadcast.Message) error {
+	for _, msg := range msgs {
+		if err := d.dispatch(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dispatch 为匹配的订阅生成投递记录
+func (d *Dispatcher) dispatch(ctx context.Context, msg broadcast.Message) error {
+	var (
+		messageType string
+		messageKey  string
+		payload     any
+		match       func(*model.WebhookSubscription) bool
+	)
+	switch msg.Kind {
+	case broadcast.KindEvent:
+		e := msg.Event
+		// 只投递已确认事件，pending 事件在确认后由扫描器再次发布
+		if e.ConfirmationStatus == nil || *e.ConfirmationStatus != repository.ConfirmationStatusConfirmed {
+			return nil
+		}
+		messageType = repository.WebhookMessageEvent
+		messageKey = eventMessageKey(e)
+		payload = newEventPayload(e)
+		match = func(s *model.WebhookSubscription) bool { return matchEvent(s, e) }
+
+	case broadcast.KindRetract:
+		messageType = repository.WebhookMessageRetracted
+		messageKey = fmt.Sprintf("retracted:%d:%d", msg.ChainID, msg.Seq)
+		payload = retractedPayload{
+			Type:              payloadTypeRetracted,
+			ChainID:           msg.ChainID,
+			ContractAddresses: msg.ContractAddresses,
+			FromBlock:         msg.FromBlock,
+		}
+		match = func(s *model.WebhookSubscription) bool { return matchContract(s, msg.ChainID, msg.ContractAddresses) }
+
+	default:
+		return nil
+	}
+
+	subscriptions, err := d.loadSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	deliveries := make([]*model.WebhookDelivery, 0)
+	for _, s := range subscriptions {
+		if !match(s) {
+			continue
+		}
+		deliveries = append(deliveries, &model.WebhookDelivery{
+			SubscriptionID: s.ID,
+			ChainID:        msg.ChainID,
+			MessageType:    messageType,
+			MessageKey:     messageKey,
+			Payload:        string(body),
+			Status:         repository.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+		})
+	}
+	return d.repo.EnqueueDeliveries(ctx, deliveries)
+}
+
+func (d *Dispatcher) loadSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	if d.subscriptions != nil && time.Since(d.refreshedAt) < subscriptionRefreshInterval {
+		return d.subscriptions, nil
+	}
+	subscriptions, err := d.repo.ListEnabledSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d.subscriptions = subscriptions
+	d.refreshedAt = time.Now()
+	return subscriptions, nil
+}
@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+)
+
+// 请求头
+const (
+	HeaderDeliveryID = "X-Webhook-Delivery"
+	HeaderTimestamp  = "X-Webhook-Timestamp"
+	HeaderSignature  = "X-Webhook-Signature"
+)
+
+// 请求体 type 取值
+const (
+	payloadTypeEvent     = "staking_event"
+	payloadTypeRetracted = "retracted"
+)
+
+type eventPayload struct {
+	Type  string    `json:"type"`
+	Event eventData `json:"event"`
+}
+
+type eventData struct {
+	ChainID            int64      `json:"chain_id"`
+	ContractAddress    string     `json:"contract_address"`
+	PoolID             int64      `json:"pool_id"`
+	EventType          string     `json:"event_type"`
+	UserAddress        string     `json:"user_address"`
+	Amount             string     `json:"amount"`
+	BlockNumber        int64      `json:"block_number"`
+	BlockTime          *time.Time `json:"block_time"`
+	TxHash             string     `json:"tx_hash"`
+	LogIndex           int32      `json:"log_index"`
+	ConfirmationStatus string     `json:"confirmation_status"`
+}
+
+// retractedPayload 重组回滚通知，from_block 之后已推送的事件作废
+type retractedPayload struct {
+	Type              string   `json:"type"`
+	ChainID           int64    `json:"chain_id"`
+	ContractAddresses []string `json:"contract_addresses"`
+	FromBlock         int64    `json:"from_block"`
+}
+
+func newEventPayload(e *model.StakingEvent) eventPayload {
+	data := eventData{
+		ChainID:         e.ChainID,
+		ContractAddress: e.ContractAddress,
+		PoolID:          e.PoolID,
+		EventType:       e.EventType,
+		UserAddress:     e.UserAddress,
+		Amount:          e.Amount.String(),
+		BlockNumber:     e.BlockNumber,
+		BlockTime:       e.BlockTime,
+		TxHash:          e.TxHash,
+		LogIndex:        e.LogIndex,
+	}
+	if e.ConfirmationStatus != nil {
+		data.ConfirmationStatus = *e.ConfirmationStatus
+	}
+	return eventPayload{Type: payloadTypeEvent, Event: data}
+}
+
+// eventMessageKey 事件消息幂等键，同一事件重复入队时只投递一次
+func eventMessageKey(e *model.StakingEvent) string {
+	return fmt.Sprintf("event:%d:%d:%s:%d", e.ChainID, e.BlockNumber, e.TxHash, e.LogIndex)
+}
+
+// Sign 计算请求签名：HMAC-SHA256(secret, "<timestamp>.<body>")，接收方按同样方式校验
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 校验请求签名
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// matchEvent 事件是否满足订阅的过滤条件
+func matchEvent(sub *model.WebhookSubscription, e *model.StakingEvent) bool {
+	if !matchContract(sub, e.ChainID, []string{e.ContractAddress}) {
+		return false
+	}
+	if sub.EventTypes != "" && !containsFold(strings.Split(sub.EventTypes, ","), e.EventType) {
+		return false
+	}
+	if sub.PoolID != nil && *sub.PoolID != e.PoolID {
+		return false
+	}
+	if sub.UserAddress != "" && !strings.EqualFold(sub.UserAddress, e.UserAddress) {
+		return false
+	}
+	return true
+}
+
+// matchContract 订阅是否关注该链上的任一合约
+func matchContract(sub *model.WebhookSubscription, chainID int64, contractAddresses []string) bool {
+	if sub.ChainID != 0 && sub.ChainID != chainID {
+		return false
+	}
+	return sub.ContractAddress == "" || containsFold(contractAddresses, sub.ContractAddress)
+}
+
+func containsFold(list []string, v string) bool {
+	for _, item := range list {
+		if strings.EqualFold(strings.TrimSpace(item), v) {
+			return true
+		}
+	}
+	return false
+}
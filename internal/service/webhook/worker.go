@@ -0,0 +1,211 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/metrics"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPollInterval = 2 * time.Second
+	defaultTimeout      = 10 * time.Second
+	defaultMaxAttempts  = 10
+	// 每轮最多投递的消息数
+	deliveryBatchSize = 100
+	// 重试退避：10s, 20s, 40s ... 最长 1 小时
+	minRetryBackoff = 10 * time.Second
+	maxRetryBackoff = time.Hour
+	// 写入数据库的错误信息最大长度
+	maxErrorLength = 512
+)
+
+// Worker 轮询投递队列，签名后 POST 到订阅地址，失败按指数退避重试
+type Worker struct {
+	repo         repository.WebhookRepository
+	client       *http.Client
+	pollInterval time.Duration
+	maxAttempts  int32
+	now          func() time.Time
+}
+
+func NewWorker(repo repository.WebhookRepository, pollInterval, timeout time.Duration, maxAttempts int) *Worker {
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	return &Worker{
+		repo:         repo,
+		client:       &http.Client{Timeout: timeout},
+		pollInterval: pollInterval,
+		maxAttempts:  int32(maxAttempts),
+		now:          time.Now,
+	}
+}
+
+// Run 阻塞直到 ctx 取消
+func (w *Worker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := w.deliverDue(ctx); err != nil {
+				logger.Logger.Error("Webhook delivery error", zap.Error(err))
+			}
+		}
+	}
+}
+
+// deliverDue 投递一批到期的消息
+func (w *Worker) deliverDue(ctx context.Context) error {
+	deliveries, err := w.repo.ListDueDeliveries(ctx, w.now(), deliveryBatchSize)
+	if err != nil {
+		return err
+	}
+	metrics.WebhookDueDeliveries.Set(float64(len(deliveries)))
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(deliveries))
+	for _, d := range deliveries {
+		ids = append(ids, d.SubscriptionID)
+	}
+	subscriptions, err := w.repo.GetSubscriptionsByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int64]*model.WebhookSubscription, len(subscriptions))
+	for _, s := range subscriptions {
+		byID[s.ID] = s
+	}
+
+	for _, d := range deliveries {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := w.deliver(ctx, d, byID[d.SubscriptionID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliver 投递单条消息并记录结果，只有写库失败时返回 error
+func (w *Worker) deliver(ctx context.Context, d *model.WebhookDelivery, sub *model.WebhookSubscription) error {
+	start := w.now()
+	d.Attempts++
+	attempt := &model.WebhookDeliveryLog{
+		DeliveryID: d.ID,
+		Attempt:    d.Attempts,
+	}
+
+	var statusCode int
+	var deliverErr error
+	if sub == nil || sub.Enabled == nil || *sub.Enabled != 1 {
+		// 订阅已删除或停用，不再重试
+		deliverErr = fmt.Errorf("subscription %d disabled", d.SubscriptionID)
+		d.Attempts = w.maxAttempts
+	} else {
+		statusCode, deliverErr = w.post(ctx, d, sub)
+	}
+	attempt.StatusCode = int32(statusCode)
+	attempt.DurationMs = w.now().Sub(start).Milliseconds()
+
+	var result string
+	switch {
+	case deliverErr == nil:
+		result = "success"
+		now := w.now()
+		d.Status = repository.WebhookDeliveryDelivered
+		d.DeliveredAt = &now
+		d.LastError = ""
+	case d.Attempts >= w.maxAttempts:
+		result = "failed"
+		d.Status = repository.WebhookDeliveryFailed
+		d.LastError = truncateError(deliverErr)
+	default:
+		result = "retry"
+		d.NextAttemptAt = w.now().Add(retryBackoff(d.Attempts))
+		d.LastError = truncateError(deliverErr)
+	}
+	attempt.Error = d.LastError
+	metrics.WebhookDeliveriesTotal.WithLabelValues(result).Inc()
+
+	if deliverErr != nil {
+		logger.Logger.Warn("Webhook delivery failed",
+			zap.Int64("delivery_id", d.ID),
+			zap.Int64("subscription_id", d.SubscriptionID),
+			zap.Int32("attempt", d.Attempts),
+			zap.String("result", result),
+			zap.Error(deliverErr),
+		)
+	}
+
+	return w.repo.RecordAttempt(ctx, d, attempt)
+}
+
+// post 发送签名请求，2xx 视为成功
+func (w *Worker) post(ctx context.Context, d *model.WebhookDelivery, sub *model.WebhookSubscription) (int, error) {
+	body := []byte(d.Payload)
+	timestamp := w.now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDeliveryID, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, timestamp, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// retryBackoff 第 attempts 次失败后的等待时间
+func retryBackoff(attempts int32) time.Duration {
+	backoff := minRetryBackoff
+	for i := int32(1); i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+	return backoff
+}
+
+func truncateError(err error) string {
+	msg := err.Error()
+	if len(msg) > maxErrorLength {
+		return msg[:maxErrorLength]
+	}
+	return msg
+}
@@ -0,0 +1,236 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dijiacoder/staking-indexer/internal/gen/model"
+	"github.com/dijiacoder/staking-indexer/internal/logger"
+	"github.com/dijiacoder/staking-indexer/internal/repository"
+	"github.com/dijiacoder/staking-indexer/internal/service/broadcast"
+	"go.uber.org/zap"
+)
+
+// fakeRepo 内存实现的 WebhookRepository
+type fakeRepo struct {
+	mu            sync.Mutex
+	subscriptions []*model.WebhookSubscription
+	deliveries    []*model.WebhookDelivery
+	logs          []*model.WebhookDeliveryLog
+}
+
+func (r *fakeRepo) ListEnabledSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	return r.subscriptions, nil
+}
+
+func (r *fakeRepo) GetSubscriptionsByIDs(ctx context.Context, ids []int64) ([]*model.WebhookSubscription, error) {
+	return r.subscriptions, nil
+}
+
+func (r *fakeRepo) EnqueueDeliveries(ctx context.Context, deliveries []*model.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+next:
+	for _, d := range deliveries {
+		// 与 uk_subscription_message 一致，重复的消息忽略
+		for _, existing := range r.deliveries {
+			if existing.SubscriptionID == d.SubscriptionID && existing.MessageKey == d.MessageKey {
+				continue next
+			}
+		}
+		d.ID = int64(len(r.deliveries) + 1)
+		r.deliveries = append(r.deliveries, d)
+	}
+	return nil
+}
+
+func (r *fakeRepo) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*model.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []*model.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.Status == repository.WebhookDeliveryPending && !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeRepo) RecordAttempt(ctx context.Context, delivery *model.WebhookDelivery, attempt *model.WebhookDeliveryLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = append(r.logs, attempt)
+	return nil
+}
+
+func newTestWorker(repo *fakeRepo, now *time.Time) *Worker {
+	w := NewWorker(repo, time.Second, time.Second, 3)
+	w.now = func() time.Time { return *now }
+	return w
+}
+
+func TestWorkerSignsAndRetries(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	var mu sync.Mutex
+	calls := 0
+	var lastBody []byte
+	var lastHeader http.Header
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		lastBody, _ = io.ReadAll(r.Body)
+		lastHeader = r.Header.Clone()
+		if calls == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer stub.Close()
+
+	enabled := int32(1)
+	repo := &fakeRepo{
+		subscriptions: []*model.WebhookSubscription{{ID: 1, URL: stub.URL, Secret: "s3cret", Enabled: &enabled}},
+	}
+	now := time.Unix(1700000000, 0)
+	if err := repo.EnqueueDeliveries(context.Background(), []*model.WebhookDelivery{{
+		SubscriptionID: 1,
+		Payload:        `{"type":"staking_event"}`,
+		Status:         repository.WebhookDeliveryPending,
+		NextAttemptAt:  now,
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	w := newTestWorker(repo, &now)
+	ctx := context.Background()
+
+	// 第一次返回 500，进入重试
+	if err := w.deliverDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	d := repo.deliveries[0]
+	if d.Status != repository.WebhookDeliveryPending || d.Attempts != 1 {
+		t.Fatalf("after first attempt: status=%s attempts=%d", d.Status, d.Attempts)
+	}
+	if want := now.Add(minRetryBackoff); !d.NextAttemptAt.Equal(want) {
+		t.Fatalf("next attempt at %v, want %v", d.NextAttemptAt, want)
+	}
+
+	// 未到重试时间不投递
+	if err := w.deliverDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Fatalf("delivered before backoff elapsed, calls=%d", calls)
+	}
+
+	// 到期后重试成功
+	now = now.Add(minRetryBackoff)
+	if err := w.deliverDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if d.Status != repository.WebhookDeliveryDelivered || d.Attempts != 2 || d.DeliveredAt == nil {
+		t.Fatalf("after retry: status=%s attempts=%d", d.Status, d.Attempts)
+	}
+	if len(repo.logs) != 2 || repo.logs[0].StatusCode != 500 || repo.logs[1].StatusCode != 204 {
+		t.Fatalf("unexpected delivery logs: %+v", repo.logs)
+	}
+
+	ts, err := strconv.ParseInt(lastHeader.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Verify("s3cret", ts, lastBody, lastHeader.Get(HeaderSignature)) {
+		t.Fatalf("invalid signature %q", lastHeader.Get(HeaderSignature))
+	}
+	if lastHeader.Get(HeaderDeliveryID) != "1" {
+		t.Fatalf("delivery id header = %q", lastHeader.Get(HeaderDeliveryID))
+	}
+}
+
+func TestWorkerGivesUpAfterMaxAttempts(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer stub.Close()
+
+	enabled := int32(1)
+	repo := &fakeRepo{
+		subscriptions: []*model.WebhookSubscription{{ID: 1, URL: stub.URL, Secret: "s", Enabled: &enabled}},
+	}
+	now := time.Unix(1700000000, 0)
+	_ = repo.EnqueueDeliveries(context.Background(), []*model.WebhookDelivery{{
+		SubscriptionID: 1,
+		Payload:        `{}`,
+		Status:         repository.WebhookDeliveryPending,
+		NextAttemptAt:  now,
+	}})
+	w := newTestWorker(repo, &now)
+
+	for i := 0; i < 3; i++ {
+		if err := w.deliverDue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(maxRetryBackoff)
+	}
+	d := repo.deliveries[0]
+	if d.Status != repository.WebhookDeliveryFailed || d.Attempts != 3 {
+		t.Fatalf("status=%s attempts=%d, want failed after 3 attempts", d.Status, d.Attempts)
+	}
+	if d.LastError == "" {
+		t.Fatal("last error not recorded")
+	}
+}
+
+func TestDispatcherMatchesSubscriptions(t *testing.T) {
+	poolID := int64(1)
+	repo := &fakeRepo{
+		subscriptions: []*model.WebhookSubscription{
+			{ID: 1, ChainID: 1, EventTypes: "Deposit"},
+			{ID: 2, ChainID: 1, PoolID: &poolID},
+			{ID: 3, ChainID: 2},
+		},
+	}
+	d := NewDispatcher(repo)
+	ctx := context.Background()
+
+	confirmed := repository.ConfirmationStatusConfirmed
+	pending := repository.ConfirmationStatusPending
+	msgs := []broadcast.Message{
+		{Kind: broadcast.KindEvent, ChainID: 1, Event: &model.StakingEvent{ChainID: 1, EventType: "Withdraw", PoolID: 1, ConfirmationStatus: &confirmed}},
+		{Kind: broadcast.KindEvent, ChainID: 1, Event: &model.StakingEvent{ChainID: 1, EventType: "Deposit", PoolID: 0, ConfirmationStatus: &pending}},
+		{Seq: 3, Kind: broadcast.KindRetract, ChainID: 1, ContractAddresses: []string{"0xabc"}, FromBlock: 10},
+	}
+	if err := d.Handle(ctx, msgs); err != nil {
+		t.Fatal(err)
+	}
+	// 重试同一批消息不会重复入队
+	if err := d.Handle(ctx, msgs); err != nil {
+		t.Fatal(err)
+	}
+
+	// Withdraw 只匹配订阅 2，pending 事件不投递，回滚通知发给链 1 的全部订阅
+	var got []string
+	for _, delivery := range repo.deliveries {
+		got = append(got, strconv.FormatInt(delivery.SubscriptionID, 10)+":"+delivery.MessageType)
+	}
+	want := []string{"2:event", "1:retracted", "2:retracted"}
+	if len(got) != len(want) {
+		t.Fatalf("deliveries = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("deliveries = %v, want %v", got, want)
+		}
+	}
+}
@@ -14,7 +14,10 @@ CREATE TABLE chain_scan_cursor (
        last_scanned_block BIGINT NOT NULL COMMENT '最近已扫描区块（可能未确认）',
        last_confirmed_block BIGINT NOT NULL COMMENT '最近已确认区块高度',
        confirmation_blocks INT NOT NULL DEFAULT 12 COMMENT '确认区块数',
-       scan_status TINYINT NOT NULL DEFAULT 1 COMMENT '扫描状态：1-正常 2-回滚中 3-暂停',
+       scan_status TINYINT NOT NULL DEFAULT 1 COMMENT '扫描状态：1-正常 2-回滚中（回滚深度超限待人工处理） 3-暂停',
+       last_snapshot_block BIGINT NOT NULL DEFAULT 0 COMMENT '最近生成持仓快照的区块',
+       last_reorg_block BIGINT NOT NULL DEFAULT 0 COMMENT '最近一次重组回滚到的区块',
+       last_reorg_at TIMESTAMP NULL COMMENT '最近一次重组时间',
        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
        UNIQUE KEY uk_chain_contract (chain_id, contract_address),
@@ -31,9 +34,11 @@ CREATE TABLE chain_blocks (
       block_hash VARCHAR(66) NOT NULL COMMENT '区块Hash',
       parent_hash VARCHAR(66) NOT NULL COMMENT '父区块Hash',
       is_confirmed TINYINT NOT NULL DEFAULT 0 COMMENT '是否已确认',
+      block_time DATETIME NULL COMMENT '区块时间（UTC）',
       created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录时间',
       UNIQUE KEY uk_chain_block (chain_id, block_number),
-      KEY idx_block_hash (block_hash)
+      KEY idx_block_hash (block_hash),
+      KEY idx_chain_time (chain_id, block_time)
 ) ENGINE=InnoDB COMMENT='区块头缓存表';
 
 -- ================================
@@ -50,6 +55,10 @@ CREATE TABLE staking_pools (
        start_block BIGINT NOT NULL COMMENT '开始区块',
        end_block BIGINT NOT NULL COMMENT '结束区块',
        reward_per_block DECIMAL(38,0) NOT NULL COMMENT '每区块奖励',
+       st_token_amount DECIMAL(38,0) NOT NULL DEFAULT 0 COMMENT '质押的代币数量（Deposit 减 RequestUnstake）',
+       pending_unstake DECIMAL(38,0) NOT NULL DEFAULT 0 COMMENT '已申请赎回未提取数量（RequestUnstake 减 Withdraw）',
+       staker_count BIGINT NOT NULL DEFAULT 0 COMMENT '质押数量扣除待提取后大于 0 的用户数',
+       total_claimed DECIMAL(38,0) NOT NULL DEFAULT 0 COMMENT '累计领取奖励',
        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
        UNIQUE KEY uk_pool (chain_id, contract_address, pool_id)
 ) ENGINE=InnoDB COMMENT='Staking池定义表';
@@ -64,7 +73,9 @@ CREATE TABLE staking_user_positions (
         pool_id BIGINT NOT NULL COMMENT 'Pool ID',
         user_address VARCHAR(42) NOT NULL COMMENT '用户地址',
         staked_amount DECIMAL(38,0) NOT NULL DEFAULT 0 COMMENT '当前质押数量',
+        pending_unstake DECIMAL(38,0) NOT NULL DEFAULT 0 COMMENT '已申请赎回未提取数量',
         reward_debt DECIMAL(38,0) NOT NULL DEFAULT 0 COMMENT '奖励债务',
+        total_claimed DECIMAL(38,0) NOT NULL DEFAULT 0 COMMENT '累计领取奖励',
         updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
         UNIQUE KEY uk_user_pool (chain_id, contract_address, pool_id, user_address)
 ) ENGINE=InnoDB COMMENT='用户质押实时状态';
@@ -81,12 +92,237 @@ CREATE TABLE staking_events (
         user_address VARCHAR(42) NOT NULL COMMENT '用户地址',
         amount DECIMAL(38,0) NOT NULL COMMENT '数量（wei）',
         block_number BIGINT NOT NULL COMMENT '区块高度',
+        block_time DATETIME NULL COMMENT '区块时间（UTC）',
         tx_hash VARCHAR(66) NOT NULL COMMENT '交易Hash',
         log_index INT NOT NULL COMMENT '日志索引',
+        confirmation_status VARCHAR(16) NOT NULL DEFAULT 'confirmed' COMMENT '确认状态：pending / confirmed / orphaned',
         created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
         UNIQUE KEY uk_tx_log (tx_hash, log_index),
         KEY idx_user (chain_id, user_address),
-        KEY idx_pool_block (chain_id, pool_id, block_number)
+        KEY idx_pool_block (chain_id, pool_id, block_number),
+        KEY idx_status_block (chain_id, confirmation_status, block_number),
+        KEY idx_chain_block_time (chain_id, block_time)
 ) ENGINE=InnoDB COMMENT='Staking事件表';
 
+-- ================================
+-- 6. Webhook 订阅表
+-- ================================
+CREATE TABLE webhook_subscriptions (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT COMMENT '主键',
+        name VARCHAR(64) NOT NULL COMMENT '订阅名称',
+        url VARCHAR(512) NOT NULL COMMENT '回调地址',
+        secret VARCHAR(128) NOT NULL COMMENT 'HMAC签名密钥',
+        event_types VARCHAR(128) NOT NULL COMMENT '事件类型，逗号分隔，为空表示全部',
+        chain_id BIGINT NOT NULL COMMENT '链ID，0表示全部',
+        contract_address VARCHAR(42) NOT NULL COMMENT '合约地址，为空表示全部',
+        pool_id BIGINT NULL COMMENT 'Pool ID，为空表示全部',
+        user_address VARCHAR(42) NOT NULL COMMENT '用户地址，为空表示全部',
+        enabled TINYINT NOT NULL DEFAULT 1 COMMENT '是否启用',
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+        KEY idx_enabled (enabled)
+) ENGINE=InnoDB COMMENT='Webhook订阅';
+
+-- ================================
+-- 7. Webhook 投递队列（outbox）
+-- ================================
+CREATE TABLE webhook_deliveries (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT COMMENT '主键',
+        subscription_id BIGINT NOT NULL COMMENT '订阅ID',
+        chain_id BIGINT NOT NULL COMMENT '链ID',
+        message_type VARCHAR(16) NOT NULL COMMENT '消息类型：event / retracted',
+        message_key VARCHAR(160) NOT NULL COMMENT '消息幂等键',
+        payload TEXT NOT NULL COMMENT '请求体JSON',
+        status VARCHAR(16) NOT NULL COMMENT '投递状态：pending / delivered / failed',
+        attempts INT NOT NULL COMMENT '已尝试次数',
+        next_attempt_at TIMESTAMP NOT NULL COMMENT '下次投递时间',
+        last_error VARCHAR(512) NOT NULL COMMENT '最后一次失败原因',
+        delivered_at TIMESTAMP NULL COMMENT '投递成功时间',
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+        UNIQUE KEY uk_subscription_message (subscription_id, message_key),
+        KEY idx_status_next (status, next_attempt_at)
+) ENGINE=InnoDB COMMENT='Webhook投递队列（outbox）';
+
+-- ================================
+-- 8. Webhook 投递日志
+-- ================================
+CREATE TABLE webhook_delivery_logs (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT COMMENT '主键',
+        delivery_id BIGINT NOT NULL COMMENT '投递ID',
+        attempt INT NOT NULL COMMENT '第几次尝试',
+        status_code INT NOT NULL COMMENT 'HTTP状态码，0表示请求失败',
+        error VARCHAR(512) NOT NULL COMMENT '失败原因',
+        duration_ms BIGINT NOT NULL COMMENT '请求耗时（毫秒）',
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '记录时间',
+        KEY idx_delivery (delivery_id)
+) ENGINE=InnoDB COMMENT='Webhook投递日志';
+
+-- ================================
+-- 9. 事件发件箱
+-- ================================
+CREATE TABLE event_outbox (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT COMMENT '主键，按写入顺序分配，较晚提交的事务可能占用较小的 id',
+        chain_id BIGINT NOT NULL COMMENT '链ID',
+        contract_address VARCHAR(42) NOT NULL COMMENT '合约地址',
+        kind VARCHAR(16) NOT NULL COMMENT '消息类型：event / retract',
+        block_number BIGINT NOT NULL COMMENT '事件所在区块，retract 为回滚到的区块',
+        payload TEXT NOT NULL COMMENT '事件JSON，retract 为空',
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+        KEY idx_chain_id (chain_id, id),
+        KEY idx_created_at (created_at)
+) ENGINE=InnoDB COMMENT='事件发件箱（与事件写入同一事务）';
+
+-- ================================
+-- 10. 发件箱消费进度
+-- ================================
+CREATE TABLE outbox_checkpoints (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT COMMENT '主键',
+        consumer VARCHAR(64) NOT NULL COMMENT '消费者名称',
+        chain_id BIGINT NOT NULL COMMENT '链ID',
+        last_id BIGINT NOT NULL COMMENT '已处理的最大发件箱ID',
+        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+        UNIQUE KEY uk_consumer_chain (consumer, chain_id)
+) ENGINE=InnoDB COMMENT='发件箱消费进度';
+
+-- ================================
+-- 11. 用户持仓快照（历史持仓查询用）
+-- ================================
+CREATE TABLE staking_position_snapshots (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT COMMENT '主键',
+        chain_id BIGINT NOT NULL COMMENT '链ID',
+        contract_address VARCHAR(42) NOT NULL COMMENT '合约地址',
+        pool_id BIGINT NOT NULL COMMENT 'Pool ID',
+        user_address VARCHAR(42) NOT NULL COMMENT '用户地址',
+        block_number BIGINT NOT NULL COMMENT '快照区块，包含该区块及之前的已确认事件',
+        staked_amount DECIMAL(38,0) NOT NULL COMMENT '质押数量',
+        pending_unstake DECIMAL(38,0) NOT NULL COMMENT '已申请赎回未提取数量',
+        claimed_amount DECIMAL(38,0) NOT NULL COMMENT '累计领取奖励',
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+        UNIQUE KEY uk_user_pool_block (chain_id, contract_address, user_address, pool_id, block_number)
+) ENGINE=InnoDB COMMENT='用户持仓快照';
+
+-- ================================
+-- 12. 管理任务（区间重扫等）
+-- ================================
+CREATE TABLE admin_jobs (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT COMMENT '主键',
+        kind VARCHAR(16) NOT NULL COMMENT '任务类型：rescan',
+        chain_id BIGINT NOT NULL COMMENT '链ID',
+        contract_address VARCHAR(42) NOT NULL COMMENT '合约地址',
+        from_block BIGINT NOT NULL COMMENT '起始区块（含）',
+        to_block BIGINT NOT NULL COMMENT '结束区块（含）',
+        current_block BIGINT NOT NULL COMMENT '已处理到的区块',
+        status VARCHAR(16) NOT NULL COMMENT '任务状态：pending / running / done / failed',
+        error VARCHAR(512) NOT NULL COMMENT '失败原因',
+        created_by VARCHAR(64) NOT NULL COMMENT '创建人',
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+        finished_at TIMESTAMP NULL COMMENT '结束时间',
+        KEY idx_status (status, updated_at)
+) ENGINE=InnoDB COMMENT='管理任务';
+
+-- ================================
+-- 13. 管理操作审计
+-- ================================
+CREATE TABLE admin_audit_logs (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT COMMENT '主键',
+        actor VARCHAR(64) NOT NULL COMMENT '操作人（令牌名称）',
+        action VARCHAR(32) NOT NULL COMMENT '操作：pause / resume / rewind / rescan / backfill / reindex / rebuild / redecode / reconcile / cursor_set',
+        chain_id BIGINT NOT NULL COMMENT '链ID',
+        contract_address VARCHAR(42) NOT NULL COMMENT '合约地址',
+        params TEXT NOT NULL COMMENT '请求参数JSON',
+        result VARCHAR(16) NOT NULL COMMENT '结果：ok / error',
+        error VARCHAR(512) NOT NULL COMMENT '失败原因',
+        remote_addr VARCHAR(64) NOT NULL COMMENT '请求来源地址',
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '操作时间',
+        KEY idx_contract (chain_id, contract_address),
+        KEY idx_created_at (created_at)
+) ENGINE=InnoDB COMMENT='管理操作审计';
+
+-- ================================
+-- 14. 原始日志归档
+-- ================================
+CREATE TABLE raw_logs (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT COMMENT '主键',
+        chain_id BIGINT NOT NULL COMMENT '链ID',
+        contract_address VARCHAR(42) NOT NULL COMMENT '合约地址',
+        block_number BIGINT NOT NULL COMMENT '区块高度',
+        block_hash VARCHAR(66) NOT NULL COMMENT '区块Hash',
+        tx_hash VARCHAR(66) NOT NULL COMMENT '交易Hash',
+        tx_index INT NOT NULL COMMENT '交易在区块内的索引',
+        log_index INT NOT NULL COMMENT '日志索引',
+        topic0 VARCHAR(66) NOT NULL COMMENT '事件签名，匿名事件为空',
+        topics VARCHAR(280) NOT NULL COMMENT '全部topic，逗号分隔',
+        data MEDIUMTEXT NOT NULL COMMENT '日志数据（十六进制）',
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+        UNIQUE KEY uk_chain_tx_log (chain_id, tx_hash, log_index),
+        KEY idx_contract_block (chain_id, contract_address, block_number, log_index)
+) ENGINE=InnoDB COMMENT='合约原始日志归档';
+
+-- ================================
+-- 15. 链上对账差异
+-- ================================
+CREATE TABLE reconcile_drifts (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT COMMENT '主键',
+        chain_id BIGINT NOT NULL COMMENT '链ID',
+        contract_address VARCHAR(42) NOT NULL COMMENT '合约地址',
+        block_number BIGINT NOT NULL COMMENT '对账区块',
+        pool_id BIGINT NOT NULL COMMENT 'Pool ID，pool_count 为 -1',
+        user_address VARCHAR(42) NOT NULL COMMENT '用户地址，质押池字段为空',
+        field VARCHAR(32) NOT NULL COMMENT '字段：pool_count / st_token_address / pool_weight / min_deposit_amount / unstake_locked_blocks / st_token_amount / staked_amount / pending_unstake',
+        indexed_value VARCHAR(80) NOT NULL COMMENT '索引值',
+        chain_value VARCHAR(80) NOT NULL COMMENT '链上值',
+        repaired TINYINT NOT NULL COMMENT '是否已自动修复：0-否 1-是',
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '发现时间',
+        KEY idx_contract_created (chain_id, contract_address, created_at)
+) ENGINE=InnoDB COMMENT='链上对账差异';
+
+-- ================================
+-- 16. 奖励参数
+-- ================================
+CREATE TABLE staking_reward_params (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT COMMENT '主键',
+        chain_id BIGINT NOT NULL COMMENT '链ID',
+        contract_address VARCHAR(42) NOT NULL COMMENT '合约地址',
+        block_number BIGINT NOT NULL COMMENT '读取参数的区块，之后的修改从 raw_logs 重放',
+        start_block BIGINT NOT NULL COMMENT '奖励开始区块',
+        end_block BIGINT NOT NULL COMMENT '奖励结束区块',
+        zero_token_per_block VARCHAR(78) NOT NULL COMMENT '每区块奖励（wei，十进制）',
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+        UNIQUE KEY uk_chain_contract (chain_id, contract_address)
+) ENGINE=InnoDB COMMENT='奖励参数';
+
+-- ================================
+-- 17. 每日领取奖励汇总
+-- ================================
+CREATE TABLE staking_claim_daily (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT COMMENT '主键',
+        chain_id BIGINT NOT NULL COMMENT '链ID',
+        contract_address VARCHAR(42) NOT NULL COMMENT '合约地址',
+        pool_id BIGINT NOT NULL COMMENT 'Pool ID',
+        day DATE NOT NULL COMMENT '领取所在区块的日期（UTC）',
+        claimed_amount DECIMAL(38,0) NOT NULL COMMENT '领取奖励数量',
+        claim_count INT NOT NULL COMMENT '领取次数',
+        updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+        UNIQUE KEY uk_pool_day (chain_id, contract_address, pool_id, day),
+        KEY idx_chain_day (chain_id, day)
+) ENGINE=InnoDB COMMENT='每日领取奖励汇总';
+
+-- ================================
+-- 18. 质押池总量历史（按区块）
+-- ================================
+CREATE TABLE staking_pool_history (
+        id BIGINT PRIMARY KEY AUTO_INCREMENT COMMENT '主键',
+        chain_id BIGINT NOT NULL COMMENT '链ID',
+        contract_address VARCHAR(42) NOT NULL COMMENT '合约地址',
+        pool_id BIGINT NOT NULL COMMENT 'Pool ID',
+        block_number BIGINT NOT NULL COMMENT '区块高度，记录该区块全部事件之后的总量',
+        st_token_amount DECIMAL(38,0) NOT NULL COMMENT '质押的代币数量',
+        pending_unstake DECIMAL(38,0) NOT NULL COMMENT '已申请赎回未提取数量',
+        staker_count BIGINT NOT NULL COMMENT '质押数量扣除待提取后大于 0 的用户数',
+        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+        UNIQUE KEY uk_pool_block (chain_id, contract_address, pool_id, block_number)
+) ENGINE=InnoDB COMMENT='质押池总量历史';
+
 SET FOREIGN_KEY_CHECKS = 1;